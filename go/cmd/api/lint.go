@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bizops360/go-api/internal/app"
+	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/infra/db"
+)
+
+// runPipelinesLint implements "api pipelines lint [-config DIR]". It validates
+// every pipeline and business YAML file against the registered actions and
+// returns the exit code: 0 when the config is valid, 1 when problems were
+// found and 2 when the config could not be read.
+func runPipelinesLint(args []string) int {
+	flags := flag.NewFlagSet("pipelines lint", flag.ContinueOnError)
+	configDir := flags.String("config", "", "config directory to lint (default: CONFIG_DIR)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg := config.LoadConfig()
+	if *configDir != "" {
+		cfg.ConfigDir = *configDir
+	}
+
+	loader := newValidatingLoader(cfg)
+	issues, err := app.LintConfig(context.Background(), loader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pipelines lint: %v\n", err)
+		return 2
+	}
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	if len(issues) > 0 {
+		fmt.Fprintf(os.Stderr, "%d problem(s) found in %s\n", len(issues), cfg.ConfigDir)
+		return 1
+	}
+	fmt.Printf("%s: OK\n", cfg.ConfigDir)
+	return 0
+}
+
+// newValidatingLoader returns a loader for the YAML config that validates
+// pipelines against the registered actions
+func newValidatingLoader(cfg *config.Config) *config.BusinessLoader {
+	loader := config.NewBusinessLoader(cfg)
+	timersService := app.NewTimersService(config.NewYAMLBusinessesRepo(loader), db.NewMemoryTimersRepo())
+	// Linting only needs the actions' schemas, not their services
+	runner := domain.NewPipelineRunner(newActions(timersService, actionIntegrations{}))
+	runner.SetPipelineLoader(config.NewYAMLPipelinesRepo(loader))
+	loader.SetPipelineValidator(runner)
+	return loader
+}
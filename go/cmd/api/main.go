@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,16 +15,33 @@ import (
 	"github.com/bizops360/go-api/internal/config"
 	"github.com/bizops360/go-api/internal/domain"
 	httphandler "github.com/bizops360/go-api/internal/http"
+	"github.com/bizops360/go-api/internal/infra/calendar"
 	"github.com/bizops360/go-api/internal/infra/db"
-	logger "github.com/bizops360/go-api/internal/infra/log"
+	"github.com/bizops360/go-api/internal/infra/email"
+	"github.com/bizops360/go-api/internal/infra/firestore"
+	"github.com/bizops360/go-api/internal/infra/geo"
+	logging "github.com/bizops360/go-api/internal/infra/log"
+	"github.com/bizops360/go-api/internal/infra/monday"
+	"github.com/bizops360/go-api/internal/infra/slack"
+	"github.com/bizops360/go-api/internal/infra/stripe"
+	"github.com/bizops360/go-api/internal/ports"
+	stripeService "github.com/bizops360/go-api/internal/services/stripe"
 )
 
 func main() {
+	// Subcommands; anything else starts the server
+	if len(os.Args) > 2 && os.Args[1] == "pipelines" && os.Args[2] == "lint" {
+		os.Exit(runPipelinesLint(os.Args[3:]))
+	}
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "import" {
+		os.Exit(runConfigImport(os.Args[3:]))
+	}
+
 	// Load configuration
 	cfg := config.LoadConfig()
 
 	// Initialize logger
-	logger := logger.NewLogger(cfg.LogLevel)
+	logger := logging.NewLogger(cfg.LogLevel)
 
 	logger.Info("starting server",
 		"port", cfg.Port,
@@ -32,28 +50,81 @@ func main() {
 		"templatesDir", cfg.TemplatesDir,
 	)
 
-	// Initialize business loader
+	// Initialize business loader and the business and pipeline config
+	// repositories
 	businessLoader := config.NewBusinessLoader(cfg)
+	configRepos, err := newConfigRepos(cfg, businessLoader)
+	if err != nil {
+		logger.Error("failed to initialize config repositories", "store", cfg.ConfigStore, "error", err)
+		os.Exit(1)
+	}
+	defer configRepos.close()
+	logger.Info("config repositories initialized", "store", cfg.ConfigStore)
 
-	// Initialize repositories (stub implementations for now)
-	jobsRepo := db.NewMemoryJobsRepo()
-
-	// Register pipeline actions (stub implementations)
-	actions := map[string]domain.Action{
-		"normalize_input":         &app.NormalizeInputAction{},
-		"send_slack_notification": &app.SendSlackNotificationAction{},
-		// Add more actions as they're implemented
+	// Initialize repositories
+	stores, err := newStores(cfg)
+	if err != nil {
+		logger.Error("failed to initialize jobs repository", "store", cfg.JobsStore, "error", err)
+		os.Exit(1)
 	}
+	defer stores.close()
+	jobsRepo := stores.jobs
+	logger.Info("jobs repository initialized", "store", cfg.JobsStore)
+
+	// Initialize timers service (used by the schedule_event_timers action)
+	timersService := app.NewTimersService(configRepos.businesses, stores.timers)
 
-	// Initialize pipeline runner
-	pipelineRunner := domain.NewPipelineRunner(actions)
+	// Initialize the built-in CRM, for businesses whose crm.provider is builtin
+	crmService := app.NewCRMService(configRepos.businesses, stores.crm)
+
+	// Initialize pipeline runner; pipelines are validated against its actions
+	// when they are loaded
+	pipelineRunner := domain.NewPipelineRunner(newActions(timersService, newActionIntegrations(logger, crmService)))
+	pipelineRunner.SetPipelineLoader(configRepos.pipelines)
+	configRepos.validated.SetPipelineValidator(pipelineRunner)
+	pipelineRunner.SetStepListener(app.NewSlowStepLogger(logging.NewSlogLogger(logger), cfg.SlowStepThreshold))
+
+	// Initialize worker pool for async pipeline jobs
+	workerPool := app.NewWorkerPool(cfg.JobWorkers, cfg.JobQueueSize)
+	workerPool.SetLogger(logger)
 
 	// Initialize services
-	formEventsService := app.NewFormEventsService(businessLoader, pipelineRunner, jobsRepo)
-	triggersService := app.NewTriggersService(businessLoader, pipelineRunner, jobsRepo)
+	formEventsService := app.NewFormEventsService(configRepos.businesses, configRepos.pipelines, pipelineRunner, jobsRepo, workerPool)
+	triggersService := app.NewTriggersService(configRepos.businesses, configRepos.pipelines, pipelineRunner, jobsRepo, workerPool)
+	jobsService := app.NewJobsService(configRepos.businesses, configRepos.pipelines, pipelineRunner, jobsRepo, workerPool)
+	if configRepos.loader != nil {
+		jobsService.SetConfigReloader(app.NewConfigReloader(configRepos.loader, logger, cfg.ConfigReloadInterval))
+	}
+
+	// Start the scheduler for business cron schedules and event timers
+	scheduler := app.NewScheduler(configRepos.businesses, triggersService, stores.timers, stores.locker, logger, cfg.SchedulerInterval)
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	switch {
+	case cfg.SchedulerEnabled && cfg.JobsStore == "memory":
+		go scheduler.Start(schedulerCtx)
+		logger.Warn("scheduler started with the in-process memory lock store; run a single instance, "+
+			"or set JOBS_STORE=sqlite or firestore so instances share locks", "interval", cfg.SchedulerInterval)
+	case cfg.SchedulerEnabled:
+		go scheduler.Start(schedulerCtx)
+		logger.Info("scheduler started", "interval", cfg.SchedulerInterval, "lockStore", cfg.JobsStore)
+	case cfg.JobsStore == "memory":
+		logger.Info("scheduler disabled: JOBS_STORE=memory has no lock store shared between instances; " +
+			"set JOBS_STORE=sqlite or firestore, or SCHEDULER_ENABLED=true for a single instance")
+	default:
+		logger.Info("scheduler disabled")
+	}
+
+	// Reload business and pipeline config when the files change
+	reloadCtx, stopReload := context.WithCancel(context.Background())
+	defer stopReload()
+	if configRepos.loader != nil && cfg.ConfigReloadInterval > 0 {
+		go app.NewConfigReloader(configRepos.loader, logger, cfg.ConfigReloadInterval).Start(reloadCtx)
+		logger.Info("config hot reload started", "interval", cfg.ConfigReloadInterval)
+	}
 
 	// Initialize router
-	router := httphandler.NewRouter(formEventsService, triggersService, businessLoader, logger, cfg.Environment)
+	router := httphandler.NewRouter(formEventsService, triggersService, jobsRepo, jobsService, scheduler, timersService, crmService, app.NewPipelineDocsService(configRepos.pipelines, pipelineRunner), stores.idempotency, cfg.IdempotencyTTL, configRepos.businesses, configRepos.loader, logger, cfg.Environment)
 
 	// Create HTTP server
 	// #region agent log
@@ -136,6 +207,7 @@ func main() {
 	<-quit
 
 	logger.Info("shutting down server")
+	stopScheduler()
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -146,5 +218,177 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Let queued async jobs finish within the same shutdown budget
+	if err := workerPool.Shutdown(ctx); err != nil {
+		logger.Error("worker pool shutdown error", "error", err)
+	}
+
 	logger.Info("server stopped")
 }
+
+// newActions returns the registered pipeline actions
+func newActions(timersService *app.TimersService, integrations actionIntegrations) map[string]domain.Action {
+	return map[string]domain.Action{
+		"normalize_input":         &app.NormalizeInputAction{},
+		"schedule_event_timers":   app.NewScheduleEventTimersAction(timersService),
+		"send_slack_notification": app.NewSendSlackNotificationAction(integrations.notifiers),
+		"calculate_estimate":      &app.CalculateEstimateAction{},
+		"create_deposit_invoice":  app.NewCreateDepositInvoiceAction(integrations.invoices),
+		"create_final_invoice":    app.NewCreateFinalInvoiceAction(integrations.invoices),
+		"void_invoice":            app.NewVoidInvoiceAction(integrations.invoices),
+		"create_calendar_event":   app.NewCreateCalendarEventAction(integrations.calendar),
+		"geocode_location":        app.NewGeocodeLocationAction(integrations.geocoder),
+		"send_quote_email":        app.NewSendQuoteEmailAction(integrations.mailer),
+		"create_crm_deal":         app.NewCreateCRMDealAction(integrations.crms),
+		"update_crm_deal":         app.NewUpdateCRMDealAction(integrations.crms),
+	}
+}
+
+// actionIntegrations holds the external services pipeline actions call. An
+// action whose service is nil skips or fails its step.
+type actionIntegrations struct {
+	invoices  *stripeService.InvoiceService
+	calendar  app.EventCalendar
+	geocoder  app.Geocoder
+	mailer    ports.Mailer
+	notifiers ports.BusinessNotifiers
+	crms      ports.BusinessCRMs
+}
+
+// newActionIntegrations connects to the services that are configured in the
+// environment
+func newActionIntegrations(logger *slog.Logger, crmService *app.CRMService) actionIntegrations {
+	integrations := actionIntegrations{
+		invoices: stripeService.NewInvoiceService(stripe.NewStripePayments()),
+		// Each business names its webhook in slack.webhookEnv
+		notifiers: slack.NewNotifiers(),
+		// Each business keeps its deals in the CRM crm.provider names; Monday.com
+		// businesses name their API token in monday.apiTokenEnv
+		crms: app.NewCRMProviders(crmService, monday.NewCRMs()),
+	}
+
+	if calendarService, err := calendar.NewCalendarService(os.Getenv("ESTIMATE_SENT_CALENDAR_ID")); err == nil {
+		integrations.calendar = calendarService
+	} else {
+		logger.Warn("calendar not available, create_calendar_event will be skipped", "error", err)
+	}
+
+	if geocoder, err := geo.NewGeocodingService(); err == nil {
+		integrations.geocoder = geocoder
+	} else {
+		logger.Warn("geocoding not available, geocode_location will be skipped", "error", err)
+	}
+
+	// Prefer the email service, as the lead handler does, then Gmail
+	if emailClient := email.NewEmailServiceClient(); emailClient != nil {
+		integrations.mailer = emailClient
+	} else if gmailSender, err := email.NewGmailSender(); err == nil {
+		integrations.mailer = gmailSender
+	} else {
+		logger.Warn("email not available, send_quote_email will be skipped", "error", err)
+	}
+
+	return integrations
+}
+
+// stores holds the persistence backends selected by cfg.JobsStore
+type stores struct {
+	jobs        ports.JobsRepo
+	timers      ports.TimersRepo
+	locker      ports.Locker
+	idempotency ports.IdempotencyStore
+	crm         ports.CRMStore
+	// close releases any underlying client
+	close func()
+}
+
+// newStores creates the jobs and timers repositories, the scheduler lock store,
+// the idempotency store and the built-in CRM store selected by cfg.JobsStore
+func newStores(cfg *config.Config) (*stores, error) {
+	switch cfg.JobsStore {
+	case "", "memory":
+		return &stores{
+			jobs:        db.NewMemoryJobsRepo(),
+			timers:      db.NewMemoryTimersRepo(),
+			locker:      db.NewMemoryLocker(),
+			idempotency: db.NewMemoryIdempotencyStore(),
+			crm:         db.NewMemoryCRMStore(),
+			close:       func() {},
+		}, nil
+	case "sqlite":
+		sqlDB, err := db.OpenSQLite(context.Background(), cfg.SQLitePath)
+		if err != nil {
+			return nil, err
+		}
+		return &stores{
+			jobs:        db.NewSQLiteJobsRepo(sqlDB),
+			timers:      db.NewSQLiteTimersRepo(sqlDB),
+			locker:      db.NewSQLiteLocker(sqlDB),
+			idempotency: db.NewSQLiteIdempotencyStore(sqlDB),
+			crm:         db.NewSQLiteCRMStore(sqlDB),
+			close:       func() { sqlDB.Close() },
+		}, nil
+	case "firestore":
+		client, err := firestore.NewClient(context.Background(), "")
+		if err != nil {
+			return nil, err
+		}
+		return &stores{
+			jobs:        firestore.NewFirestoreJobsRepo(client),
+			timers:      firestore.NewFirestoreTimersRepo(client),
+			locker:      firestore.NewFirestoreLocker(client),
+			idempotency: firestore.NewFirestoreIdempotencyStore(client),
+			crm:         firestore.NewFirestoreCRMStore(client),
+			close:       func() { client.Close() },
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown JOBS_STORE %q (expected memory, sqlite or firestore)", cfg.JobsStore)
+	}
+}
+
+// configRepos holds the business and pipeline config repositories selected by
+// cfg.ConfigStore
+type configRepos struct {
+	businesses ports.BusinessesRepo
+	pipelines  ports.PipelinesRepo
+	// loader is the loader behind the YAML repositories, for hot reload; nil
+	// when the config is stored in a database
+	loader *config.BusinessLoader
+	// validated validates the pipelines that pipelines returns and saves
+	validated interface {
+		SetPipelineValidator(validator config.PipelineValidator)
+	}
+	// close releases any underlying client
+	close func()
+}
+
+// newConfigRepos creates the business and pipeline config repositories
+// selected by cfg.ConfigStore. The YAML repositories read through loader.
+func newConfigRepos(cfg *config.Config, loader *config.BusinessLoader) (*configRepos, error) {
+	switch cfg.ConfigStore {
+	case "", "yaml":
+		return &configRepos{
+			businesses: config.NewYAMLBusinessesRepo(loader),
+			pipelines:  config.NewYAMLPipelinesRepo(loader),
+			loader:     loader,
+			validated:  loader,
+			close:      func() {},
+		}, nil
+	case "firestore":
+		client, err := firestore.NewClient(context.Background(), "")
+		if err != nil {
+			return nil, err
+		}
+		// Database configs are validated like the YAML files
+		stored := firestore.NewFirestorePipelinesRepo(client)
+		pipelines := config.NewValidatingPipelinesRepo(stored)
+		return &configRepos{
+			businesses: config.NewValidatingBusinessesRepo(firestore.NewFirestoreBusinessesRepo(client), stored),
+			pipelines:  pipelines,
+			validated:  pipelines,
+			close:      func() { client.Close() },
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown CONFIG_STORE %q (expected yaml or firestore)", cfg.ConfigStore)
+	}
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+// decodeActionConfig decodes the running action's config into the struct v
+// points to, matching keys to its json tags. Each of fieldDefaults missing
+// from the config is read from the pipeline field of the same name, so
+// pipelines only configure what differs from the lead's fields. Config that
+// does not decode fails the same way on every attempt and is reported as
+// permanent.
+func decodeActionConfig(ctx context.Context, pctx *domain.PipelineContext, v any, fieldDefaults ...string) error {
+	config := domain.ActionConfig(ctx)
+	values := make(map[string]any, len(config)+len(fieldDefaults))
+	for key, value := range config {
+		values[key] = value
+	}
+	for _, key := range fieldDefaults {
+		if _, ok := values[key]; !ok && pctx.Fields[key] != nil {
+			values[key] = pctx.Fields[key]
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return domain.Permanent(fmt.Errorf("invalid config: %w", err))
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return domain.Permanent(fmt.Errorf("invalid config: %w", err))
+	}
+	return nil
+}
+
+// missingConfig is the error for a required config key without a value
+func missingConfig(key string) error {
+	return domain.Permanent(fmt.Errorf("%s is required", key))
+}
+
+// stepOutputs returns typed outputs, a struct with json tags, as step
+// Outputs. They take the form they will have once the job is stored, so a
+// reference to them resolves the same way in a resumed job.
+func stepOutputs(v any) map[string]any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var outputs map[string]any
+	if err := json.Unmarshal(data, &outputs); err != nil {
+		return nil
+	}
+	return outputs
+}
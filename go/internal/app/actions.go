@@ -2,46 +2,149 @@ package app
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
 )
 
-// NormalizeInputAction normalizes input fields
-type NormalizeInputAction struct{}
-
-func (a *NormalizeInputAction) Name() string {
-	return "normalize_input"
+// SendSlackNotificationConfig is the config of send_slack_notification
+type SendSlackNotificationConfig struct {
+	Channel string                    `json:"channel"`
+	Title   string                    `json:"title"`
+	Message string                    `json:"message"`
+	Color   string                    `json:"color"`
+	Fields  []ports.NotificationField `json:"fields"`
 }
 
-func (a *NormalizeInputAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
-	// Stub implementation - just log and mark as ok
-	return domain.JobStep{
-		Name:     a.Name(),
-		Status:   "ok",
-		Critical: false,
-		Details: map[string]any{
-			"message": "input normalized (stub)",
-			"fields":  pctx.Fields,
-		},
-	}
+// SendSlackNotificationAction sends a Slack notification
+type SendSlackNotificationAction struct {
+	notifiers ports.BusinessNotifiers
 }
 
-// SendSlackNotificationAction sends a Slack notification
-type SendSlackNotificationAction struct{}
+// NewSendSlackNotificationAction creates the send_slack_notification action.
+// With nil notifiers the action is skipped.
+func NewSendSlackNotificationAction(notifiers ports.BusinessNotifiers) *SendSlackNotificationAction {
+	return &SendSlackNotificationAction{notifiers: notifiers}
+}
 
 func (a *SendSlackNotificationAction) Name() string {
 	return "send_slack_notification"
 }
 
+func (a *SendSlackNotificationAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Posts a message to the business Slack channel",
+		Config: map[string]domain.ConfigField{
+			"channel": {Type: domain.ConfigTypeString, Description: "Channel to post to, e.g. #leads (default: the webhook's channel)"},
+			"title":   {Type: domain.ConfigTypeString, Description: "Header of the message"},
+			"message": {Type: domain.ConfigTypeString, Description: "Message text, in Slack mrkdwn"},
+			"color":   {Type: domain.ConfigTypeString, Description: "Color bar beside the message: good, warning, danger or a hex color"},
+			"fields":  {Type: domain.ConfigTypeList, Description: "Fields shown under the message, each with title, value and short (side by side)"},
+		},
+	}
+}
+
 func (a *SendSlackNotificationAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
-	// Stub implementation - just log and mark as skipped for now
+	if step, skip := a.skip(pctx); skip {
+		return step
+	}
+	config, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	notifier, err := a.notifiers.ForBusiness(pctx.Business)
+	if err != nil {
+		return domain.FailedStep(a.Name(), domain.Permanent(err))
+	}
+	result, err := notifier.SendNotification(ctx, &ports.NotificationRequest{
+		Channel: config.Channel,
+		Title:   config.Title,
+		Message: config.Message,
+		Color:   config.Color,
+		Fields:  config.Fields,
+	})
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	if !result.Success {
+		message := "slack notification failed"
+		if result.Error != nil {
+			message = *result.Error
+		}
+		return domain.FailedStep(a.Name(), fmt.Errorf("%s", message))
+	}
+
 	return domain.JobStep{
-		Name:     a.Name(),
-		Status:   "skipped",
-		Critical: false,
+		Name:   a.Name(),
+		Status: "ok",
 		Details: map[string]any{
-			"message": "slack notification skipped (stub)",
+			"message": "posted to " + channelOrDefault(config.Channel),
 		},
 	}
 }
 
+// Plan returns the message Execute would post
+func (a *SendSlackNotificationAction) Plan(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if step, skip := a.skip(pctx); skip {
+		return step
+	}
+	config, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		SideEffects: []domain.SideEffect{{
+			Kind:    "slack_message",
+			Summary: "Post to " + channelOrDefault(config.Channel),
+			Details: map[string]any{
+				"channel": config.Channel,
+				"title":   config.Title,
+				"message": config.Message,
+				"fields":  config.Fields,
+			},
+		}},
+	}
+}
+
+// skip returns the skipped step when there is nowhere to post
+func (a *SendSlackNotificationAction) skip(pctx *domain.PipelineContext) (domain.JobStep, bool) {
+	message := ""
+	switch {
+	case a.notifiers == nil:
+		message = "slack is not configured"
+	case pctx.Business == nil || !pctx.Business.Slack.Enabled:
+		message = "slack is not enabled for this business"
+	default:
+		return domain.JobStep{}, false
+	}
+	return domain.JobStep{
+		Name:    a.Name(),
+		Status:  "skipped",
+		Details: map[string]any{"message": message},
+	}, true
+}
+
+// config reads and checks the config
+func (a *SendSlackNotificationAction) config(ctx context.Context, pctx *domain.PipelineContext) (SendSlackNotificationConfig, error) {
+	var config SendSlackNotificationConfig
+	if err := decodeActionConfig(ctx, pctx, &config); err != nil {
+		return config, err
+	}
+	if config.Message == "" && config.Title == "" {
+		return config, missingConfig("message")
+	}
+	return config, nil
+}
+
+// channelOrDefault names the channel of a notification for people
+func channelOrDefault(channel string) string {
+	if channel == "" {
+		return "the business Slack channel"
+	}
+	return channel
+}
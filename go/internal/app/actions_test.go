@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// fakeNotifiers is a ports.BusinessNotifiers whose notifier records the
+// notifications it sends
+type fakeNotifiers struct {
+	sent []*ports.NotificationRequest
+	fail error
+}
+
+func (n *fakeNotifiers) ForBusiness(business *domain.BusinessConfig) (ports.Notifier, error) {
+	if business.Slack.WebhookEnv == "" {
+		return nil, fmt.Errorf("slack.webhookEnv is not set for business %s", business.ID)
+	}
+	return n, nil
+}
+
+func (n *fakeNotifiers) SendNotification(ctx context.Context, req *ports.NotificationRequest) (*ports.NotificationResult, error) {
+	if n.fail != nil {
+		return nil, n.fail
+	}
+	n.sent = append(n.sent, req)
+	return &ports.NotificationResult{Success: true}, nil
+}
+
+func TestSendSlackNotificationAction(t *testing.T) {
+	business := &domain.BusinessConfig{ID: "stlpartyhelpers", Slack: domain.SlackConfig{Enabled: true, WebhookEnv: "SLACK_WEBHOOK_URL"}}
+	config := map[string]any{
+		"channel": "#leads",
+		"title":   "New lead",
+		"message": "Jane asked for a quote",
+		"fields":  []any{map[string]any{"title": "Event date", "value": "2027-06-12", "short": true}},
+	}
+
+	tests := []struct {
+		name       string
+		notifiers  *fakeNotifiers
+		business   *domain.BusinessConfig
+		config     map[string]any
+		wantStatus string
+		wantSent   int
+	}{
+		{name: "posts the message", notifiers: &fakeNotifiers{}, business: business, config: config, wantStatus: "ok", wantSent: 1},
+		{name: "slack disabled", notifiers: &fakeNotifiers{}, business: &domain.BusinessConfig{ID: "acme"}, config: config, wantStatus: "skipped"},
+		{name: "no webhook configured", notifiers: &fakeNotifiers{}, business: &domain.BusinessConfig{ID: "acme", Slack: domain.SlackConfig{Enabled: true}}, config: config, wantStatus: "failed"},
+		{name: "post fails", notifiers: &fakeNotifiers{fail: fmt.Errorf("slack returned 429, retry after 1s")}, business: business, config: config, wantStatus: "failed"},
+		{name: "no message", notifiers: &fakeNotifiers{}, business: business, config: map[string]any{"channel": "#leads"}, wantStatus: "failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := NewSendSlackNotificationAction(tt.notifiers)
+			ctx := domain.WithActionConfig(context.Background(), tt.config)
+			step := action.Execute(ctx, &domain.PipelineContext{Business: tt.business})
+
+			if step.Status != tt.wantStatus {
+				t.Fatalf("expected status %s, got %+v", tt.wantStatus, step)
+			}
+			if len(tt.notifiers.sent) != tt.wantSent {
+				t.Fatalf("expected %d notification(s), got %d", tt.wantSent, len(tt.notifiers.sent))
+			}
+			if tt.wantSent == 0 {
+				return
+			}
+			sent := tt.notifiers.sent[0]
+			if sent.Channel != "#leads" || sent.Title != "New lead" || len(sent.Fields) != 1 || !sent.Fields[0].Short {
+				t.Errorf("unexpected notification: %+v", sent)
+			}
+		})
+	}
+
+	step := (&SendSlackNotificationAction{}).Execute(domain.WithActionConfig(context.Background(), config), &domain.PipelineContext{Business: business})
+	if step.Status != "skipped" {
+		t.Errorf("expected the action to be skipped without notifiers, got %+v", step)
+	}
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/services/pricing"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// CalculateEstimateConfig is the config of calculate_estimate
+type CalculateEstimateConfig struct {
+	EventDate     string  `json:"eventDate"`
+	DurationHours float64 `json:"durationHours"`
+	NumHelpers    int     `json:"numHelpers"`
+}
+
+// CalculateEstimateOutputs are the outputs of calculate_estimate
+type CalculateEstimateOutputs struct {
+	TotalCost             float64 `json:"totalCost"`
+	TotalCents            int64   `json:"totalCents"`
+	BasePerHelper         float64 `json:"basePerHelper"`
+	ExtraPerHourPerHelper float64 `json:"extraPerHourPerHelper"`
+	IsSpecialDate         bool    `json:"isSpecialDate"`
+	RateLabel             string  `json:"rateLabel"`
+	Currency              string  `json:"currency"`
+	Summary               string  `json:"summary"`
+}
+
+// CalculateEstimateAction prices an event with pricing.CalculateEstimate
+type CalculateEstimateAction struct{}
+
+func (a *CalculateEstimateAction) Name() string {
+	return "calculate_estimate"
+}
+
+// SideEffectFree marks the action as safe to run again when a job is resumed
+func (a *CalculateEstimateAction) SideEffectFree() bool {
+	return true
+}
+
+func (a *CalculateEstimateAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Prices the event from its date, duration and number of helpers",
+		Config: map[string]domain.ConfigField{
+			"eventDate":     {Type: domain.ConfigTypeString, Description: "Event date (default: the eventDate field)"},
+			"durationHours": {Type: domain.ConfigTypeNumber, Description: "Event length in hours (default: the durationHours field)"},
+			"numHelpers":    {Type: domain.ConfigTypeInteger, Description: "Helpers requested (default: the numHelpers field)"},
+		},
+	}
+}
+
+func (a *CalculateEstimateAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	var config CalculateEstimateConfig
+	if err := decodeActionConfig(ctx, pctx, &config, "eventDate", "durationHours", "numHelpers"); err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	if config.EventDate == "" {
+		return domain.FailedStep(a.Name(), missingConfig("eventDate"))
+	}
+	eventDate, err := util.ParseDate(config.EventDate)
+	if err != nil {
+		return domain.FailedStep(a.Name(), domain.Permanent(err))
+	}
+
+	estimate, err := pricing.CalculateEstimate(eventDate, config.DurationHours, config.NumHelpers)
+	if err != nil {
+		return domain.FailedStep(a.Name(), domain.Permanent(err))
+	}
+
+	rateLabel := "Base Rate"
+	if estimate.SpecialLabel != nil {
+		rateLabel = *estimate.SpecialLabel
+	}
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": fmt.Sprintf("estimate $%.2f for %d helper(s), %g hour(s)", estimate.TotalCost, config.NumHelpers, config.DurationHours),
+		},
+		Outputs: stepOutputs(CalculateEstimateOutputs{
+			TotalCost:             estimate.TotalCost,
+			TotalCents:            util.DollarsToCents(estimate.TotalCost),
+			BasePerHelper:         estimate.BasePerHelper,
+			ExtraPerHourPerHelper: estimate.ExtraPerHourPerHelper,
+			IsSpecialDate:         estimate.IsSpecialDate,
+			RateLabel:             rateLabel,
+			Currency:              estimate.Currency,
+			Summary:               estimate.CalculationSummary,
+		}),
+	}
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/services/pricing"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+func TestCalculateEstimateAction(t *testing.T) {
+	eventDate := time.Date(2027, time.June, 12, 0, 0, 0, 0, time.UTC)
+	want, err := pricing.CalculateEstimate(eventDate, 5, 3)
+	if err != nil {
+		t.Fatalf("CalculateEstimate failed: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		fields        map[string]any
+		config        map[string]any
+		wantStatus    string
+		wantPermanent bool
+	}{
+		{
+			name:       "reads the lead fields",
+			fields:     map[string]any{"eventDate": "2027-06-12", "durationHours": 5.0, "numHelpers": 3},
+			wantStatus: "ok",
+		},
+		{
+			name:       "config overrides fields",
+			fields:     map[string]any{"eventDate": "2027-06-12", "durationHours": 2.0, "numHelpers": 1},
+			config:     map[string]any{"durationHours": 5, "numHelpers": float64(3)},
+			wantStatus: "ok",
+		},
+		{
+			name:          "missing event date",
+			fields:        map[string]any{"durationHours": 5.0, "numHelpers": 3},
+			wantStatus:    "failed",
+			wantPermanent: true,
+		},
+		{
+			name:          "no helpers",
+			fields:        map[string]any{"eventDate": "2027-06-12", "durationHours": 5.0, "numHelpers": 0},
+			wantStatus:    "failed",
+			wantPermanent: true,
+		},
+		{
+			name:          "wrong type",
+			fields:        map[string]any{"eventDate": "2027-06-12", "durationHours": 5.0},
+			config:        map[string]any{"numHelpers": "three"},
+			wantStatus:    "failed",
+			wantPermanent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := &CalculateEstimateAction{}
+			ctx := domain.WithActionConfig(context.Background(), tt.config)
+			step := action.Execute(ctx, &domain.PipelineContext{Fields: tt.fields})
+
+			if step.Status != tt.wantStatus || step.Permanent != tt.wantPermanent {
+				t.Fatalf("expected status %s (permanent %v), got %+v", tt.wantStatus, tt.wantPermanent, step)
+			}
+			if step.Status != "ok" {
+				return
+			}
+			if step.Outputs["totalCost"] != want.TotalCost || step.Outputs["totalCents"] != float64(util.DollarsToCents(want.TotalCost)) {
+				t.Errorf("expected total %.2f, got outputs %+v", want.TotalCost, step.Outputs)
+			}
+			if step.Outputs["rateLabel"] == "" || step.Outputs["basePerHelper"] != want.BasePerHelper {
+				t.Errorf("expected the rate in the outputs, got %+v", step.Outputs)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+// LintIssue is one problem found in a configuration file
+type LintIssue struct {
+	// File is the path relative to the config directory
+	File    string `json:"file"`
+	Message string `json:"message"`
+}
+
+func (i LintIssue) String() string {
+	return i.File + ": " + i.Message
+}
+
+// LintConfig loads every pipeline and business YAML file through the loader
+// and returns every problem found. The loader should have a pipeline validator
+// set so that action names and configs are checked. Pipelines are checked
+// first, then businesses, each in file name order.
+func LintConfig(ctx context.Context, loader *config.BusinessLoader) ([]LintIssue, error) {
+	issues := []LintIssue{}
+	addErr := func(file string, err error) {
+		var invalid *domain.ValidationError
+		if !errors.As(err, &invalid) {
+			issues = append(issues, LintIssue{File: file, Message: err.Error()})
+			return
+		}
+		for _, problem := range invalid.Problems {
+			issues = append(issues, LintIssue{File: file, Message: problem})
+		}
+	}
+
+	pipelineKeys, err := loader.ListPipelineKeys()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, key := range pipelineKeys {
+		file := filepath.Join("pipelines", key+".yaml")
+		pipeline, err := loader.LoadPipeline(ctx, key)
+		if err != nil {
+			addErr(file, err)
+			continue
+		}
+		if pipeline.Key != key {
+			issues = append(issues, LintIssue{File: file, Message: fmt.Sprintf("key '%s' does not match the file name", pipeline.Key)})
+		}
+	}
+
+	businessIDs, err := loader.ListBusinessIDs()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range businessIDs {
+		file := filepath.Join("businesses", id+".yaml")
+		business, err := loader.LoadBusiness(ctx, id)
+		if err != nil {
+			addErr(file, err)
+			continue
+		}
+		for _, sc := range business.Pipelines.Schedules {
+			if _, _, err := parseSchedule(business, sc); err != nil {
+				addErr(file, fmt.Errorf("pipelines.schedules: %w", err))
+			}
+		}
+		for _, def := range business.Pipelines.EventTimers {
+			if _, ok := business.Pipelines.Triggers[def.Trigger]; !ok {
+				addErr(file, fmt.Errorf("pipelines.eventTimers.%s: trigger '%s' not found in business config", def.Key, def.Trigger))
+			}
+		}
+	}
+
+	return issues, nil
+}
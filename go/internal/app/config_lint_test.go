@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+func TestLintConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name: "valid",
+			files: map[string]string{
+				"businesses/stlpartyhelpers.yaml":  "id: stlpartyhelpers\npipelines:\n  defaultForm: quote_and_deposit\n  triggers:\n    resend_deposit_link: quote_and_deposit\n",
+				"pipelines/quote_and_deposit.yaml": "key: quote_and_deposit\nactions:\n  - name: normalize_input\n  - name: send_slack_notification\n    config:\n      channel: \"#leads\"\n",
+			},
+			want: []string{},
+		},
+		{
+			name: "invalid",
+			files: map[string]string{
+				"businesses/stlpartyhelpers.yaml":  "id: stlpartyhelpers\npipelines:\n  triggers:\n    resend_deposit_link: deposit_only\n",
+				"businesses/other.yaml":            "id: other\npipelines:\n  triggers:\n    resend_deposit_link: quote_and_deposit\n  eventTimers:\n    - key: deposit_reminder\n      trigger: send_reminder\n",
+				"pipelines/quote_and_deposit.yaml": "key: quote_and_deposit\nactions:\n  - name: normalize_inpt\n  - name: send_slack_notification\n    config:\n      chanel: \"#leads\"\n",
+				"pipelines/renewal.yaml":           "key: renewal_followup\nactions:\n  - name: normalize_input\n",
+			},
+			want: []string{
+				"pipelines/quote_and_deposit.yaml: actions[0] (normalize_inpt): action 'normalize_inpt' is not registered",
+				`pipelines/quote_and_deposit.yaml: actions[1] (send_slack_notification): unknown config key "chanel"`,
+				"pipelines/renewal.yaml: key 'renewal_followup' does not match the file name",
+				"businesses/other.yaml: pipelines.eventTimers.deposit_reminder: trigger 'send_reminder' not found in business config",
+				"businesses/stlpartyhelpers.yaml: pipelines.triggers.resend_deposit_link: pipeline 'deposit_only' not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := config.NewBusinessLoader(writeConfigFiles(t, tt.files))
+			loader.SetPipelineValidator(domain.NewPipelineRunner(map[string]domain.Action{
+				"normalize_input":         &NormalizeInputAction{},
+				"send_slack_notification": &SendSlackNotificationAction{},
+			}))
+
+			issues, err := LintConfig(context.Background(), loader)
+			if err != nil {
+				t.Fatalf("LintConfig failed: %v", err)
+			}
+			got := []string{}
+			for _, issue := range issues {
+				got = append(got, issue.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected issues %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bizops360/go-api/internal/config"
+)
+
+// DefaultConfigReloadInterval is how often the config directory is checked for
+// changes
+const DefaultConfigReloadInterval = 10 * time.Second
+
+// ConfigReloader polls the config directory and reloads business and pipeline
+// configuration when a file changes. A change that fails validation is logged
+// and the previous configuration stays in use.
+type ConfigReloader struct {
+	businessLoader *config.BusinessLoader
+	logger         *slog.Logger
+	interval       time.Duration
+}
+
+// NewConfigReloader creates a new config reloader
+func NewConfigReloader(businessLoader *config.BusinessLoader, logger *slog.Logger, interval time.Duration) *ConfigReloader {
+	if interval <= 0 {
+		interval = DefaultConfigReloadInterval
+	}
+	return &ConfigReloader{
+		businessLoader: businessLoader,
+		logger:         logger,
+		interval:       interval,
+	}
+}
+
+// Start loads the configuration and then checks for changes every interval
+// until ctx is cancelled
+func (r *ConfigReloader) Start(ctx context.Context) {
+	_ = r.Check(ctx)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = r.Check(ctx)
+		}
+	}
+}
+
+// Check reloads the configuration if any config file changed since the last
+// check. It returns the error of a rejected reload, in which case the previous
+// configuration stays in use.
+func (r *ConfigReloader) Check(ctx context.Context) error {
+	reloaded, err := r.businessLoader.ReloadIfChanged(ctx)
+	if err != nil {
+		r.logger.Error("config reload rejected, keeping the previous config", "error", err)
+		return err
+	}
+	if reloaded {
+		status := r.businessLoader.ReloadStatus()
+		r.logger.Info("config reloaded", "generation", status.Generation, "businesses", len(status.Businesses))
+	}
+	return nil
+}
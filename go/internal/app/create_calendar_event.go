@@ -0,0 +1,181 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/infra/calendar"
+)
+
+// EventCalendar creates calendar events; *calendar.CalendarService satisfies
+// it
+type EventCalendar interface {
+	CreateEvent(ctx context.Context, req *calendar.CreateEventRequest) (*calendar.CreateEventResult, error)
+}
+
+// CreateCalendarEventConfig is the config of create_calendar_event
+type CreateCalendarEventConfig struct {
+	ClientName    string  `json:"clientName"`
+	Email         string  `json:"email"`
+	Phone         string  `json:"phone"`
+	Occasion      string  `json:"occasion"`
+	GuestCount    int     `json:"guestCount"`
+	EventDate     string  `json:"eventDate"`
+	EventTime     string  `json:"eventTime"`
+	EventLocation string  `json:"eventLocation"`
+	NumHelpers    int     `json:"numHelpers"`
+	DurationHours float64 `json:"durationHours"`
+	TotalCost     float64 `json:"totalCost"`
+	CalendarID    string  `json:"calendarId"`
+	Status        string  `json:"status"`
+}
+
+// CreateCalendarEventOutputs are the outputs of create_calendar_event
+type CreateCalendarEventOutputs struct {
+	EventID string `json:"eventId"`
+}
+
+// calendarEventFields are the config keys of create_calendar_event that
+// default to the pipeline field of the same name
+var calendarEventFields = []string{"email", "phone", "occasion", "guestCount", "eventDate", "eventTime", "eventLocation", "numHelpers", "durationHours"}
+
+// CreateCalendarEventAction adds the event to the business calendar
+type CreateCalendarEventAction struct {
+	calendar EventCalendar
+}
+
+// NewCreateCalendarEventAction creates the create_calendar_event action. With
+// a nil calendar the action is skipped.
+func NewCreateCalendarEventAction(calendar EventCalendar) *CreateCalendarEventAction {
+	return &CreateCalendarEventAction{calendar: calendar}
+}
+
+func (a *CreateCalendarEventAction) Name() string {
+	return "create_calendar_event"
+}
+
+func (a *CreateCalendarEventAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Adds the event to the business Google Calendar",
+		Config: map[string]domain.ConfigField{
+			"clientName":    {Type: domain.ConfigTypeString, Description: "Client name in the event title"},
+			"email":         {Type: domain.ConfigTypeString, Description: "Client email (default: the email field)"},
+			"phone":         {Type: domain.ConfigTypeString, Description: "Client phone (default: the phone field)"},
+			"occasion":      {Type: domain.ConfigTypeString, Description: "Occasion (default: the occasion field)"},
+			"guestCount":    {Type: domain.ConfigTypeInteger, Description: "Guests expected (default: the guestCount field)"},
+			"eventDate":     {Type: domain.ConfigTypeString, Description: "YYYY-MM-DD event date (default: the eventDate field)"},
+			"eventTime":     {Type: domain.ConfigTypeString, Description: "Start time (default: the eventTime field)"},
+			"eventLocation": {Type: domain.ConfigTypeString, Description: "Event address (default: the eventLocation field)"},
+			"numHelpers":    {Type: domain.ConfigTypeInteger, Description: "Helpers booked (default: the numHelpers field)"},
+			"durationHours": {Type: domain.ConfigTypeNumber, Description: "Event length in hours (default: the durationHours field)"},
+			"totalCost":     {Type: domain.ConfigTypeNumber, Description: "Estimate total in dollars"},
+			"calendarId":    {Type: domain.ConfigTypeString, Description: "Calendar to add the event to (default: ESTIMATE_SENT_CALENDAR_ID)"},
+			"status":        {Type: domain.ConfigTypeString, Description: "Booking status in the event (default: Pending)"},
+		},
+	}
+}
+
+func (a *CreateCalendarEventAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if a.calendar == nil {
+		return a.notConfigured()
+	}
+	config, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	result, err := a.calendar.CreateEvent(ctx, &calendar.CreateEventRequest{
+		CalendarID: config.CalendarID,
+		ClientName: config.ClientName,
+		Occasion:   config.Occasion,
+		GuestCount: config.GuestCount,
+		EventDate:  config.EventDate,
+		EventTime:  config.EventTime,
+		Phone:      config.Phone,
+		Location:   config.EventLocation,
+		NumHelpers: config.NumHelpers,
+		Duration:   config.DurationHours,
+		TotalCost:  config.TotalCost,
+		EmailID:    config.Email,
+		DataSource: pctx.Source,
+		Status:     config.Status,
+	})
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	if result.Error != "" {
+		return domain.FailedStep(a.Name(), fmt.Errorf("%s", result.Error))
+	}
+
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": fmt.Sprintf("calendar event %s created", result.EventID),
+		},
+		Outputs: stepOutputs(CreateCalendarEventOutputs{EventID: result.EventID}),
+	}
+}
+
+// Plan returns the calendar event Execute would create
+func (a *CreateCalendarEventAction) Plan(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if a.calendar == nil {
+		return a.notConfigured()
+	}
+	config, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": "dry run, calendar event not created",
+		},
+		Outputs: stepOutputs(CreateCalendarEventOutputs{EventID: dryRunPlaceholder}),
+		SideEffects: []domain.SideEffect{{
+			Kind:    "calendar_event",
+			Summary: fmt.Sprintf("Add %s for %s on %s at %s to the calendar", config.Occasion, config.ClientName, config.EventDate, config.EventTime),
+			Details: map[string]any{
+				"calendarId":    config.CalendarID,
+				"clientName":    config.ClientName,
+				"occasion":      config.Occasion,
+				"eventDate":     config.EventDate,
+				"eventTime":     config.EventTime,
+				"durationHours": config.DurationHours,
+				"eventLocation": config.EventLocation,
+				"numHelpers":    config.NumHelpers,
+				"status":        config.Status,
+			},
+		}},
+	}
+}
+
+// config reads and checks the config
+func (a *CreateCalendarEventAction) config(ctx context.Context, pctx *domain.PipelineContext) (CreateCalendarEventConfig, error) {
+	var config CreateCalendarEventConfig
+	if err := decodeActionConfig(ctx, pctx, &config, calendarEventFields...); err != nil {
+		return config, err
+	}
+	if config.EventDate == "" {
+		return config, missingConfig("eventDate")
+	}
+	if config.EventTime == "" {
+		return config, missingConfig("eventTime")
+	}
+	if config.Status == "" {
+		config.Status = "Pending"
+	}
+	return config, nil
+}
+
+// notConfigured is the step when no calendar is configured
+func (a *CreateCalendarEventAction) notConfigured() domain.JobStep {
+	return domain.JobStep{
+		Name:    a.Name(),
+		Status:  "skipped",
+		Details: map[string]any{"message": "calendar is not configured"},
+	}
+}
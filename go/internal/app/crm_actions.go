@@ -0,0 +1,291 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// CreateCRMDealConfig is the config of create_crm_deal
+type CreateCRMDealConfig struct {
+	Board   string         `json:"board"`
+	BoardID int64          `json:"boardId"`
+	Name    string         `json:"name"`
+	Fields  map[string]any `json:"fields"`
+}
+
+// UpdateCRMDealConfig is the config of update_crm_deal
+type UpdateCRMDealConfig struct {
+	Board   string         `json:"board"`
+	BoardID int64          `json:"boardId"`
+	ItemID  int64          `json:"itemId"`
+	Fields  map[string]any `json:"fields"`
+}
+
+// CRMDealOutputs are the outputs of create_crm_deal and update_crm_deal
+type CRMDealOutputs struct {
+	BoardID int64 `json:"boardId"`
+	ItemID  int64 `json:"itemId"`
+}
+
+// CreateCRMDealAction creates a deal in the business CRM
+type CreateCRMDealAction struct {
+	crms ports.BusinessCRMs
+}
+
+// NewCreateCRMDealAction creates the create_crm_deal action. With nil CRMs
+// the action is skipped.
+func NewCreateCRMDealAction(crms ports.BusinessCRMs) *CreateCRMDealAction {
+	return &CreateCRMDealAction{crms: crms}
+}
+
+func (a *CreateCRMDealAction) Name() string {
+	return "create_crm_deal"
+}
+
+func (a *CreateCRMDealAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Creates a deal on a CRM board",
+		Config: map[string]domain.ConfigField{
+			"board":   {Type: domain.ConfigTypeString, Description: "Board name from crm.boards or monday.boards, e.g. deals (default: the triggering board)"},
+			"boardId": {Type: domain.ConfigTypeInteger, Description: "Board ID, instead of board"},
+			"name":    {Type: domain.ConfigTypeString, Required: true, Description: "Deal name"},
+			"fields":  {Type: domain.ConfigTypeObject, Description: "Deal fields; on Monday.com, written to the columns mapped in monday.columns"},
+		},
+	}
+}
+
+func (a *CreateCRMDealAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if step, skip := crmSkip(a.Name(), a.crms, pctx); skip {
+		return step
+	}
+	config, boardID, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	crm, err := a.crms.ForBusiness(pctx.Business)
+	if err != nil {
+		return domain.FailedStep(a.Name(), domain.Permanent(err))
+	}
+	result, err := crm.CreateDeal(ctx, &ports.CreateDealRequest{BoardID: boardID, Name: config.Name, Fields: config.Fields})
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	if !result.Success {
+		return domain.FailedStep(a.Name(), crmResultError(result.Error))
+	}
+
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": fmt.Sprintf("deal %d created on board %d", result.ItemID, boardID),
+		},
+		Outputs: stepOutputs(CRMDealOutputs{BoardID: boardID, ItemID: result.ItemID}),
+	}
+}
+
+// Plan returns the deal Execute would create
+func (a *CreateCRMDealAction) Plan(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if step, skip := crmSkip(a.Name(), a.crms, pctx); skip {
+		return step
+	}
+	config, boardID, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": "dry run, deal not created",
+		},
+		Outputs: map[string]any{"boardId": float64(boardID), "itemId": dryRunPlaceholder},
+		SideEffects: []domain.SideEffect{{
+			Kind:    "crm_deal",
+			Summary: fmt.Sprintf("Create deal %q on board %d", config.Name, boardID),
+			Details: map[string]any{
+				"boardId": boardID,
+				"name":    config.Name,
+				"fields":  config.Fields,
+			},
+		}},
+	}
+}
+
+// config reads and checks the config and resolves the board
+func (a *CreateCRMDealAction) config(ctx context.Context, pctx *domain.PipelineContext) (CreateCRMDealConfig, int64, error) {
+	var config CreateCRMDealConfig
+	if err := decodeActionConfig(ctx, pctx, &config); err != nil {
+		return config, 0, err
+	}
+	if config.Name == "" {
+		return config, 0, missingConfig("name")
+	}
+	boardID, err := crmBoardID(pctx, config.Board, config.BoardID)
+	return config, boardID, err
+}
+
+// UpdateCRMDealAction updates fields of a deal in the business CRM
+type UpdateCRMDealAction struct {
+	crms ports.BusinessCRMs
+}
+
+// NewUpdateCRMDealAction creates the update_crm_deal action. With nil CRMs
+// the action is skipped.
+func NewUpdateCRMDealAction(crms ports.BusinessCRMs) *UpdateCRMDealAction {
+	return &UpdateCRMDealAction{crms: crms}
+}
+
+func (a *UpdateCRMDealAction) Name() string {
+	return "update_crm_deal"
+}
+
+func (a *UpdateCRMDealAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Updates fields of a deal on a CRM board",
+		Config: map[string]domain.ConfigField{
+			"board":   {Type: domain.ConfigTypeString, Description: "Board name from crm.boards or monday.boards (default: the triggering board)"},
+			"boardId": {Type: domain.ConfigTypeInteger, Description: "Board ID, instead of board"},
+			"itemId":  {Type: domain.ConfigTypeInteger, Description: "Deal to update (default: the triggering item)"},
+			"fields":  {Type: domain.ConfigTypeObject, Required: true, Description: "Deal fields to write, e.g. status"},
+		},
+	}
+}
+
+func (a *UpdateCRMDealAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if step, skip := crmSkip(a.Name(), a.crms, pctx); skip {
+		return step
+	}
+	config, boardID, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	crm, err := a.crms.ForBusiness(pctx.Business)
+	if err != nil {
+		return domain.FailedStep(a.Name(), domain.Permanent(err))
+	}
+	result, err := crm.UpdateDeal(ctx, &ports.UpdateDealRequest{BoardID: boardID, ItemID: config.ItemID, Fields: config.Fields})
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	if !result.Success {
+		return domain.FailedStep(a.Name(), crmResultError(result.Error))
+	}
+
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": fmt.Sprintf("deal %d updated on board %d", config.ItemID, boardID),
+		},
+		Outputs: stepOutputs(CRMDealOutputs{BoardID: boardID, ItemID: config.ItemID}),
+	}
+}
+
+// Plan returns the update Execute would make
+func (a *UpdateCRMDealAction) Plan(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if step, skip := crmSkip(a.Name(), a.crms, pctx); skip {
+		return step
+	}
+	config, boardID, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": "dry run, deal not updated",
+		},
+		Outputs: stepOutputs(CRMDealOutputs{BoardID: boardID, ItemID: config.ItemID}),
+		SideEffects: []domain.SideEffect{{
+			Kind:    "crm_update",
+			Summary: fmt.Sprintf("Update deal %d on board %d", config.ItemID, boardID),
+			Details: map[string]any{
+				"boardId": boardID,
+				"itemId":  config.ItemID,
+				"fields":  config.Fields,
+			},
+		}},
+	}
+}
+
+// config reads and checks the config and resolves the board and item
+func (a *UpdateCRMDealAction) config(ctx context.Context, pctx *domain.PipelineContext) (UpdateCRMDealConfig, int64, error) {
+	var config UpdateCRMDealConfig
+	if err := decodeActionConfig(ctx, pctx, &config); err != nil {
+		return config, 0, err
+	}
+	if len(config.Fields) == 0 {
+		return config, 0, missingConfig("fields")
+	}
+	if config.ItemID == 0 && pctx.Resource != nil && pctx.Resource.ItemID != nil {
+		config.ItemID = *pctx.Resource.ItemID
+	}
+	if config.ItemID == 0 {
+		return config, 0, missingConfig("itemId")
+	}
+	boardID, err := crmBoardID(pctx, config.Board, config.BoardID)
+	return config, boardID, err
+}
+
+// crmBoardID resolves the board a CRM action works on: boardId, else the
+// board named in the business crm.boards (built-in CRM) or monday.boards,
+// else the board of the resource that triggered the pipeline
+func crmBoardID(pctx *domain.PipelineContext, board string, boardID int64) (int64, error) {
+	if boardID != 0 {
+		return boardID, nil
+	}
+	if board != "" {
+		id, ok := pctx.Business.CRMBoardID(board)
+		if !ok {
+			return 0, domain.Permanent(fmt.Errorf("board %s is not configured in %s.boards", board, crmConfigKey(pctx.Business)))
+		}
+		return id, nil
+	}
+	if pctx.Resource != nil && pctx.Resource.BoardID != nil {
+		return *pctx.Resource.BoardID, nil
+	}
+	return 0, missingConfig("board")
+}
+
+// crmConfigKey is the business config key the CRM boards are listed under
+func crmConfigKey(business *domain.BusinessConfig) string {
+	if business.CRM.Builtin() {
+		return "crm"
+	}
+	return "monday"
+}
+
+// crmSkip returns the skipped step when there is no CRM to work with
+func crmSkip(name string, crms ports.BusinessCRMs, pctx *domain.PipelineContext) (domain.JobStep, bool) {
+	message := ""
+	switch {
+	case crms == nil:
+		message = "crm is not configured"
+	case pctx.Business == nil:
+		message = "no business to find the crm for"
+	default:
+		return domain.JobStep{}, false
+	}
+	return domain.JobStep{
+		Name:    name,
+		Status:  "skipped",
+		Details: map[string]any{"message": message},
+	}, true
+}
+
+// crmResultError is the error for a CRM result reporting failure
+func crmResultError(message *string) error {
+	if message == nil {
+		return fmt.Errorf("crm request failed")
+	}
+	return fmt.Errorf("%s", *message)
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// fakeCRM is a ports.BusinessCRMs whose CRM keeps deals in memory
+type fakeCRM struct {
+	deals  map[int64]*ports.Deal
+	nextID int64
+}
+
+func newFakeCRM() *fakeCRM {
+	return &fakeCRM{deals: map[int64]*ports.Deal{}, nextID: 100}
+}
+
+func (c *fakeCRM) ForBusiness(business *domain.BusinessConfig) (ports.CRM, error) {
+	return c, nil
+}
+
+func (c *fakeCRM) CreateDeal(ctx context.Context, req *ports.CreateDealRequest) (*ports.CreateDealResult, error) {
+	c.nextID++
+	c.deals[c.nextID] = &ports.Deal{ItemID: c.nextID, BoardID: req.BoardID, Name: req.Name, Fields: req.Fields}
+	return &ports.CreateDealResult{ItemID: c.nextID, Success: true}, nil
+}
+
+func (c *fakeCRM) UpdateDeal(ctx context.Context, req *ports.UpdateDealRequest) (*ports.UpdateDealResult, error) {
+	deal, ok := c.deals[req.ItemID]
+	if !ok || deal.BoardID != req.BoardID {
+		return nil, fmt.Errorf("deal %d not found on board %d", req.ItemID, req.BoardID)
+	}
+	for k, v := range req.Fields {
+		deal.Fields[k] = v
+	}
+	return &ports.UpdateDealResult{Success: true}, nil
+}
+
+func (c *fakeCRM) GetDeal(ctx context.Context, boardID int64, itemID int64) (*ports.Deal, error) {
+	return c.deals[itemID], nil
+}
+
+func TestCRMDealActions(t *testing.T) {
+	business := &domain.BusinessConfig{ID: "stlpartyhelpers", Monday: domain.MondayConfig{Boards: map[string]int64{"deals": 234567890}}}
+	boardID, itemID := int64(234567890), int64(101)
+	triggered := &domain.ResourceContext{Type: "monday_item", BoardID: &boardID, ItemID: &itemID}
+
+	crm := newFakeCRM()
+	create := NewCreateCRMDealAction(crm)
+	ctx := domain.WithActionConfig(context.Background(), map[string]any{
+		"board":  "deals",
+		"name":   "Jane - Birthday",
+		"fields": map[string]any{"status": "Quote Sent"},
+	})
+	step := create.Execute(ctx, &domain.PipelineContext{Business: business})
+	if step.Status != "ok" || step.Outputs["itemId"] != float64(101) || step.Outputs["boardId"] != float64(234567890) {
+		t.Fatalf("expected the deal on the deals board, got %+v", step)
+	}
+
+	tests := []struct {
+		name       string
+		action     domain.Action
+		config     map[string]any
+		resource   *domain.ResourceContext
+		wantStatus string
+		wantBoard  float64
+	}{
+		{name: "update the created deal", action: NewUpdateCRMDealAction(crm), config: map[string]any{"board": "deals", "itemId": 101, "fields": map[string]any{"status": "Booked"}}, wantStatus: "ok", wantBoard: 234567890},
+		{name: "update the triggering item", action: NewUpdateCRMDealAction(crm), config: map[string]any{"fields": map[string]any{"status": "Deposit Paid"}}, resource: triggered, wantStatus: "ok", wantBoard: 234567890},
+		{name: "create on the triggering board", action: NewCreateCRMDealAction(crm), config: map[string]any{"name": "Follow-up"}, resource: triggered, wantStatus: "ok", wantBoard: 234567890},
+		{name: "unknown board", action: NewCreateCRMDealAction(crm), config: map[string]any{"board": "tasks", "name": "Follow-up"}, wantStatus: "failed"},
+		{name: "no board", action: NewCreateCRMDealAction(crm), config: map[string]any{"name": "Follow-up"}, wantStatus: "failed"},
+		{name: "no item", action: NewUpdateCRMDealAction(crm), config: map[string]any{"board": "deals", "fields": map[string]any{"status": "Paid"}}, wantStatus: "failed"},
+		{name: "no crm", action: NewCreateCRMDealAction(nil), config: map[string]any{"board": "deals", "name": "Follow-up"}, wantStatus: "skipped"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := domain.WithActionConfig(context.Background(), tt.config)
+			step := tt.action.Execute(ctx, &domain.PipelineContext{Business: business, Resource: tt.resource})
+			if step.Status != tt.wantStatus {
+				t.Fatalf("expected status %s, got %+v", tt.wantStatus, step)
+			}
+			if step.Status == "ok" && step.Outputs["boardId"] != tt.wantBoard {
+				t.Errorf("expected board %v, got outputs %+v", tt.wantBoard, step.Outputs)
+			}
+		})
+	}
+
+	if crm.deals[101].Fields["status"] != "Deposit Paid" {
+		t.Errorf("expected the deal to be updated, got %+v", crm.deals[101])
+	}
+}
@@ -0,0 +1,453 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+var (
+	// ErrInvalidCRMRequest is returned for a board, field value or filter the
+	// business CRM config does not allow
+	ErrInvalidCRMRequest = errors.New("invalid crm request")
+
+	// ErrBuiltinCRMDisabled is returned for a business whose crm.provider is
+	// not builtin
+	ErrBuiltinCRMDisabled = errors.New("built-in crm is not enabled")
+)
+
+// CRMService keeps the deals, contacts and boards of businesses whose
+// crm.provider is builtin. It is the CRM create_crm_deal and update_crm_deal
+// write to for those businesses, and serves the /v1/crm endpoints.
+type CRMService struct {
+	businesses ports.BusinessesRepo
+	store      ports.CRMStore
+}
+
+// NewCRMService creates a new built-in CRM service
+func NewCRMService(businesses ports.BusinessesRepo, store ports.CRMStore) *CRMService {
+	return &CRMService{
+		businesses: businesses,
+		store:      store,
+	}
+}
+
+// ForBusiness returns the built-in CRM of a business
+func (s *CRMService) ForBusiness(business *domain.BusinessConfig) (ports.CRM, error) {
+	if !business.CRM.Builtin() {
+		return nil, fmt.Errorf("%w for business %s", ErrBuiltinCRMDisabled, business.ID)
+	}
+	return &builtinCRM{service: s, business: business}, nil
+}
+
+// ListCRMDealsRequest filters the deals of a business
+type ListCRMDealsRequest struct {
+	BusinessID string
+	// Board is a board name from crm.boards; empty lists every board
+	Board  string
+	Search string
+	// Statuses keeps deals whose status columns, by field, have these labels
+	Statuses map[string]string
+	Limit    int
+}
+
+// CRMDealList is the result of ListDeals
+type CRMDealList struct {
+	BusinessID string `json:"businessId"`
+	Board      string `json:"board,omitempty"`
+	// StatusColumns are the status columns of Board
+	StatusColumns []domain.CRMStatusColumn `json:"statusColumns,omitempty"`
+	Deals         []*domain.CRMDeal        `json:"deals"`
+}
+
+// CRMDealView is a deal with its contact
+type CRMDealView struct {
+	Deal    *domain.CRMDeal    `json:"deal"`
+	Contact *domain.CRMContact `json:"contact,omitempty"`
+}
+
+// UpdateCRMDealRequest changes the name and fields of a deal. Fields are
+// merged into the deal's fields; a null value removes a field.
+type UpdateCRMDealRequest struct {
+	BusinessID string
+	DealID     int64
+	Name       string
+	Fields     map[string]any
+}
+
+// Boards returns the boards of a business with their status columns
+func (s *CRMService) Boards(ctx context.Context, businessID string) ([]*domain.CRMBoard, error) {
+	business, err := s.business(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.syncBoards(ctx, business); err != nil {
+		return nil, err
+	}
+	return s.store.ListBoards(ctx, business.ID)
+}
+
+// ListDeals returns the deals of a business matching the request, most
+// recently updated first
+func (s *CRMService) ListDeals(ctx context.Context, req *ListCRMDealsRequest) (*CRMDealList, error) {
+	business, err := s.business(ctx, req.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &CRMDealList{BusinessID: business.ID, Board: req.Board}
+	query := ports.DealQuery{
+		BusinessID: business.ID,
+		Search:     strings.TrimSpace(req.Search),
+		Limit:      req.Limit,
+	}
+
+	// Status filters are checked against the listed board, or any board
+	boards := business.CRM.Boards
+	if req.Board != "" {
+		board, ok := business.CRM.Boards[req.Board]
+		if !ok {
+			return nil, fmt.Errorf("%w: board %s is not configured in crm.boards", ErrInvalidCRMRequest, req.Board)
+		}
+		boards = map[string]domain.CRMBoardConfig{req.Board: board}
+		query.BoardID = board.ID
+		list.StatusColumns = board.StatusColumns
+	}
+	if len(req.Statuses) > 0 {
+		query.Fields = make(map[string]string, len(req.Statuses))
+	}
+	for field, value := range req.Statuses {
+		label, err := statusLabel(boards, field, value)
+		if err != nil {
+			return nil, err
+		}
+		query.Fields[field] = label
+	}
+
+	if list.Deals, err = s.store.ListDeals(ctx, query); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// statusLabel returns the label value matches in the status column field of
+// one of boards
+func statusLabel(boards map[string]domain.CRMBoardConfig, field, value string) (string, error) {
+	isColumn := false
+	for _, board := range boards {
+		column, ok := board.StatusColumn(field)
+		if !ok {
+			continue
+		}
+		isColumn = true
+		if label, ok := column.Label(value); ok {
+			return label, nil
+		}
+	}
+	if !isColumn {
+		return "", fmt.Errorf("%w: %s is not a status column", ErrInvalidCRMRequest, field)
+	}
+	return "", fmt.Errorf("%w: '%s' is not a label of status column %s", ErrInvalidCRMRequest, value, field)
+}
+
+// GetDeal returns a deal of a business with its contact
+func (s *CRMService) GetDeal(ctx context.Context, businessID string, id int64) (*CRMDealView, error) {
+	business, err := s.business(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	deal, err := s.store.GetDeal(ctx, business.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.view(ctx, deal)
+}
+
+// UpdateDeal changes the name and fields of a deal. Status columns only take
+// their labels.
+func (s *CRMService) UpdateDeal(ctx context.Context, req *UpdateCRMDealRequest) (*CRMDealView, error) {
+	business, err := s.business(ctx, req.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	deal, err := s.store.GetDeal(ctx, business.ID, req.DealID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.updateDeal(ctx, business, deal, req.Name, req.Fields); err != nil {
+		return nil, err
+	}
+	return s.view(ctx, deal)
+}
+
+// business loads a business that uses the built-in CRM
+func (s *CRMService) business(ctx context.Context, businessID string) (*domain.BusinessConfig, error) {
+	business, err := s.businesses.GetByID(ctx, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load business: %w", err)
+	}
+	if !business.CRM.Builtin() {
+		return nil, fmt.Errorf("%w for business %s (crm.provider is %s)", ErrBuiltinCRMDisabled, business.ID, business.CRM.ProviderName())
+	}
+	return business, nil
+}
+
+// view returns a deal with its contact
+func (s *CRMService) view(ctx context.Context, deal *domain.CRMDeal) (*CRMDealView, error) {
+	view := &CRMDealView{Deal: deal}
+	if deal.ContactID == "" {
+		return view, nil
+	}
+	contact, err := s.store.GetContact(ctx, deal.BusinessID, deal.ContactID)
+	if err != nil && !errors.Is(err, ports.ErrCRMContactNotFound) {
+		return nil, err
+	}
+	view.Contact = contact
+	return view, nil
+}
+
+// createDeal saves a new deal on a board of the business
+func (s *CRMService) createDeal(ctx context.Context, business *domain.BusinessConfig, boardID int64, name string, fields map[string]any) (*domain.CRMDeal, error) {
+	_, board, ok := business.CRM.Board(boardID)
+	if !ok {
+		return nil, fmt.Errorf("%w: board %d is not configured in crm.boards", ErrInvalidCRMRequest, boardID)
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidCRMRequest)
+	}
+
+	deal := &domain.CRMDeal{
+		BusinessID: business.ID,
+		BoardID:    boardID,
+		Name:       name,
+		Fields:     make(map[string]any, len(fields)),
+	}
+	if err := mergeDealFields(board, deal, fields); err != nil {
+		return nil, err
+	}
+	for _, column := range board.StatusColumns {
+		if _, ok := deal.Fields[column.Field]; !ok && column.Default != "" {
+			deal.Fields[column.Field] = column.Default
+		}
+	}
+
+	if err := s.syncBoards(ctx, business); err != nil {
+		return nil, err
+	}
+	if err := s.saveContact(ctx, deal); err != nil {
+		return nil, err
+	}
+	if err := s.store.CreateDeal(ctx, deal); err != nil {
+		return nil, err
+	}
+	return deal, nil
+}
+
+// updateDeal merges name and fields into a deal and saves it
+func (s *CRMService) updateDeal(ctx context.Context, business *domain.BusinessConfig, deal *domain.CRMDeal, name string, fields map[string]any) error {
+	_, board, ok := business.CRM.Board(deal.BoardID)
+	if !ok {
+		return fmt.Errorf("%w: board %d is not configured in crm.boards", ErrInvalidCRMRequest, deal.BoardID)
+	}
+	if name = strings.TrimSpace(name); name != "" {
+		deal.Name = name
+	}
+	if deal.Fields == nil {
+		deal.Fields = make(map[string]any, len(fields))
+	}
+	if err := mergeDealFields(board, deal, fields); err != nil {
+		return err
+	}
+
+	if err := s.saveContact(ctx, deal); err != nil {
+		return err
+	}
+	return s.store.SaveDeal(ctx, deal)
+}
+
+// mergeDealFields writes fields to a deal, replacing the values of status
+// columns with their labels
+func mergeDealFields(board domain.CRMBoardConfig, deal *domain.CRMDeal, fields map[string]any) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := fields[name]
+		if value == nil {
+			delete(deal.Fields, name)
+			continue
+		}
+		if column, ok := board.StatusColumn(name); ok {
+			text, _ := value.(string)
+			label, ok := column.Label(text)
+			if !ok {
+				return fmt.Errorf("%w: '%v' is not a label of status column %s (want one of %s)", ErrInvalidCRMRequest, value, name, strings.Join(column.Labels, ", "))
+			}
+			value = label
+		}
+		deal.Fields[name] = value
+	}
+	return nil
+}
+
+// saveContact creates or updates the contact a deal's email or phone field
+// names, and links the deal to it
+func (s *CRMService) saveContact(ctx context.Context, deal *domain.CRMDeal) error {
+	email, _ := deal.Fields["email"].(string)
+	phone, _ := deal.Fields["phone"].(string)
+	id := domain.CRMContactID(deal.BusinessID, email, phone)
+	if id == "" {
+		return nil
+	}
+
+	contact, err := s.store.GetContact(ctx, deal.BusinessID, id)
+	if errors.Is(err, ports.ErrCRMContactNotFound) {
+		contact, err = &domain.CRMContact{ID: id, BusinessID: deal.BusinessID}, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	updated := *contact
+	if email != "" {
+		updated.Email = email
+	}
+	if phone != "" {
+		updated.Phone = phone
+	}
+	first, _ := deal.Fields["firstName"].(string)
+	last, _ := deal.Fields["lastName"].(string)
+	if name := strings.TrimSpace(first + " " + last); name != "" {
+		updated.Name = name
+	}
+
+	deal.ContactID = id
+	if updated == *contact && !contact.CreatedAt.IsZero() {
+		return nil
+	}
+	return s.store.SaveContact(ctx, &updated)
+}
+
+// syncBoards saves the boards configured in crm.boards that the store does
+// not hold as configured
+func (s *CRMService) syncBoards(ctx context.Context, business *domain.BusinessConfig) error {
+	stored, err := s.store.ListBoards(ctx, business.ID)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int64]*domain.CRMBoard, len(stored))
+	for _, board := range stored {
+		byID[board.ID] = board
+	}
+
+	names := make([]string, 0, len(business.CRM.Boards))
+	for name := range business.CRM.Boards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		config := business.CRM.Boards[name]
+		if existing, ok := byID[config.ID]; ok && existing.Name == name && reflect.DeepEqual(existing.StatusColumns, config.StatusColumns) {
+			continue
+		}
+		board := &domain.CRMBoard{BusinessID: business.ID, ID: config.ID, Name: name, StatusColumns: config.StatusColumns}
+		if err := s.store.SaveBoard(ctx, board); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// builtinCRM is the ports.CRM of one business using the built-in CRM.
+// Rejected requests are permanent errors, so that pipelines do not retry
+// them.
+type builtinCRM struct {
+	service  *CRMService
+	business *domain.BusinessConfig
+}
+
+// CreateDeal creates a deal on req.BoardID
+func (c *builtinCRM) CreateDeal(ctx context.Context, req *ports.CreateDealRequest) (*ports.CreateDealResult, error) {
+	deal, err := c.service.createDeal(ctx, c.business, req.BoardID, req.Name, req.Fields)
+	if errors.Is(err, ErrInvalidCRMRequest) {
+		return nil, domain.Permanent(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ports.CreateDealResult{ItemID: deal.ID, Success: true}, nil
+}
+
+// UpdateDeal writes req.Fields to a deal on req.BoardID
+func (c *builtinCRM) UpdateDeal(ctx context.Context, req *ports.UpdateDealRequest) (*ports.UpdateDealResult, error) {
+	deal, err := c.deal(ctx, req.BoardID, req.ItemID)
+	if errors.Is(err, ports.ErrCRMDealNotFound) {
+		return nil, domain.Permanent(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.service.updateDeal(ctx, c.business, deal, "", req.Fields)
+	if errors.Is(err, ErrInvalidCRMRequest) {
+		return nil, domain.Permanent(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ports.UpdateDealResult{Success: true}, nil
+}
+
+// GetDeal returns a deal on boardID
+func (c *builtinCRM) GetDeal(ctx context.Context, boardID int64, itemID int64) (*ports.Deal, error) {
+	deal, err := c.deal(ctx, boardID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	return &ports.Deal{ItemID: deal.ID, BoardID: deal.BoardID, Name: deal.Name, Fields: deal.Fields}, nil
+}
+
+// deal returns a deal of the business; a deal on another board is not found
+func (c *builtinCRM) deal(ctx context.Context, boardID, itemID int64) (*domain.CRMDeal, error) {
+	deal, err := c.service.store.GetDeal(ctx, c.business.ID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if deal.BoardID != boardID {
+		return nil, fmt.Errorf("%w: %d on board %d", ports.ErrCRMDealNotFound, itemID, boardID)
+	}
+	return deal, nil
+}
+
+// CRMProviders returns each business the CRM its crm.provider names
+type CRMProviders struct {
+	builtin ports.BusinessCRMs
+	monday  ports.BusinessCRMs
+}
+
+// NewCRMProviders creates the per-business CRM selection over the built-in
+// and Monday.com CRMs
+func NewCRMProviders(builtin, monday ports.BusinessCRMs) *CRMProviders {
+	return &CRMProviders{builtin: builtin, monday: monday}
+}
+
+// ForBusiness returns the CRM of a business
+func (p *CRMProviders) ForBusiness(business *domain.BusinessConfig) (ports.CRM, error) {
+	switch business.CRM.ProviderName() {
+	case domain.CRMProviderBuiltin:
+		return p.builtin.ForBusiness(business)
+	case domain.CRMProviderMonday:
+		return p.monday.ForBusiness(business)
+	default:
+		return nil, fmt.Errorf("unknown crm provider %s for business %s", business.CRM.Provider, business.ID)
+	}
+}
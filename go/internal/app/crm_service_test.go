@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/infra/db"
+)
+
+const crmBusinessYAML = `id: stlpartyhelpers
+crm:
+  provider: builtin
+  boards:
+    deals:
+      id: 1
+      statusColumns:
+        - field: status
+          labels: [Lead, Quote Sent, Booked, Lost]
+          default: Lead
+    tasks:
+      id: 2
+`
+
+func newTestCRMService(t *testing.T) (*CRMService, *domain.BusinessConfig) {
+	t.Helper()
+	loader := config.NewBusinessLoader(writeConfigFiles(t, map[string]string{
+		"businesses/stlpartyhelpers.yaml": crmBusinessYAML,
+		"businesses/acme.yaml":            "id: acme\n",
+	}))
+	businesses := config.NewYAMLBusinessesRepo(loader)
+	business, err := businesses.GetByID(context.Background(), "stlpartyhelpers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewCRMService(businesses, db.NewMemoryCRMStore()), business
+}
+
+func TestCRMService_PipelineActions(t *testing.T) {
+	service, business := newTestCRMService(t)
+	crms := NewCRMProviders(service, newFakeCRM())
+
+	create := NewCreateCRMDealAction(crms)
+	ctx := domain.WithActionConfig(context.Background(), map[string]any{
+		"board":  "deals",
+		"name":   "Jane - Birthday",
+		"fields": map[string]any{"email": "jane@example.com", "firstName": "Jane", "totalCost": 450},
+	})
+	step := create.Execute(ctx, &domain.PipelineContext{Business: business})
+	if step.Status != "ok" || step.Outputs["boardId"] != float64(1) || step.Outputs["itemId"] != float64(1) {
+		t.Fatalf("expected deal 1 on board 1, got %+v", step)
+	}
+
+	tests := []struct {
+		name          string
+		action        domain.Action
+		config        map[string]any
+		wantStatus    string
+		wantPermanent bool
+	}{
+		{name: "status label", action: NewUpdateCRMDealAction(crms), config: map[string]any{"board": "deals", "itemId": 1, "fields": map[string]any{"status": "quote sent"}}, wantStatus: "ok"},
+		{name: "unknown status label", action: NewUpdateCRMDealAction(crms), config: map[string]any{"board": "deals", "itemId": 1, "fields": map[string]any{"status": "Paid"}}, wantStatus: "failed", wantPermanent: true},
+		{name: "deal on another board", action: NewUpdateCRMDealAction(crms), config: map[string]any{"board": "tasks", "itemId": 1, "fields": map[string]any{"done": true}}, wantStatus: "failed", wantPermanent: true},
+		{name: "board not in crm.boards", action: NewCreateCRMDealAction(crms), config: map[string]any{"board": "leads", "name": "Follow-up"}, wantStatus: "failed", wantPermanent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := domain.WithActionConfig(context.Background(), tt.config)
+			step := tt.action.Execute(ctx, &domain.PipelineContext{Business: business})
+			if step.Status != tt.wantStatus || step.Permanent != tt.wantPermanent {
+				t.Fatalf("expected status %s (permanent %v), got %+v", tt.wantStatus, tt.wantPermanent, step)
+			}
+		})
+	}
+
+	view, err := service.GetDeal(context.Background(), "stlpartyhelpers", 1)
+	if err != nil {
+		t.Fatalf("GetDeal failed: %v", err)
+	}
+	want := map[string]any{"email": "jane@example.com", "firstName": "Jane", "totalCost": float64(450), "status": "Quote Sent"}
+	if !reflect.DeepEqual(view.Deal.Fields, want) {
+		t.Errorf("expected fields %v, got %v", want, view.Deal.Fields)
+	}
+	if view.Contact == nil || view.Contact.Name != "Jane" || view.Contact.Email != "jane@example.com" {
+		t.Errorf("expected the deal's contact, got %+v", view.Contact)
+	}
+}
+
+func TestCRMService_ListAndUpdateDeals(t *testing.T) {
+	ctx := context.Background()
+	service, business := newTestCRMService(t)
+	for _, name := range []string{"Jane - Birthday", "John - Wedding"} {
+		if _, err := service.createDeal(ctx, business, 1, name, nil); err != nil {
+			t.Fatalf("createDeal failed: %v", err)
+		}
+	}
+	if _, err := service.createDeal(ctx, business, 2, "Order napkins", nil); err != nil {
+		t.Fatalf("createDeal failed: %v", err)
+	}
+
+	view, err := service.UpdateDeal(ctx, &UpdateCRMDealRequest{
+		BusinessID: "stlpartyhelpers",
+		DealID:     2,
+		Fields:     map[string]any{"status": "booked", "phone": "(314) 555-0100"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateDeal failed: %v", err)
+	}
+	if view.Deal.Fields["status"] != "Booked" || view.Contact == nil || view.Contact.Phone != "(314) 555-0100" {
+		t.Errorf("expected the booked deal with its contact, got %+v", view)
+	}
+
+	tests := []struct {
+		name    string
+		req     ListCRMDealsRequest
+		want    []string
+		wantErr error
+	}{
+		{name: "every board", req: ListCRMDealsRequest{}, want: []string{"John - Wedding", "Order napkins", "Jane - Birthday"}},
+		{name: "board", req: ListCRMDealsRequest{Board: "deals"}, want: []string{"John - Wedding", "Jane - Birthday"}},
+		{name: "status", req: ListCRMDealsRequest{Board: "deals", Statuses: map[string]string{"status": "lead"}}, want: []string{"Jane - Birthday"}},
+		{name: "search", req: ListCRMDealsRequest{Search: "NAPKINS"}, want: []string{"Order napkins"}},
+		{name: "unknown board", req: ListCRMDealsRequest{Board: "leads"}, wantErr: ErrInvalidCRMRequest},
+		{name: "unknown label", req: ListCRMDealsRequest{Statuses: map[string]string{"status": "Paid"}}, wantErr: ErrInvalidCRMRequest},
+		{name: "not a status column", req: ListCRMDealsRequest{Board: "tasks", Statuses: map[string]string{"status": "Lead"}}, wantErr: ErrInvalidCRMRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.BusinessID = "stlpartyhelpers"
+			list, err := service.ListDeals(ctx, &tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListDeals failed: %v", err)
+			}
+			names := []string{}
+			for _, deal := range list.Deals {
+				names = append(names, deal.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, names)
+			}
+		})
+	}
+
+	boards, err := service.Boards(ctx, "stlpartyhelpers")
+	if err != nil {
+		t.Fatalf("Boards failed: %v", err)
+	}
+	if len(boards) != 2 || boards[0].Name != "deals" || len(boards[0].StatusColumns) != 1 || boards[1].Name != "tasks" {
+		t.Errorf("expected the configured boards, got %+v", boards)
+	}
+
+	if _, err := service.ListDeals(ctx, &ListCRMDealsRequest{BusinessID: "acme"}); !errors.Is(err, ErrBuiltinCRMDisabled) {
+		t.Errorf("expected ErrBuiltinCRMDisabled for a Monday.com business, got %v", err)
+	}
+}
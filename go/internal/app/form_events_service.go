@@ -4,35 +4,40 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/bizops360/go-api/internal/config"
 	"github.com/bizops360/go-api/internal/domain"
 	"github.com/bizops360/go-api/internal/ports"
 )
 
 // FormEventsService handles form event processing
 type FormEventsService struct {
-	businessLoader *config.BusinessLoader
+	businesses     ports.BusinessesRepo
+	pipelines      ports.PipelinesRepo
 	pipelineRunner *domain.PipelineRunner
 	jobsRepo       ports.JobsRepo
+	workerPool     *WorkerPool
 }
 
 // NewFormEventsService creates a new form events service
 func NewFormEventsService(
-	businessLoader *config.BusinessLoader,
+	businesses ports.BusinessesRepo,
+	pipelines ports.PipelinesRepo,
 	pipelineRunner *domain.PipelineRunner,
 	jobsRepo ports.JobsRepo,
+	workerPool *WorkerPool,
 ) *FormEventsService {
 	return &FormEventsService{
-		businessLoader: businessLoader,
+		businesses:     businesses,
+		pipelines:      pipelines,
 		pipelineRunner: pipelineRunner,
 		jobsRepo:       jobsRepo,
+		workerPool:     workerPool,
 	}
 }
 
 // Run executes a form event pipeline
 func (s *FormEventsService) Run(ctx context.Context, req *FormEventsRequest) (*domain.PipelineResult, error) {
 	// Load business config
-	business, err := s.businessLoader.LoadBusiness(ctx, req.BusinessID)
+	business, err := s.businesses.GetByID(ctx, req.BusinessID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load business: %w", err)
 	}
@@ -47,7 +52,7 @@ func (s *FormEventsService) Run(ctx context.Context, req *FormEventsRequest) (*d
 	}
 
 	// Load pipeline
-	pipeline, err := s.businessLoader.LoadPipeline(ctx, pipelineKey)
+	pipeline, err := s.pipelines.GetByKey(ctx, pipelineKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load pipeline: %w", err)
 	}
@@ -61,20 +66,11 @@ func (s *FormEventsService) Run(ctx context.Context, req *FormEventsRequest) (*d
 		Fields:      req.Fields,
 		Options:     req.Options,
 		RequestID:   req.RequestID,
+		Business:    business,
 	}
 
-	// Run pipeline
-	result, job := s.pipelineRunner.Run(ctx, pipeline, pctx)
-
-	// Save job
-	if job != nil {
-		if err := s.jobsRepo.Save(ctx, job); err != nil {
-			// Log error but don't fail the request
-			_ = err
-		}
-	}
-
-	return result, nil
+	// Run pipeline (inline, or queued on the worker pool when async) and save the job
+	return runPipelineJob(ctx, s.pipelineRunner, s.jobsRepo, s.workerPool, pipeline, pctx, nil, req.Async)
 }
 
 // FormEventsRequest represents a form event request
@@ -86,5 +82,7 @@ type FormEventsRequest struct {
 	Fields      map[string]any
 	Options     map[string]any
 	RequestID   string
+	// Async queues the pipeline and returns the job ID without waiting for it to finish
+	Async bool
 }
 
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/infra/geo"
+)
+
+// Geocoder looks up the coordinates of an address; *geo.GeocodingService
+// satisfies it
+type Geocoder interface {
+	GetLatLng(ctx context.Context, address string) (*geo.GeocodeResult, error)
+}
+
+// GeocodeLocationConfig is the config of geocode_location
+type GeocodeLocationConfig struct {
+	EventLocation string `json:"eventLocation"`
+}
+
+// GeocodeLocationOutputs are the outputs of geocode_location. The distance
+// and service area check are only set when the business location has
+// coordinates.
+type GeocodeLocationOutputs struct {
+	Lat               float64  `json:"lat"`
+	Lng               float64  `json:"lng"`
+	FullAddress       string   `json:"fullAddress"`
+	DistanceMiles     *float64 `json:"distanceMiles,omitempty"`
+	WithinServiceArea *bool    `json:"withinServiceArea,omitempty"`
+}
+
+// GeocodeLocationAction geocodes the event address and measures its distance
+// from the business location
+type GeocodeLocationAction struct {
+	geocoder Geocoder
+}
+
+// NewGeocodeLocationAction creates the geocode_location action. With a nil
+// geocoder the action is skipped.
+func NewGeocodeLocationAction(geocoder Geocoder) *GeocodeLocationAction {
+	return &GeocodeLocationAction{geocoder: geocoder}
+}
+
+func (a *GeocodeLocationAction) Name() string {
+	return "geocode_location"
+}
+
+// SideEffectFree marks the action as safe to run again when a job is resumed
+func (a *GeocodeLocationAction) SideEffectFree() bool {
+	return true
+}
+
+func (a *GeocodeLocationAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Geocodes the event address and checks it against the business service area",
+		Config: map[string]domain.ConfigField{
+			"eventLocation": {Type: domain.ConfigTypeString, Description: "Address to geocode (default: the eventLocation field)"},
+		},
+	}
+}
+
+func (a *GeocodeLocationAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if a.geocoder == nil {
+		return domain.JobStep{
+			Name:    a.Name(),
+			Status:  "skipped",
+			Details: map[string]any{"message": "geocoding is not configured"},
+		}
+	}
+	var config GeocodeLocationConfig
+	if err := decodeActionConfig(ctx, pctx, &config, "eventLocation"); err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	if config.EventLocation == "" {
+		return domain.JobStep{
+			Name:    a.Name(),
+			Status:  "skipped",
+			Details: map[string]any{"message": "no eventLocation, nothing to geocode"},
+		}
+	}
+
+	result, err := a.geocoder.GetLatLng(ctx, config.EventLocation)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	outputs := GeocodeLocationOutputs{Lat: result.Lat, Lng: result.Lng, FullAddress: result.FullAddress}
+	message := fmt.Sprintf("geocoded to %s", result.FullAddress)
+	if pctx.Business != nil {
+		// Without a geocoder the origin is only known from configured
+		// coordinates, which avoids a second lookup per lead
+		if lat, lng, radius, err := geo.LocationFromBusinessConfig(ctx, &pctx.Business.Location, nil); err == nil {
+			distance := math.Round(geo.CalculateDistanceFromOrigin(lat, lng, result.Lat, result.Lng)*10) / 10
+			within := distance <= radius
+			outputs.DistanceMiles, outputs.WithinServiceArea = &distance, &within
+			message = fmt.Sprintf("geocoded to %s, %.1f miles away", result.FullAddress, distance)
+		}
+	}
+
+	return domain.JobStep{
+		Name:    a.Name(),
+		Status:  "ok",
+		Details: map[string]any{"message": message},
+		Outputs: stepOutputs(outputs),
+	}
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/infra/geo"
+)
+
+// fakeGeocoder is a Geocoder over a map of addresses
+type fakeGeocoder map[string]*geo.GeocodeResult
+
+func (g fakeGeocoder) GetLatLng(ctx context.Context, address string) (*geo.GeocodeResult, error) {
+	result, ok := g[address]
+	if !ok {
+		return nil, fmt.Errorf("geocoding failed: ZERO_RESULTS")
+	}
+	return result, nil
+}
+
+func TestGeocodeLocationAction(t *testing.T) {
+	geocoder := fakeGeocoder{
+		"Forest Park": {Lat: 38.6368, Lng: -90.2853, FullAddress: "Forest Park, St. Louis, MO"},
+		"Chicago":     {Lat: 41.8781, Lng: -87.6298, FullAddress: "Chicago, IL"},
+	}
+	business := &domain.BusinessConfig{Location: domain.LocationConfig{Lat: 38.6255, Lng: -90.2456, ServiceRadiusMiles: 15}}
+
+	tests := []struct {
+		name         string
+		geocoder     Geocoder
+		location     any
+		business     *domain.BusinessConfig
+		wantStatus   string
+		wantWithin   any
+		wantDistance bool
+	}{
+		{name: "within the service area", geocoder: geocoder, location: "Forest Park", business: business, wantStatus: "ok", wantWithin: true, wantDistance: true},
+		{name: "outside the service area", geocoder: geocoder, location: "Chicago", business: business, wantStatus: "ok", wantWithin: false, wantDistance: true},
+		{name: "business without coordinates", geocoder: geocoder, location: "Chicago", business: &domain.BusinessConfig{}, wantStatus: "ok"},
+		{name: "unknown address", geocoder: geocoder, location: "Atlantis", business: business, wantStatus: "failed"},
+		{name: "no address", geocoder: geocoder, business: business, wantStatus: "skipped"},
+		{name: "no geocoder", location: "Chicago", business: business, wantStatus: "skipped"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := NewGeocodeLocationAction(tt.geocoder)
+			pctx := &domain.PipelineContext{Fields: map[string]any{"eventLocation": tt.location}, Business: tt.business}
+			step := action.Execute(context.Background(), pctx)
+
+			if step.Status != tt.wantStatus {
+				t.Fatalf("expected status %s, got %+v", tt.wantStatus, step)
+			}
+			if step.Status != "ok" {
+				return
+			}
+			if step.Outputs["fullAddress"] == "" || step.Outputs["withinServiceArea"] != tt.wantWithin {
+				t.Errorf("expected withinServiceArea %v, got outputs %+v", tt.wantWithin, step.Outputs)
+			}
+			if _, ok := step.Outputs["distanceMiles"]; ok != tt.wantDistance {
+				t.Errorf("expected distanceMiles set %v, got outputs %+v", tt.wantDistance, step.Outputs)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	stripeService "github.com/bizops360/go-api/internal/services/stripe"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// dryRunPlaceholder stands in for IDs and links that only exist once a
+// planned action has run
+const dryRunPlaceholder = "dry-run"
+
+// InvoiceOutputs are the outputs of create_deposit_invoice and
+// create_final_invoice
+type InvoiceOutputs struct {
+	InvoiceID        string `json:"invoiceId"`
+	HostedInvoiceURL string `json:"hostedInvoiceUrl"`
+	InvoicePDF       string `json:"invoicePdf,omitempty"`
+	AmountCents      int64  `json:"amountCents"`
+	Status           string `json:"status"`
+}
+
+// CreateDepositInvoiceConfig is the config of create_deposit_invoice. The
+// deposit is DepositCents when set and is otherwise calculated from
+// EstimateCents.
+type CreateDepositInvoiceConfig struct {
+	Email         string         `json:"email"`
+	CustomerName  string         `json:"customerName"`
+	EstimateCents int64          `json:"estimateCents"`
+	DepositCents  int64          `json:"depositCents"`
+	Description   string         `json:"description"`
+	Memo          string         `json:"memo"`
+	Metadata      map[string]any `json:"metadata"`
+}
+
+// CreateDepositInvoiceAction creates and sends a Stripe deposit invoice
+type CreateDepositInvoiceAction struct {
+	invoices *stripeService.InvoiceService
+}
+
+// NewCreateDepositInvoiceAction creates the create_deposit_invoice action
+func NewCreateDepositInvoiceAction(invoices *stripeService.InvoiceService) *CreateDepositInvoiceAction {
+	return &CreateDepositInvoiceAction{invoices: invoices}
+}
+
+func (a *CreateDepositInvoiceAction) Name() string {
+	return "create_deposit_invoice"
+}
+
+func (a *CreateDepositInvoiceAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Creates and sends a Stripe invoice for the booking deposit",
+		Config: map[string]domain.ConfigField{
+			"email":         {Type: domain.ConfigTypeString, Description: "Customer email (default: the email field)"},
+			"customerName":  {Type: domain.ConfigTypeString, Description: "Customer name on the invoice"},
+			"estimateCents": {Type: domain.ConfigTypeInteger, Description: "Estimate total the deposit is calculated from"},
+			"depositCents":  {Type: domain.ConfigTypeInteger, Description: "Deposit amount, overriding the calculated one"},
+			"description":   {Type: domain.ConfigTypeString, Description: "Line item description (default: Booking Deposit Invoice)"},
+			"memo":          {Type: domain.ConfigTypeString, Description: "Memo shown on the invoice"},
+			"metadata":      {Type: domain.ConfigTypeObject, Description: "Stripe metadata"},
+		},
+	}
+}
+
+func (a *CreateDepositInvoiceAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	config, amountCents, err := a.deposit(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	if a.invoices == nil {
+		return domain.FailedStep(a.Name(), domain.Permanent(fmt.Errorf("invoicing is not configured")))
+	}
+
+	invoice, err := a.invoices.CreateDepositInvoice(ctx, &stripeService.CreateDepositInvoiceRequest{
+		CustomerEmail:     config.Email,
+		CustomerName:      config.CustomerName,
+		DepositValueCents: &amountCents,
+		Description:       config.Description,
+		Metadata:          invoiceMetadata(pctx, config.Metadata),
+		Memo:              config.Memo,
+	})
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	return invoiceStep(a.Name(), "deposit", invoice)
+}
+
+// Plan returns the deposit invoice Execute would create
+func (a *CreateDepositInvoiceAction) Plan(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	config, amountCents, err := a.deposit(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	return plannedInvoiceStep(a.Name(), "deposit", config.Email, amountCents, map[string]any{
+		"customerName":  config.CustomerName,
+		"estimateCents": config.EstimateCents,
+		"description":   config.Description,
+	})
+}
+
+// deposit reads the config and works out the deposit amount
+func (a *CreateDepositInvoiceAction) deposit(ctx context.Context, pctx *domain.PipelineContext) (CreateDepositInvoiceConfig, int64, error) {
+	var config CreateDepositInvoiceConfig
+	if err := decodeActionConfig(ctx, pctx, &config, "email"); err != nil {
+		return config, 0, err
+	}
+	if config.Email == "" {
+		return config, 0, missingConfig("email")
+	}
+	if config.DepositCents > 0 {
+		return config, config.DepositCents, nil
+	}
+	if config.EstimateCents <= 0 {
+		return config, 0, domain.Permanent(fmt.Errorf("depositCents or estimateCents is required"))
+	}
+	if a.invoices == nil {
+		return config, 0, domain.Permanent(fmt.Errorf("invoicing is not configured"))
+	}
+
+	deposit, err := a.invoices.CalculateDepositFromEstimate(ctx, config.EstimateCents)
+	if err != nil {
+		return config, 0, fmt.Errorf("failed to calculate deposit: %w", err)
+	}
+	return config, deposit.AmountCents, nil
+}
+
+// CreateFinalInvoiceConfig is the config of create_final_invoice
+type CreateFinalInvoiceConfig struct {
+	Email            string         `json:"email"`
+	CustomerName     string         `json:"customerName"`
+	TotalCents       int64          `json:"totalCents"`
+	DepositPaidCents int64          `json:"depositPaidCents"`
+	Currency         string         `json:"currency"`
+	Description      string         `json:"description"`
+	Memo             string         `json:"memo"`
+	Metadata         map[string]any `json:"metadata"`
+}
+
+// CreateFinalInvoiceAction creates and sends a Stripe invoice for the balance
+// left after the deposit
+type CreateFinalInvoiceAction struct {
+	invoices *stripeService.InvoiceService
+}
+
+// NewCreateFinalInvoiceAction creates the create_final_invoice action
+func NewCreateFinalInvoiceAction(invoices *stripeService.InvoiceService) *CreateFinalInvoiceAction {
+	return &CreateFinalInvoiceAction{invoices: invoices}
+}
+
+func (a *CreateFinalInvoiceAction) Name() string {
+	return "create_final_invoice"
+}
+
+func (a *CreateFinalInvoiceAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Creates and sends a Stripe invoice for the balance after the deposit",
+		Config: map[string]domain.ConfigField{
+			"email":            {Type: domain.ConfigTypeString, Description: "Customer email (default: the email field)"},
+			"customerName":     {Type: domain.ConfigTypeString, Description: "Customer name on the invoice"},
+			"totalCents":       {Type: domain.ConfigTypeInteger, Required: true, Description: "Total event cost"},
+			"depositPaidCents": {Type: domain.ConfigTypeInteger, Description: "Deposit already paid, subtracted from the total"},
+			"currency":         {Type: domain.ConfigTypeString, Description: "Currency (default: the business Stripe currency)"},
+			"description":      {Type: domain.ConfigTypeString, Description: "Line item description"},
+			"memo":             {Type: domain.ConfigTypeString, Description: "Memo shown on the invoice"},
+			"metadata":         {Type: domain.ConfigTypeObject, Description: "Stripe metadata"},
+		},
+	}
+}
+
+func (a *CreateFinalInvoiceAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	config, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	if a.invoices == nil {
+		return domain.FailedStep(a.Name(), domain.Permanent(fmt.Errorf("invoicing is not configured")))
+	}
+
+	invoice, err := a.invoices.CreateFinalInvoice(ctx, &stripeService.CreateFinalInvoiceRequest{
+		CustomerEmail:    config.Email,
+		CustomerName:     config.CustomerName,
+		TotalAmountCents: &config.TotalCents,
+		DepositPaidCents: &config.DepositPaidCents,
+		Currency:         config.Currency,
+		Description:      config.Description,
+		Metadata:         invoiceMetadata(pctx, config.Metadata),
+		Memo:             config.Memo,
+	})
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	return invoiceStep(a.Name(), "final", invoice)
+}
+
+// Plan returns the final invoice Execute would create
+func (a *CreateFinalInvoiceAction) Plan(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	config, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	return plannedInvoiceStep(a.Name(), "final", config.Email, config.TotalCents-config.DepositPaidCents, map[string]any{
+		"customerName":     config.CustomerName,
+		"totalCents":       config.TotalCents,
+		"depositPaidCents": config.DepositPaidCents,
+		"currency":         config.Currency,
+		"description":      config.Description,
+	})
+}
+
+// config reads and checks the config, defaulting the currency to the
+// business's
+func (a *CreateFinalInvoiceAction) config(ctx context.Context, pctx *domain.PipelineContext) (CreateFinalInvoiceConfig, error) {
+	var config CreateFinalInvoiceConfig
+	if err := decodeActionConfig(ctx, pctx, &config, "email"); err != nil {
+		return config, err
+	}
+	if config.Email == "" {
+		return config, missingConfig("email")
+	}
+	if config.TotalCents <= 0 {
+		return config, missingConfig("totalCents")
+	}
+	if config.DepositPaidCents >= config.TotalCents {
+		return config, domain.Permanent(fmt.Errorf("depositPaidCents (%d) leaves no balance on totalCents (%d)", config.DepositPaidCents, config.TotalCents))
+	}
+	if config.Currency == "" && pctx.Business != nil {
+		config.Currency = pctx.Business.Stripe.DefaultCurrency
+	}
+	return config, nil
+}
+
+// VoidInvoiceConfig is the config of void_invoice
+type VoidInvoiceConfig struct {
+	InvoiceID string `json:"invoiceId"`
+}
+
+// VoidInvoiceAction voids a Stripe invoice. It is the compensation of
+// create_deposit_invoice and create_final_invoice, so a failed job does not
+// leave the customer with an invoice for a booking that never happened.
+type VoidInvoiceAction struct {
+	invoices *stripeService.InvoiceService
+}
+
+// NewVoidInvoiceAction creates the void_invoice action
+func NewVoidInvoiceAction(invoices *stripeService.InvoiceService) *VoidInvoiceAction {
+	return &VoidInvoiceAction{invoices: invoices}
+}
+
+func (a *VoidInvoiceAction) Name() string {
+	return "void_invoice"
+}
+
+func (a *VoidInvoiceAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Voids a Stripe invoice, typically to compensate an invoice created by a failed job",
+		Config: map[string]domain.ConfigField{
+			"invoiceId": {Type: domain.ConfigTypeString, Required: true, Description: "Invoice to void, e.g. {{ steps.create_deposit_invoice.invoiceId }}"},
+		},
+	}
+}
+
+func (a *VoidInvoiceAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	config, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	if a.invoices == nil {
+		return domain.FailedStep(a.Name(), domain.Permanent(fmt.Errorf("invoicing is not configured")))
+	}
+
+	// The invoice actions create live-mode invoices, see StripePayments.CreateInvoice
+	if err := a.invoices.VoidInvoice(ctx, config.InvoiceID, false); err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message":   fmt.Sprintf("invoice %s voided", config.InvoiceID),
+			"invoiceId": config.InvoiceID,
+		},
+	}
+}
+
+// Plan returns the invoice Execute would void
+func (a *VoidInvoiceAction) Plan(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	config, err := a.config(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": "dry run, invoice not voided",
+		},
+		SideEffects: []domain.SideEffect{{
+			Kind:    "invoice_void",
+			Summary: fmt.Sprintf("Void invoice %s", config.InvoiceID),
+			Details: map[string]any{"invoiceId": config.InvoiceID},
+		}},
+	}
+}
+
+// config reads and checks the config
+func (a *VoidInvoiceAction) config(ctx context.Context, pctx *domain.PipelineContext) (VoidInvoiceConfig, error) {
+	var config VoidInvoiceConfig
+	if err := decodeActionConfig(ctx, pctx, &config); err != nil {
+		return config, err
+	}
+	if config.InvoiceID == "" {
+		return config, missingConfig("invoiceId")
+	}
+	return config, nil
+}
+
+// invoiceMetadata returns the Stripe metadata of an invoice: the configured
+// values as text plus the business that created it
+func invoiceMetadata(pctx *domain.PipelineContext, configured map[string]any) map[string]string {
+	metadata := make(map[string]string, len(configured)+1)
+	for key, value := range configured {
+		metadata[key] = fmt.Sprint(value)
+	}
+	if _, ok := metadata["business_id"]; !ok && pctx.BusinessID != "" {
+		metadata["business_id"] = pctx.BusinessID
+	}
+	return metadata
+}
+
+// invoiceStep is the step for a created invoice
+func invoiceStep(name, invoiceType string, invoice *ports.InvoiceResult) domain.JobStep {
+	return domain.JobStep{
+		Name:   name,
+		Status: "ok",
+		Details: map[string]any{
+			"message": fmt.Sprintf("%s invoice %s created for $%.2f", invoiceType, invoice.InvoiceID, util.CentsToDollars(invoice.AmountDue)),
+		},
+		Outputs: stepOutputs(InvoiceOutputs{
+			InvoiceID:        invoice.InvoiceID,
+			HostedInvoiceURL: invoice.HostedInvoiceURL,
+			InvoicePDF:       invoice.InvoicePDF,
+			AmountCents:      invoice.AmountDue,
+			Status:           invoice.Status,
+		}),
+	}
+}
+
+// plannedInvoiceStep is the dry-run step for an invoice, with stand-in
+// outputs for the invoice that does not exist
+func plannedInvoiceStep(name, invoiceType, email string, amountCents int64, details map[string]any) domain.JobStep {
+	details["email"] = email
+	details["amountCents"] = amountCents
+	return domain.JobStep{
+		Name:   name,
+		Status: "ok",
+		Details: map[string]any{
+			"message": fmt.Sprintf("dry run, %s invoice not created", invoiceType),
+		},
+		Outputs: stepOutputs(InvoiceOutputs{
+			InvoiceID:        dryRunPlaceholder,
+			HostedInvoiceURL: dryRunPlaceholder,
+			AmountCents:      amountCents,
+			Status:           "draft",
+		}),
+		SideEffects: []domain.SideEffect{{
+			Kind:    "invoice",
+			Summary: fmt.Sprintf("Send %s a %s invoice for $%.2f", email, invoiceType, util.CentsToDollars(amountCents)),
+			Details: details,
+		}},
+	}
+}
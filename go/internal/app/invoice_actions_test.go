@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	stripeService "github.com/bizops360/go-api/internal/services/stripe"
+)
+
+// fakePayments is a ports.PaymentsProvider that records the invoices it is
+// asked to create and void. Deposits are a fifth of the estimate.
+type fakePayments struct {
+	invoices      []*ports.CreateInvoiceRequest
+	finalInvoices []*ports.CreateFinalInvoiceRequest
+	voided        []string
+}
+
+func (p *fakePayments) CreateInvoice(ctx context.Context, req *ports.CreateInvoiceRequest) (*ports.InvoiceResult, error) {
+	p.invoices = append(p.invoices, req)
+	return &ports.InvoiceResult{
+		InvoiceID:        fmt.Sprintf("in_%d", len(p.invoices)),
+		HostedInvoiceURL: "https://invoice.stripe.com/i/deposit",
+		AmountDue:        req.AmountCents,
+		Status:           "open",
+	}, nil
+}
+
+func (p *fakePayments) CreateFinalInvoice(ctx context.Context, req *ports.CreateFinalInvoiceRequest) (*ports.InvoiceResult, error) {
+	p.finalInvoices = append(p.finalInvoices, req)
+	return &ports.InvoiceResult{
+		InvoiceID:        "in_final",
+		HostedInvoiceURL: "https://invoice.stripe.com/i/final",
+		AmountDue:        req.TotalAmountCents - req.DepositPaidCents,
+		Status:           "open",
+	}, nil
+}
+
+func (p *fakePayments) CalculateDeposit(ctx context.Context, estimateTotalCents int64) (*domain.Deposit, error) {
+	return &domain.Deposit{AmountCents: estimateTotalCents / 5, EstimateTotalCents: estimateTotalCents}, nil
+}
+
+func (p *fakePayments) GetInvoice(ctx context.Context, invoiceID string, useTest bool) (*ports.InvoiceResult, error) {
+	return nil, fmt.Errorf("invoice %s not found", invoiceID)
+}
+
+func (p *fakePayments) SendInvoice(ctx context.Context, invoiceID string, useTest bool) error {
+	return nil
+}
+
+func (p *fakePayments) VoidInvoice(ctx context.Context, invoiceID string, useTest bool) error {
+	p.voided = append(p.voided, invoiceID)
+	return nil
+}
+
+func TestCreateDepositInvoiceAction(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        map[string]any
+		dryRun        bool
+		wantStatus    string
+		wantAmount    float64
+		wantInvoices  int
+		wantPermanent bool
+	}{
+		{
+			name:         "deposit from the estimate",
+			config:       map[string]any{"customerName": "Jane", "estimateCents": float64(50000)},
+			wantStatus:   "ok",
+			wantAmount:   10000,
+			wantInvoices: 1,
+		},
+		{
+			name:         "explicit deposit",
+			config:       map[string]any{"estimateCents": 50000, "depositCents": 7500},
+			wantStatus:   "ok",
+			wantAmount:   7500,
+			wantInvoices: 1,
+		},
+		{
+			name:       "dry run",
+			config:     map[string]any{"estimateCents": 50000},
+			dryRun:     true,
+			wantStatus: "ok",
+			wantAmount: 10000,
+		},
+		{
+			name:          "no amount",
+			config:        map[string]any{"customerName": "Jane"},
+			wantStatus:    "failed",
+			wantPermanent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payments := &fakePayments{}
+			action := NewCreateDepositInvoiceAction(stripeService.NewInvoiceService(payments))
+			ctx := domain.WithActionConfig(context.Background(), tt.config)
+			pctx := &domain.PipelineContext{BusinessID: "stlpartyhelpers", Fields: map[string]any{"email": "jane@example.com"}}
+
+			var step domain.JobStep
+			if tt.dryRun {
+				step = action.Plan(ctx, pctx)
+			} else {
+				step = action.Execute(ctx, pctx)
+			}
+
+			if step.Status != tt.wantStatus || step.Permanent != tt.wantPermanent {
+				t.Fatalf("expected status %s (permanent %v), got %+v", tt.wantStatus, tt.wantPermanent, step)
+			}
+			if len(payments.invoices) != tt.wantInvoices {
+				t.Fatalf("expected %d invoice(s), got %d", tt.wantInvoices, len(payments.invoices))
+			}
+			if step.Status != "ok" {
+				return
+			}
+			if step.Outputs["amountCents"] != tt.wantAmount || step.Outputs["hostedInvoiceUrl"] == "" {
+				t.Errorf("expected amount %v and an invoice link, got outputs %+v", tt.wantAmount, step.Outputs)
+			}
+			if tt.dryRun {
+				if len(step.SideEffects) != 1 || step.SideEffects[0].Kind != "invoice" || step.SideEffects[0].Details["email"] != "jane@example.com" {
+					t.Errorf("expected an invoice side effect, got %+v", step.SideEffects)
+				}
+				return
+			}
+			invoice := payments.invoices[0]
+			if invoice.CustomerEmail != "jane@example.com" || invoice.InvoiceType != "deposit" || invoice.Metadata["business_id"] != "stlpartyhelpers" {
+				t.Errorf("unexpected invoice request: %+v", invoice)
+			}
+		})
+	}
+}
+
+func TestCreateFinalInvoiceAction(t *testing.T) {
+	payments := &fakePayments{}
+	action := NewCreateFinalInvoiceAction(stripeService.NewInvoiceService(payments))
+	pctx := &domain.PipelineContext{
+		Fields:   map[string]any{"email": "jane@example.com"},
+		Business: &domain.BusinessConfig{Stripe: domain.StripeConfig{DefaultCurrency: "cad"}},
+	}
+
+	ctx := domain.WithActionConfig(context.Background(), map[string]any{"totalCents": 50000, "depositPaidCents": 10000})
+	step := action.Execute(ctx, pctx)
+	if step.Status != "ok" || step.Outputs["amountCents"] != float64(40000) || step.Outputs["invoiceId"] != "in_final" {
+		t.Fatalf("expected a final invoice for the balance, got %+v", step)
+	}
+	if invoice := payments.finalInvoices[0]; invoice.Currency != "cad" || invoice.DepositPaidCents != 10000 {
+		t.Errorf("expected the business currency and the deposit paid, got %+v", invoice)
+	}
+
+	ctx = domain.WithActionConfig(context.Background(), map[string]any{"totalCents": 50000, "depositPaidCents": 50000})
+	if step := action.Execute(ctx, pctx); step.Status != "failed" || !step.Permanent {
+		t.Errorf("expected a paid-up balance to fail permanently, got %+v", step)
+	}
+	if len(payments.finalInvoices) != 1 {
+		t.Errorf("expected 1 final invoice, got %d", len(payments.finalInvoices))
+	}
+}
+
+func TestVoidInvoiceAction(t *testing.T) {
+	payments := &fakePayments{}
+	action := NewVoidInvoiceAction(stripeService.NewInvoiceService(payments))
+	pctx := &domain.PipelineContext{}
+
+	ctx := domain.WithActionConfig(context.Background(), map[string]any{"invoiceId": "in_1"})
+	if step := action.Plan(ctx, pctx); step.Status != "ok" || len(step.SideEffects) != 1 || len(payments.voided) != 0 {
+		t.Fatalf("expected a planned void without voiding, got %+v", step)
+	}
+	if step := action.Execute(ctx, pctx); step.Status != "ok" || len(payments.voided) != 1 || payments.voided[0] != "in_1" {
+		t.Fatalf("expected in_1 to be voided, got %+v (voided %v)", step, payments.voided)
+	}
+
+	if step := action.Execute(domain.WithActionConfig(context.Background(), nil), pctx); step.Status != "failed" || !step.Permanent {
+		t.Errorf("expected a missing invoiceId to fail permanently, got %+v", step)
+	}
+}
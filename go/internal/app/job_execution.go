@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// runPipelineJob runs a pipeline and persists its job. When async is set the job is
+// saved as "pending", queued on the worker pool and a pending result with the job ID
+// is returned immediately; step progress is saved to the jobs repo as it happens.
+// When previous is set the run resumes that job instead of starting a new one.
+func runPipelineJob(
+	ctx context.Context,
+	runner *domain.PipelineRunner,
+	jobsRepo ports.JobsRepo,
+	pool *WorkerPool,
+	pipeline *domain.PipelineDefinition,
+	pctx *domain.PipelineContext,
+	previous *domain.Job,
+	async bool,
+) (*domain.PipelineResult, error) {
+	if !async {
+		result, job := runner.ResumeObserved(ctx, pipeline, pctx, previous, nil)
+		if job != nil {
+			// The pipeline already ran, so a lost history entry does not fail the request
+			if err := jobsRepo.Save(ctx, job); err != nil {
+				jobLogger(pool).Error("failed to save job", "jobId", job.ID, "status", job.Status, "error", err)
+			}
+		}
+		return result, nil
+	}
+
+	if pool == nil {
+		return nil, fmt.Errorf("async execution is not enabled")
+	}
+
+	pending := &domain.Job{
+		ID:          pctx.RequestID,
+		BusinessID:  pctx.BusinessID,
+		PipelineKey: pctx.PipelineKey,
+		Status:      "pending",
+		Steps:       []domain.JobStep{},
+		Input:       pctx.Fields,
+		Result:      make(map[string]any),
+		CreatedAt:   time.Now(),
+		Source:      pctx.Source,
+		DryRun:      pctx.DryRun,
+		Options:     pctx.Options,
+		Resource:    pctx.Resource,
+		ReplayOf:    pctx.ReplayOf,
+	}
+	if previous != nil {
+		// Keep the previous steps visible until the resumed run reports progress
+		pending = previous.Snapshot()
+		pending.Status = "pending"
+		pending.Result = make(map[string]any)
+	}
+	if err := jobsRepo.Save(ctx, pending.Snapshot()); err != nil {
+		return nil, fmt.Errorf("failed to save pending job: %w", err)
+	}
+
+	err := pool.Submit(func(workerCtx context.Context) {
+		runQueuedJob(workerCtx, pool.logger, runner, jobsRepo, pipeline, pctx, previous, pending)
+	})
+	if err != nil {
+		failed := pending.Snapshot()
+		failed.Status = "failed"
+		failed.Result["error"] = err.Error()
+		_ = jobsRepo.Save(ctx, failed)
+		return nil, err
+	}
+
+	return &domain.PipelineResult{
+		PipelineKey: pipeline.Key,
+		BusinessID:  pctx.BusinessID,
+		DryRun:      pctx.DryRun,
+		Steps:       []domain.JobStep{},
+		JobID:       pending.ID,
+		Status:      pending.Status,
+	}, nil
+}
+
+// runQueuedJob executes a queued pipeline on a worker, saving every progress snapshot
+func runQueuedJob(
+	ctx context.Context,
+	logger *slog.Logger,
+	runner *domain.PipelineRunner,
+	jobsRepo ports.JobsRepo,
+	pipeline *domain.PipelineDefinition,
+	pctx *domain.PipelineContext,
+	previous *domain.Job,
+	pending *domain.Job,
+) {
+	logger = logger.With("jobId", pending.ID, "pipelineKey", pending.PipelineKey)
+	save := func(job *domain.Job) {
+		if err := jobsRepo.Save(ctx, job); err != nil {
+			logger.Error("failed to save job", "status", job.Status, "error", err)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("pipeline job panicked", "panic", r, "stack", string(debug.Stack()))
+			failed := pending.Snapshot()
+			failed.Status = "failed"
+			failed.Result["error"] = fmt.Sprintf("panic: %v", r)
+			save(failed)
+		}
+	}()
+
+	observe := func(snapshot *domain.Job) {
+		snapshot.CreatedAt = pending.CreatedAt
+		save(snapshot)
+	}
+
+	result, job := runner.ResumeObserved(ctx, pipeline, pctx, previous, observe)
+	if job == nil {
+		return
+	}
+	job.CreatedAt = pending.CreatedAt
+	if result != nil && result.Error != nil {
+		job.Result["error"] = *result.Error
+	}
+	save(job)
+}
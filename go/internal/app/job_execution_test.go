@@ -0,0 +1,195 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/infra/db"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// blockingAction waits on release before completing, so tests can observe progress
+type blockingAction struct {
+	release chan struct{}
+}
+
+func (a *blockingAction) Name() string {
+	return "blocking"
+}
+
+func (a *blockingAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	<-a.release
+	return domain.JobStep{Name: a.Name(), Status: "ok"}
+}
+
+func TestRunPipelineJob_Async(t *testing.T) {
+	blocking := &blockingAction{release: make(chan struct{})}
+	runner := domain.NewPipelineRunner(map[string]domain.Action{
+		"normalize_input": &NormalizeInputAction{},
+		"blocking":        blocking,
+	})
+	jobsRepo := db.NewMemoryJobsRepo()
+	pool := NewWorkerPool(1, 1)
+	defer pool.Shutdown(context.Background())
+
+	pipeline := &domain.PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []domain.ActionDefinition{
+			{Name: "normalize_input", Critical: true},
+			{Name: "blocking"},
+		},
+	}
+	pctx := &domain.PipelineContext{
+		BusinessID:  "stlpartyhelpers",
+		PipelineKey: "quote_and_deposit",
+		RequestID:   "req_async",
+		Fields:      map[string]any{"email": "jane@example.com"},
+	}
+
+	result, err := runPipelineJob(context.Background(), runner, jobsRepo, pool, pipeline, pctx, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != "pending" || result.JobID != "req_async" {
+		t.Fatalf("expected pending result for req_async, got status=%q jobId=%q", result.Status, result.JobID)
+	}
+
+	// The first step is saved while the second one is still running
+	job := waitForJob(t, jobsRepo, "req_async", func(job *domain.Job) bool {
+		return job.Status == "running" && len(job.Steps) == 1
+	})
+	if job.Steps[0].Name != "normalize_input" {
+		t.Errorf("expected normalize_input progress, got %s", job.Steps[0].Name)
+	}
+
+	close(blocking.release)
+	job = waitForJob(t, jobsRepo, "req_async", func(job *domain.Job) bool {
+		return job.Status == "completed"
+	})
+	if len(job.Steps) != 2 {
+		t.Errorf("expected 2 steps, got %d", len(job.Steps))
+	}
+}
+
+func TestRunPipelineJob_QueueFull(t *testing.T) {
+	blocking := &blockingAction{release: make(chan struct{})}
+	defer close(blocking.release)
+	runner := domain.NewPipelineRunner(map[string]domain.Action{"blocking": blocking})
+	jobsRepo := db.NewMemoryJobsRepo()
+	pool := NewWorkerPool(1, 1)
+
+	pipeline := &domain.PipelineDefinition{
+		Key:     "deposit_only",
+		Actions: []domain.ActionDefinition{{Name: "blocking"}},
+	}
+	submit := func(id string) error {
+		_, err := runPipelineJob(context.Background(), runner, jobsRepo, pool, pipeline, &domain.PipelineContext{RequestID: id}, nil, true)
+		return err
+	}
+
+	// Occupy the single worker, then fill the queue
+	if err := submit("req_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitForJob(t, jobsRepo, "req_1", func(job *domain.Job) bool { return job.Status == "running" })
+	if err := submit("req_2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := submit("req_3"); !errors.Is(err, ErrJobQueueFull) {
+		t.Fatalf("expected ErrJobQueueFull, got %v", err)
+	}
+
+	job, err := jobsRepo.GetByID(context.Background(), "req_3")
+	if err != nil {
+		t.Fatalf("expected rejected job to be saved: %v", err)
+	}
+	if job.Status != "failed" {
+		t.Errorf("expected rejected job to be failed, got %s", job.Status)
+	}
+}
+
+// panickingAction panics instead of returning a step
+type panickingAction struct{}
+
+func (a *panickingAction) Name() string {
+	return "panicking"
+}
+
+func (a *panickingAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	panic("nil map write")
+}
+
+func TestRunPipelineJob_Panic(t *testing.T) {
+	runner := domain.NewPipelineRunner(map[string]domain.Action{"panicking": &panickingAction{}})
+	jobsRepo := db.NewMemoryJobsRepo()
+	var logs bytes.Buffer
+	pool := NewWorkerPool(1, 1)
+	pool.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	pipeline := &domain.PipelineDefinition{
+		Key:     "deposit_only",
+		Actions: []domain.ActionDefinition{{Name: "panicking"}},
+	}
+	if _, err := runPipelineJob(context.Background(), runner, jobsRepo, pool, pipeline, &domain.PipelineContext{RequestID: "req_panic"}, nil, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	job, err := jobsRepo.GetByID(context.Background(), "req_panic")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if job.Status != "failed" || job.Result["error"] != "panic: nil map write" {
+		t.Errorf("expected the job to fail with the panic, got status=%s result=%v", job.Status, job.Result)
+	}
+	if out := logs.String(); !strings.Contains(out, "pipeline job panicked") || !strings.Contains(out, "stack=") {
+		t.Errorf("expected the panic to be logged with its stack, got %q", out)
+	}
+}
+
+func TestRunPipelineJob_Sync(t *testing.T) {
+	runner := domain.NewPipelineRunner(map[string]domain.Action{"normalize_input": &NormalizeInputAction{}})
+	jobsRepo := db.NewMemoryJobsRepo()
+
+	pipeline := &domain.PipelineDefinition{
+		Key:     "deposit_only",
+		Actions: []domain.ActionDefinition{{Name: "normalize_input"}},
+	}
+
+	result, err := runPipelineJob(context.Background(), runner, jobsRepo, nil, pipeline, &domain.PipelineContext{RequestID: "req_sync"}, nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Success || result.Status != "" {
+		t.Errorf("expected synchronous success, got success=%v status=%q", result.Success, result.Status)
+	}
+
+	job, err := jobsRepo.GetByID(context.Background(), "req_sync")
+	if err != nil || job.Status != "completed" {
+		t.Errorf("expected completed job to be saved, got %v (err %v)", job, err)
+	}
+}
+
+// waitForJob polls the repo until the job matches or the test times out
+func waitForJob(t *testing.T, repo ports.JobsRepo, id string, match func(job *domain.Job) bool) *domain.Job {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := repo.GetByID(context.Background(), id)
+		if err == nil && match(job) {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for job %s", id)
+	return nil
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// ErrJobNotResumable is returned when resuming a job that has not failed
+var ErrJobNotResumable = errors.New("only failed jobs can be resumed")
+
+// JobsService resumes and replays stored jobs
+type JobsService struct {
+	businesses     ports.BusinessesRepo
+	pipelines      ports.PipelinesRepo
+	pipelineRunner *domain.PipelineRunner
+	jobsRepo       ports.JobsRepo
+	workerPool     *WorkerPool
+	// reloader picks up config changes before a replay; nil when the config
+	// is not hot reloaded
+	reloader *ConfigReloader
+}
+
+// NewJobsService creates a new jobs service
+func NewJobsService(
+	businesses ports.BusinessesRepo,
+	pipelines ports.PipelinesRepo,
+	pipelineRunner *domain.PipelineRunner,
+	jobsRepo ports.JobsRepo,
+	workerPool *WorkerPool,
+) *JobsService {
+	return &JobsService{
+		businesses:     businesses,
+		pipelines:      pipelines,
+		pipelineRunner: pipelineRunner,
+		jobsRepo:       jobsRepo,
+		workerPool:     workerPool,
+	}
+}
+
+// SetConfigReloader makes Replay load config changes through reloader first
+func (s *JobsService) SetConfigReloader(reloader *ConfigReloader) {
+	s.reloader = reloader
+}
+
+// Resume continues a failed job from its failed step. Steps that already
+// succeeded are reused with their outputs instead of being executed again, and
+// the job keeps its ID.
+func (s *JobsService) Resume(ctx context.Context, req *ResumeJobRequest) (*domain.PipelineResult, error) {
+	job, err := s.jobsRepo.GetByID(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != "failed" {
+		return nil, fmt.Errorf("%w: job %s is %s", ErrJobNotResumable, job.ID, job.Status)
+	}
+
+	// Claim the job so that concurrent resumes cannot run its steps twice
+	claimed, err := s.jobsRepo.CompareAndSetStatus(ctx, job.ID, "failed", "running")
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, fmt.Errorf("%w: job %s is already being resumed", ErrJobNotResumable, job.ID)
+	}
+
+	result, err := s.resume(ctx, job, req.Async)
+	if err != nil {
+		// Nothing ran, so hand the job back for another resume
+		_, _ = s.jobsRepo.CompareAndSetStatus(ctx, job.ID, "running", "failed")
+		return nil, err
+	}
+	return result, nil
+}
+
+// resume runs a claimed job from where it failed
+func (s *JobsService) resume(ctx context.Context, job *domain.Job, async bool) (*domain.PipelineResult, error) {
+	pipeline, pctx, err := s.pipelineContext(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	pctx.RequestID = job.ID
+	pctx.ReplayOf = job.ReplayOf
+
+	return runPipelineJob(ctx, s.pipelineRunner, s.jobsRepo, s.workerPool, pipeline, pctx, job, async)
+}
+
+// Replay re-executes every step of a stored job's input against the current
+// pipeline definition as a new job linked to the original through ReplayOf
+func (s *JobsService) Replay(ctx context.Context, req *ReplayJobRequest) (*domain.PipelineResult, error) {
+	job, err := s.jobsRepo.GetByID(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replays exist to try out config changes, so load any pending change
+	// first. It goes through the validated reload, so a broken edit is
+	// reported instead of replacing the loaded config.
+	if s.reloader != nil {
+		if err := s.reloader.Check(ctx); err != nil {
+			return nil, fmt.Errorf("config change rejected: %w", err)
+		}
+	}
+
+	pipeline, pctx, err := s.pipelineContext(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	pctx.RequestID = req.RequestID
+	pctx.DryRun = req.DryRun
+	pctx.ReplayOf = job.ID
+
+	return runPipelineJob(ctx, s.pipelineRunner, s.jobsRepo, s.workerPool, pipeline, pctx, nil, req.Async)
+}
+
+// pipelineContext loads the current pipeline definition for a stored job and
+// rebuilds the context it was started with
+func (s *JobsService) pipelineContext(ctx context.Context, job *domain.Job) (*domain.PipelineDefinition, *domain.PipelineContext, error) {
+	business, err := s.businesses.GetByID(ctx, job.BusinessID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load business: %w", err)
+	}
+
+	pipeline, err := s.pipelines.GetByKey(ctx, job.PipelineKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load pipeline: %w", err)
+	}
+
+	pctx := &domain.PipelineContext{
+		BusinessID:  job.BusinessID,
+		PipelineKey: job.PipelineKey,
+		Source:      job.Source,
+		DryRun:      job.DryRun,
+		Fields:      job.Input,
+		Options:     job.Options,
+		Resource:    job.Resource,
+		Business:    business,
+	}
+	return pipeline, pctx, nil
+}
+
+// ResumeJobRequest represents a request to resume a failed job
+type ResumeJobRequest struct {
+	JobID string
+	// Async queues the resumed job and returns without waiting for it to finish
+	Async bool
+}
+
+// ReplayJobRequest represents a request to replay a job
+type ReplayJobRequest struct {
+	JobID string
+	// RequestID becomes the ID of the replay job
+	RequestID string
+	DryRun    bool
+	// Async queues the replay and returns without waiting for it to finish
+	Async bool
+}
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/infra/db"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// flakyAction fails until fail is cleared
+type flakyAction struct {
+	name  string
+	fail  bool
+	calls int
+}
+
+func (a *flakyAction) Name() string {
+	return a.name
+}
+
+func (a *flakyAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	a.calls++
+	if a.fail {
+		return domain.JobStep{Name: a.name, Status: "failed", Critical: true, Error: stringPtr("temporarily unavailable")}
+	}
+	return domain.JobStep{Name: a.name, Status: "ok", Outputs: map[string]any{"dryRun": pctx.DryRun}}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// writeTestConfig writes a business and pipeline YAML into a temporary config dir
+func writeTestConfig(t *testing.T, pipelineYAML string) *config.Config {
+	t.Helper()
+	return writeConfigFiles(t, map[string]string{
+		"businesses/stlpartyhelpers.yaml":  "id: stlpartyhelpers\n",
+		"pipelines/quote_and_deposit.yaml": pipelineYAML,
+	})
+}
+
+// writeConfigFiles writes files, keyed by path relative to the config dir, into a
+// temporary config dir
+func writeConfigFiles(t *testing.T, files map[string]string) *config.Config {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &config.Config{ConfigDir: dir}
+}
+
+const resumePipelineYAML = `key: quote_and_deposit
+actions:
+  - name: create_deposit_invoice
+    critical: true
+  - name: send_deposit_email
+    critical: true
+`
+
+func TestJobsService_Resume(t *testing.T) {
+	ctx := context.Background()
+	cfg := writeTestConfig(t, resumePipelineYAML)
+	invoice := &flakyAction{name: "create_deposit_invoice"}
+	email := &flakyAction{name: "send_deposit_email", fail: true}
+	runner := domain.NewPipelineRunner(map[string]domain.Action{invoice.name: invoice, email.name: email})
+	jobsRepo := db.NewMemoryJobsRepo()
+	loader := config.NewBusinessLoader(cfg)
+	businesses, pipelines := config.NewYAMLBusinessesRepo(loader), config.NewYAMLPipelinesRepo(loader)
+
+	formEvents := NewFormEventsService(businesses, pipelines, runner, jobsRepo, nil)
+	result, err := formEvents.Run(ctx, &FormEventsRequest{BusinessID: "stlpartyhelpers", PipelineKey: "quote_and_deposit", Source: "form", RequestID: "req_1"})
+	if err != nil || result.Success {
+		t.Fatalf("expected the first run to fail, got success=%v err=%v", result.Success, err)
+	}
+
+	service := NewJobsService(businesses, pipelines, runner, jobsRepo, nil)
+
+	email.fail = false
+	result, err = service.Resume(ctx, &ResumeJobRequest{JobID: "req_1"})
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if !result.Success || result.JobID != "req_1" {
+		t.Fatalf("expected resumed job req_1 to succeed, got %+v", result)
+	}
+	if invoice.calls != 1 || email.calls != 2 {
+		t.Errorf("expected invoice to run once and email twice, got %d and %d", invoice.calls, email.calls)
+	}
+
+	job, err := jobsRepo.GetByID(ctx, "req_1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if job.Status != "completed" || job.Source != "form" || len(job.Steps) != 2 {
+		t.Errorf("expected the stored job to be completed in place, got %+v", job)
+	}
+
+	// Only failed jobs can be resumed
+	if _, err := service.Resume(ctx, &ResumeJobRequest{JobID: "req_1"}); !errors.Is(err, ErrJobNotResumable) {
+		t.Errorf("expected ErrJobNotResumable, got %v", err)
+	}
+}
+
+// staleJobsRepo returns a fixed copy of a job from GetByID, like a caller that
+// read the job just before another resume claimed it
+type staleJobsRepo struct {
+	ports.JobsRepo
+	stale *domain.Job
+}
+
+func (r *staleJobsRepo) GetByID(ctx context.Context, id string) (*domain.Job, error) {
+	return r.stale.Snapshot(), nil
+}
+
+func TestJobsService_ResumeClaim(t *testing.T) {
+	ctx := context.Background()
+	cfg := writeTestConfig(t, resumePipelineYAML)
+	invoice := &flakyAction{name: "create_deposit_invoice"}
+	email := &flakyAction{name: "send_deposit_email", fail: true}
+	runner := domain.NewPipelineRunner(map[string]domain.Action{invoice.name: invoice, email.name: email})
+	jobsRepo := db.NewMemoryJobsRepo()
+	loader := config.NewBusinessLoader(cfg)
+	businesses, pipelines := config.NewYAMLBusinessesRepo(loader), config.NewYAMLPipelinesRepo(loader)
+
+	formEvents := NewFormEventsService(businesses, pipelines, runner, jobsRepo, nil)
+	if _, err := formEvents.Run(ctx, &FormEventsRequest{BusinessID: "stlpartyhelpers", PipelineKey: "quote_and_deposit", RequestID: "req_1"}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	failed, err := jobsRepo.GetByID(ctx, "req_1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+
+	// Another resume has claimed the job since it was read
+	if _, err := jobsRepo.CompareAndSetStatus(ctx, "req_1", "failed", "running"); err != nil {
+		t.Fatalf("CompareAndSetStatus failed: %v", err)
+	}
+	service := NewJobsService(businesses, pipelines, runner, &staleJobsRepo{JobsRepo: jobsRepo, stale: failed}, nil)
+
+	email.fail = false
+	if _, err := service.Resume(ctx, &ResumeJobRequest{JobID: "req_1"}); !errors.Is(err, ErrJobNotResumable) {
+		t.Fatalf("expected ErrJobNotResumable, got %v", err)
+	}
+	if invoice.calls != 1 || email.calls != 1 {
+		t.Errorf("expected no step to run again, got invoice=%d email=%d", invoice.calls, email.calls)
+	}
+
+	// A resume that cannot start releases its claim
+	if _, err := jobsRepo.CompareAndSetStatus(ctx, "req_1", "running", "failed"); err != nil {
+		t.Fatalf("CompareAndSetStatus failed: %v", err)
+	}
+	if _, err := service.Resume(ctx, &ResumeJobRequest{JobID: "req_1", Async: true}); err == nil {
+		t.Fatal("expected async resume without a worker pool to fail")
+	}
+	job, err := jobsRepo.GetByID(ctx, "req_1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if job.Status != "failed" {
+		t.Errorf("expected the job to be resumable again, got %s", job.Status)
+	}
+}
+
+func TestJobsService_Replay(t *testing.T) {
+	ctx := context.Background()
+	cfg := writeTestConfig(t, resumePipelineYAML)
+	invoice := &flakyAction{name: "create_deposit_invoice"}
+	email := &flakyAction{name: "send_deposit_email"}
+	runner := domain.NewPipelineRunner(map[string]domain.Action{invoice.name: invoice, email.name: email})
+	jobsRepo := db.NewMemoryJobsRepo()
+	loader := config.NewBusinessLoader(cfg)
+	businesses, pipelines := config.NewYAMLBusinessesRepo(loader), config.NewYAMLPipelinesRepo(loader)
+
+	formEvents := NewFormEventsService(businesses, pipelines, runner, jobsRepo, nil)
+	if _, err := formEvents.Run(ctx, &FormEventsRequest{BusinessID: "stlpartyhelpers", PipelineKey: "quote_and_deposit", RequestID: "req_1"}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	service := NewJobsService(businesses, pipelines, runner, jobsRepo, nil)
+	service.SetConfigReloader(NewConfigReloader(loader, slog.New(slog.NewTextHandler(io.Discard, nil)), 0))
+
+	// A broken edit is rejected and the loaded pipeline stays in use
+	if err := os.WriteFile(cfg.GetPipelineConfigPath("quote_and_deposit"), []byte("actions: [oops"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.Replay(ctx, &ReplayJobRequest{JobID: "req_1", RequestID: "req_bad", DryRun: true}); err == nil {
+		t.Fatal("expected the broken pipeline edit to be rejected")
+	}
+	if pipeline, err := pipelines.GetByKey(ctx, "quote_and_deposit"); err != nil || len(pipeline.Actions) != 2 {
+		t.Fatalf("expected the previous pipeline to stay loaded, got %+v, %v", pipeline, err)
+	}
+
+	// The replay must pick up the edited pipeline even though it is cached
+	edited := resumePipelineYAML + "  - name: create_calendar_event\n"
+	if err := os.WriteFile(cfg.GetPipelineConfigPath("quote_and_deposit"), []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := service.Replay(ctx, &ReplayJobRequest{JobID: "req_1", RequestID: "req_2", DryRun: true})
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if result.JobID != "req_2" || !result.DryRun {
+		t.Errorf("expected dry-run replay job req_2, got %+v", result)
+	}
+	if invoice.calls != 2 {
+		t.Errorf("expected every step to run again, invoice ran %d times", invoice.calls)
+	}
+	if len(result.Steps) != 3 || result.Steps[2].Name != "create_calendar_event" {
+		t.Errorf("expected replay against the current pipeline YAML, got %+v", result.Steps)
+	}
+
+	replay, err := jobsRepo.GetByID(ctx, "req_2")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if replay.ReplayOf != "req_1" || !replay.DryRun {
+		t.Errorf("expected replay linked to req_1, got replayOf=%q dryRun=%v", replay.ReplayOf, replay.DryRun)
+	}
+}
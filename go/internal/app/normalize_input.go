@@ -0,0 +1,225 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// defaultInputDateFormat is the layout date fields are rewritten to
+const defaultInputDateFormat = "2006-01-02"
+
+var (
+	digitPattern = regexp.MustCompile(`\d`)
+	emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// FieldError describes why one input field failed validation
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// NormalizeInputAction rewrites pipeline fields into the canonical form declared
+// by the business input schema. Missing required fields or values that cannot be
+// coerced fail the step critically, listing every invalid field.
+type NormalizeInputAction struct{}
+
+func (a *NormalizeInputAction) Name() string {
+	return "normalize_input"
+}
+
+// SideEffectFree marks the action as safe to run again when a job is resumed
+func (a *NormalizeInputAction) SideEffectFree() bool {
+	return true
+}
+
+func (a *NormalizeInputAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Renames, defaults and coerces fields per the business input schema",
+	}
+}
+
+func (a *NormalizeInputAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if pctx.Business == nil || len(pctx.Business.Input.Fields) == 0 {
+		return domain.JobStep{
+			Name:   a.Name(),
+			Status: "ok",
+			Details: map[string]any{
+				"message": "no input schema configured, fields left unchanged",
+				"fields":  pctx.Fields,
+			},
+		}
+	}
+
+	fields, fieldErrors := NormalizeFields(pctx.Business.Input, pctx.Fields)
+	if len(fieldErrors) > 0 {
+		errMsg := fmt.Sprintf("input validation failed: %d invalid field(s)", len(fieldErrors))
+		return domain.JobStep{
+			Name:      a.Name(),
+			Status:    "failed",
+			Critical:  true,
+			Error:     &errMsg,
+			Permanent: true,
+			Details: map[string]any{
+				"errorCode": domain.ErrCodeInvalidInput,
+				"errors":    fieldErrors,
+			},
+		}
+	}
+
+	// Replace rather than mutate so the job keeps the raw input
+	pctx.Fields = fields
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": "input normalized",
+			"fields":  fields,
+		},
+	}
+}
+
+// NormalizeFields applies schema to raw fields. It returns a new map holding the
+// canonical fields plus any fields the schema does not mention; aliases are
+// dropped once mapped. Errors are returned in schema order.
+func NormalizeFields(schema domain.InputSchema, raw map[string]any) (map[string]any, []FieldError) {
+	out := make(map[string]any, len(raw))
+	consumed := make(map[string]bool)
+	for _, field := range schema.Fields {
+		consumed[field.Name] = true
+		for _, alias := range field.Aliases {
+			consumed[alias] = true
+		}
+		if field.Fallback != nil {
+			consumed[field.Fallback.Field] = true
+		}
+	}
+	for key, value := range raw {
+		if !consumed[key] {
+			out[key] = value
+		}
+	}
+
+	var fieldErrors []FieldError
+	for _, field := range schema.Fields {
+		value, ok := lookupInputField(raw, field)
+
+		if ok && field.Fallback != nil {
+			fallback, _ := raw[field.Fallback.Field].(string)
+			value = util.ConsolidateWithFallback(inputString(value), strings.TrimSpace(fallback), field.Fallback.Trigger, inputString(field.Default))
+			ok = value != ""
+		}
+		if !ok && field.Default != nil {
+			value, ok = field.Default, true
+		}
+		if !ok {
+			if field.Required {
+				fieldErrors = append(fieldErrors, FieldError{Field: field.Name, Message: "is required"})
+			}
+			continue
+		}
+
+		coerced, err := coerceInputField(field, value)
+		if err != nil {
+			fieldErrors = append(fieldErrors, FieldError{Field: field.Name, Message: err.Error()})
+			continue
+		}
+		out[field.Name] = coerced
+	}
+
+	return out, fieldErrors
+}
+
+// lookupInputField returns the first non-empty value under the canonical name or an alias
+func lookupInputField(raw map[string]any, field domain.InputField) (any, bool) {
+	for _, key := range append([]string{field.Name}, field.Aliases...) {
+		value, ok := raw[key]
+		if !ok || value == nil {
+			continue
+		}
+		if s, isString := value.(string); isString && strings.TrimSpace(s) == "" {
+			continue
+		}
+		return value, true
+	}
+	return nil, false
+}
+
+// coerceInputField converts value to the field's declared type
+func coerceInputField(field domain.InputField, value any) (any, error) {
+	switch field.Type {
+	case "", domain.InputTypeString:
+		return inputString(value), nil
+
+	case domain.InputTypeEmail:
+		email := strings.ToLower(inputString(value))
+		if !emailPattern.MatchString(email) {
+			return nil, fmt.Errorf("must be a valid email address, got %q", email)
+		}
+		return email, nil
+
+	case domain.InputTypeInteger:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case float64:
+			return int(v), nil
+		}
+		s := inputString(value)
+		if !digitPattern.MatchString(s) {
+			return nil, fmt.Errorf("must contain a whole number, got %q", s)
+		}
+		return util.ExtractFirstInteger(s), nil
+
+	case domain.InputTypeFloat:
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+		s := inputString(value)
+		if !digitPattern.MatchString(s) {
+			return nil, fmt.Errorf("must contain a number, got %q", s)
+		}
+		return util.ExtractFirstFloat(s), nil
+
+	case domain.InputTypeDate:
+		s := inputString(value)
+		t, err := util.ParseDate(s)
+		if err != nil {
+			return nil, fmt.Errorf("must be a date, got %q", s)
+		}
+		format := field.Format
+		if format == "" {
+			format = defaultInputDateFormat
+		}
+		return t.Format(format), nil
+
+	case domain.InputTypeBoolean:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return util.ParseBooleanFromText(inputString(value), "yes", "true", "1", "on"), nil
+
+	default:
+		return nil, fmt.Errorf("unknown field type %q", field.Type)
+	}
+}
+
+// inputString renders a raw field value as trimmed text
+func inputString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,183 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+var testInputSchema = domain.InputSchema{
+	Fields: []domain.InputField{
+		{Name: "email", Aliases: []string{"email_address"}, Type: domain.InputTypeEmail, Required: true},
+		{Name: "eventDate", Aliases: []string{"event_date"}, Type: domain.InputTypeDate, Required: true},
+		{Name: "numHelpers", Aliases: []string{"helpers_requested"}, Type: domain.InputTypeInteger, Required: true},
+		{Name: "durationHours", Aliases: []string{"for_how_many_hours"}, Type: domain.InputTypeFloat},
+		{Name: "occasion", Default: "Unspecified", Fallback: &domain.InputFallback{Field: "occasion_as_you_see_it", Trigger: "other"}},
+		{Name: "scheduleCall", Aliases: []string{"schedule_call"}, Type: domain.InputTypeBoolean, Default: false},
+	},
+}
+
+func TestNormalizeFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        map[string]any
+		want       map[string]any
+		wantErrors []FieldError
+	}{
+		{
+			name: "aliases and coercions",
+			raw: map[string]any{
+				"email_address":      " Jane@Example.com ",
+				"event_date":         "March 14, 2026",
+				"helpers_requested":  "3 helpers",
+				"for_how_many_hours": "about 4.5 hours",
+				"occasion":           "Birthday",
+				"schedule_call":      "Yes, please",
+				"utm_source":         "google",
+			},
+			want: map[string]any{
+				"email":         "jane@example.com",
+				"eventDate":     "2026-03-14",
+				"numHelpers":    3,
+				"durationHours": 4.5,
+				"occasion":      "Birthday",
+				"scheduleCall":  true,
+				"utm_source":    "google",
+			},
+		},
+		{
+			name: "canonical names and JSON numbers",
+			raw: map[string]any{
+				"email":         "jane@example.com",
+				"eventDate":     "2026-03-14",
+				"numHelpers":    float64(2),
+				"durationHours": float64(5),
+			},
+			want: map[string]any{
+				"email":         "jane@example.com",
+				"eventDate":     "2026-03-14",
+				"numHelpers":    2,
+				"durationHours": float64(5),
+				"occasion":      "Unspecified",
+				"scheduleCall":  false,
+			},
+		},
+		{
+			name: "fallback replaces other",
+			raw: map[string]any{
+				"email":                  "jane@example.com",
+				"eventDate":              "2026-03-14",
+				"numHelpers":             "2",
+				"occasion":               "Other (please specify)",
+				"occasion_as_you_see_it": "Retirement party",
+			},
+			want: map[string]any{
+				"email":        "jane@example.com",
+				"eventDate":    "2026-03-14",
+				"numHelpers":   2,
+				"occasion":     "Retirement party",
+				"scheduleCall": false,
+			},
+		},
+		{
+			name: "other without fallback uses default",
+			raw: map[string]any{
+				"email":      "jane@example.com",
+				"eventDate":  "2026-03-14",
+				"numHelpers": "2",
+				"occasion":   "Other",
+			},
+			want: map[string]any{
+				"email":        "jane@example.com",
+				"eventDate":    "2026-03-14",
+				"numHelpers":   2,
+				"occasion":     "Unspecified",
+				"scheduleCall": false,
+			},
+		},
+		{
+			name: "validation errors",
+			raw: map[string]any{
+				"email_address":     "not-an-email",
+				"helpers_requested": "a few",
+				"event_date":        "   ",
+			},
+			wantErrors: []FieldError{
+				{Field: "email", Message: `must be a valid email address, got "not-an-email"`},
+				{Field: "eventDate", Message: "is required"},
+				{Field: "numHelpers", Message: `must contain a whole number, got "a few"`},
+			},
+		},
+		{
+			name: "unparseable date",
+			raw: map[string]any{
+				"email":      "jane@example.com",
+				"eventDate":  "next saturday",
+				"numHelpers": 2,
+			},
+			wantErrors: []FieldError{
+				{Field: "eventDate", Message: `must be a date, got "next saturday"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, fieldErrors := NormalizeFields(testInputSchema, tt.raw)
+			if !reflect.DeepEqual(fieldErrors, tt.wantErrors) {
+				t.Fatalf("expected errors %v, got %v", tt.wantErrors, fieldErrors)
+			}
+			if tt.wantErrors != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected fields %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeInputAction_Execute(t *testing.T) {
+	action := &NormalizeInputAction{}
+	business := &domain.BusinessConfig{ID: "stlpartyhelpers", Input: testInputSchema}
+
+	t.Run("rewrites fields", func(t *testing.T) {
+		raw := map[string]any{"email_address": "jane@example.com", "event_date": "2026-03-14", "helpers_requested": "3"}
+		pctx := &domain.PipelineContext{Business: business, Fields: raw}
+
+		step := action.Execute(context.Background(), pctx)
+		if step.Status != "ok" {
+			t.Fatalf("expected ok, got %s (%v)", step.Status, step.Error)
+		}
+		if pctx.Fields["numHelpers"] != 3 {
+			t.Errorf("expected canonical numHelpers, got %v", pctx.Fields)
+		}
+		if _, ok := raw["numHelpers"]; ok {
+			t.Error("expected raw input to be left untouched")
+		}
+	})
+
+	t.Run("fails critically", func(t *testing.T) {
+		pctx := &domain.PipelineContext{Business: business, Fields: map[string]any{}}
+
+		step := action.Execute(context.Background(), pctx)
+		if step.Status != "failed" || !step.Critical || !step.Permanent {
+			t.Fatalf("expected permanent critical failure, got %+v", step)
+		}
+		fieldErrors, _ := step.Details["errors"].([]FieldError)
+		if len(fieldErrors) != 3 {
+			t.Errorf("expected 3 field errors, got %v", step.Details["errors"])
+		}
+	})
+
+	t.Run("no schema", func(t *testing.T) {
+		pctx := &domain.PipelineContext{Business: &domain.BusinessConfig{}, Fields: map[string]any{"anything": "goes"}}
+
+		step := action.Execute(context.Background(), pctx)
+		if step.Status != "ok" || pctx.Fields["anything"] != "goes" {
+			t.Errorf("expected fields to pass through, got %+v", step)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// Graph formats supported by PipelineDocsService.Render
+const (
+	GraphFormatMermaid = "mermaid"
+	GraphFormatDOT     = "dot"
+)
+
+// ErrUnknownGraphFormat is returned by Render for an unsupported format
+var ErrUnknownGraphFormat = errors.New("unknown graph format")
+
+// PipelineDoc describes a pipeline for people: what each action does and how
+// it is configured
+type PipelineDoc struct {
+	Key         string      `json:"key"`
+	Description string      `json:"description,omitempty"`
+	Actions     []ActionDoc `json:"actions"`
+	// Calls lists the pipelines run through call_pipeline, in definition order
+	Calls []string `json:"calls,omitempty"`
+}
+
+// ActionDoc describes one action of a pipeline, or a parallel group when
+// Parallel is set
+type ActionDoc struct {
+	Name        string                        `json:"name,omitempty"`
+	Description string                        `json:"description,omitempty"`
+	Critical    bool                          `json:"critical"`
+	When        string                        `json:"when,omitempty"`
+	Timeout     string                        `json:"timeout,omitempty"`
+	Retry       *domain.RetryPolicy           `json:"retry,omitempty"`
+	Config      map[string]any                `json:"config,omitempty"`
+	Schema      map[string]domain.ConfigField `json:"schema,omitempty"`
+	Compensate  *ActionDoc                    `json:"compensate,omitempty"`
+	Parallel    *ParallelDoc                  `json:"parallel,omitempty"`
+}
+
+// ParallelDoc describes a parallel group of actions
+type ParallelDoc struct {
+	MaxConcurrency int         `json:"maxConcurrency,omitempty"`
+	Actions        []ActionDoc `json:"actions"`
+}
+
+// PipelineDocsService documents the configured pipelines using the schemas
+// of the runner's actions
+type PipelineDocsService struct {
+	pipelines ports.PipelinesRepo
+	runner    *domain.PipelineRunner
+}
+
+// NewPipelineDocsService creates a new pipeline docs service
+func NewPipelineDocsService(pipelines ports.PipelinesRepo, runner *domain.PipelineRunner) *PipelineDocsService {
+	return &PipelineDocsService{pipelines: pipelines, runner: runner}
+}
+
+// List documents every pipeline, sorted by key
+func (s *PipelineDocsService) List(ctx context.Context) ([]*PipelineDoc, error) {
+	pipelines, err := s.pipelines.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]*PipelineDoc, len(pipelines))
+	for i, pipeline := range pipelines {
+		docs[i] = s.document(pipeline)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Key < docs[j].Key })
+	return docs, nil
+}
+
+// Get documents one pipeline
+func (s *PipelineDocsService) Get(ctx context.Context, key string) (*PipelineDoc, error) {
+	pipeline, err := s.pipelines.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return s.document(pipeline), nil
+}
+
+// Render draws a pipeline's flow in format (GraphFormatMermaid or
+// GraphFormatDOT), with its conditions, parallel groups, compensations and
+// the pipelines it calls
+func (s *PipelineDocsService) Render(ctx context.Context, key, format string) (string, error) {
+	if format != GraphFormatMermaid && format != GraphFormatDOT {
+		return "", fmt.Errorf("%w: %q", ErrUnknownGraphFormat, format)
+	}
+	pipeline, err := s.pipelines.GetByKey(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	graph, err := buildPipelineGraph(ctx, s.pipelines, pipeline)
+	if err != nil {
+		return "", err
+	}
+	if format == GraphFormatDOT {
+		return graph.dot(pipeline.Key), nil
+	}
+	return graph.mermaid(), nil
+}
+
+func (s *PipelineDocsService) document(pipeline *domain.PipelineDefinition) *PipelineDoc {
+	doc := &PipelineDoc{
+		Key:         pipeline.Key,
+		Description: pipeline.Description,
+		Actions:     make([]ActionDoc, len(pipeline.Actions)),
+		Calls:       domain.CalledPipelines(pipeline),
+	}
+	for i, def := range pipeline.Actions {
+		doc.Actions[i] = s.documentAction(def)
+	}
+	return doc
+}
+
+func (s *PipelineDocsService) documentAction(def domain.ActionDefinition) ActionDoc {
+	if def.Parallel != nil {
+		group := &ParallelDoc{
+			MaxConcurrency: def.Parallel.MaxConcurrency,
+			Actions:        make([]ActionDoc, len(def.Parallel.Actions)),
+		}
+		for i, inner := range def.Parallel.Actions {
+			group.Actions[i] = s.documentAction(inner)
+		}
+		return ActionDoc{Parallel: group}
+	}
+
+	schema, _ := s.runner.ActionSchema(def.Name)
+	doc := ActionDoc{
+		Name:        def.Name,
+		Description: schema.Description,
+		Critical:    def.Critical,
+		When:        def.When,
+		Retry:       def.Retry,
+		Config:      def.Config,
+		Schema:      schema.Config,
+	}
+	if def.Timeout > 0 {
+		doc.Timeout = def.Timeout.String()
+	}
+	if def.Compensate != nil {
+		compensate := s.documentAction(*def.Compensate)
+		doc.Compensate = &compensate
+	}
+	return doc
+}
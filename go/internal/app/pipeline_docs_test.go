@@ -0,0 +1,167 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+// mapPipelinesRepo is a ports.PipelinesRepo over a map
+type mapPipelinesRepo map[string]*domain.PipelineDefinition
+
+func (r mapPipelinesRepo) GetByKey(ctx context.Context, key string) (*domain.PipelineDefinition, error) {
+	pipeline, ok := r[key]
+	if !ok {
+		return nil, fmt.Errorf("pipeline %s: %w", key, fs.ErrNotExist)
+	}
+	return pipeline, nil
+}
+
+func (r mapPipelinesRepo) GetAll(ctx context.Context) ([]*domain.PipelineDefinition, error) {
+	var pipelines []*domain.PipelineDefinition
+	for _, pipeline := range r {
+		pipelines = append(pipelines, pipeline)
+	}
+	return pipelines, nil
+}
+
+func (r mapPipelinesRepo) Save(ctx context.Context, pipeline *domain.PipelineDefinition) error {
+	r[pipeline.Key] = pipeline
+	return nil
+}
+
+func newTestDocsService() *PipelineDocsService {
+	repo := mapPipelinesRepo{
+		"quote_and_deposit": {
+			Key:         "quote_and_deposit",
+			Description: "Quote a lead and collect a deposit",
+			Actions: []domain.ActionDefinition{
+				{Name: "normalize_input", Critical: true},
+				{
+					Parallel: &domain.ParallelGroup{Actions: []domain.ActionDefinition{
+						{Name: "send_slack_notification", Config: map[string]any{"channel": "#leads"}},
+						{Name: "schedule_event_timers", When: `source == "form"`},
+					}},
+				},
+				{Name: "call_pipeline", Critical: true, Config: map[string]any{"pipelineKey": "deposit_only"}},
+			},
+		},
+		"deposit_only": {
+			Key: "deposit_only",
+			Actions: []domain.ActionDefinition{
+				{Name: "send_slack_notification", Compensate: &domain.ActionDefinition{Name: "normalize_input"}},
+			},
+		},
+	}
+	runner := domain.NewPipelineRunner(map[string]domain.Action{
+		"normalize_input":         &NormalizeInputAction{},
+		"send_slack_notification": &SendSlackNotificationAction{},
+		"schedule_event_timers":   nil,
+	})
+	return NewPipelineDocsService(repo, runner)
+}
+
+func TestPipelineDocsService_Get(t *testing.T) {
+	doc, err := newTestDocsService().Get(context.Background(), "quote_and_deposit")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if doc.Description != "Quote a lead and collect a deposit" || strings.Join(doc.Calls, ",") != "deposit_only" {
+		t.Errorf("unexpected pipeline doc: %+v", doc)
+	}
+	if len(doc.Actions) != 3 {
+		t.Fatalf("expected 3 actions, got %d", len(doc.Actions))
+	}
+	if normalize := doc.Actions[0]; !normalize.Critical || normalize.Description == "" {
+		t.Errorf("expected normalize_input to be critical and described, got %+v", normalize)
+	}
+	group := doc.Actions[1].Parallel
+	if group == nil || len(group.Actions) != 2 {
+		t.Fatalf("expected a parallel group of 2, got %+v", doc.Actions[1])
+	}
+	if slack := group.Actions[0]; slack.Schema["channel"].Type != domain.ConfigTypeString || slack.Config["channel"] != "#leads" {
+		t.Errorf("expected send_slack_notification's schema and config, got %+v", slack)
+	}
+	if timers := group.Actions[1]; timers.When != `source == "form"` || timers.Schema != nil {
+		t.Errorf("expected an undescribed conditional action, got %+v", timers)
+	}
+	if call := doc.Actions[2]; call.Schema["pipelineKey"].Required != true {
+		t.Errorf("expected call_pipeline's schema, got %+v", call)
+	}
+
+	if _, err := newTestDocsService().Get(context.Background(), "missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestPipelineDocsService_RenderMermaid(t *testing.T) {
+	got, err := newTestDocsService().Render(context.Background(), "quote_and_deposit", GraphFormatMermaid)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	want := `flowchart TD
+  subgraph c2 ["parallel"]
+    n3["send_slack_notification"]
+    n4{"when source == #quot;form#quot;"}
+    n5["schedule_event_timers"]
+  end
+  subgraph c7 ["pipeline deposit_only"]
+    n8(["deposit_only"])
+    n9["send_slack_notification"]
+    n10[/"normalize_input"/]
+    n11(["end"])
+  end
+  n0(["quote_and_deposit"])
+  n1["normalize_input"]
+  n6[["call_pipeline: deposit_only"]]
+  n12(["end"])
+  n0 --> n1
+  n1 --> n3
+  n1 --> n4
+  n4 -->|"yes"| n5
+  n3 --> n6
+  n5 --> n6
+  n4 -.->|"no"| n6
+  n8 --> n9
+  n9 -.->|"compensate"| n10
+  n9 --> n11
+  n6 -.->|"runs"| n8
+  n6 --> n12
+  classDef critical stroke-width:3px
+  class n1,n6 critical
+`
+	if got != want {
+		t.Errorf("unexpected mermaid graph:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPipelineDocsService_RenderDOT(t *testing.T) {
+	got, err := newTestDocsService().Render(context.Background(), "quote_and_deposit", GraphFormatDOT)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	for _, want := range []string{
+		`digraph "quote_and_deposit" {`,
+		`subgraph cluster_c2 {`,
+		`n4 [label="when source == \"form\"", shape=diamond];`,
+		`n6 [label="call_pipeline: deposit_only", shape=box, peripheries=2, penwidth=3];`,
+		`n4 -> n6 [label="no", style=dashed];`,
+		`n6 -> n8 [label="runs", style=dashed];`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected DOT output to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	if _, err := newTestDocsService().Render(context.Background(), "quote_and_deposit", "svg"); !errors.Is(err, ErrUnknownGraphFormat) {
+		t.Errorf("expected ErrUnknownGraphFormat, got %v", err)
+	}
+}
@@ -0,0 +1,310 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// maxGraphDepth limits how many levels of called pipelines are drawn
+const maxGraphDepth = 8
+
+// Shapes of pipeline graph nodes
+const (
+	shapeTerminal   = "terminal"
+	shapeAction     = "action"
+	shapeCall       = "call"
+	shapeDecision   = "decision"
+	shapeCompensate = "compensate"
+)
+
+type graphNode struct {
+	id       string
+	label    string
+	shape    string
+	critical bool
+}
+
+type graphEdge struct {
+	from, to string
+	label    string
+	dashed   bool
+}
+
+// graphCluster is a group of nodes drawn together: a parallel group or a
+// called pipeline
+type graphCluster struct {
+	id       string
+	label    string
+	nodes    []graphNode
+	clusters []*graphCluster
+}
+
+// pipelineGraph is a pipeline's flow, independent of the output format
+type pipelineGraph struct {
+	root  graphCluster
+	edges []graphEdge
+	next  int
+}
+
+// graphTail is a node the next action is reached from, with the label of
+// that edge
+type graphTail struct {
+	id     string
+	label  string
+	dashed bool
+}
+
+// buildPipelineGraph draws a pipeline from a start node to an end node. An
+// action with a condition is reached through a decision node whose "no"
+// branch skips it; pipelines run by call_pipeline are drawn as nested
+// clusters.
+func buildPipelineGraph(ctx context.Context, pipelines ports.PipelinesRepo, pipeline *domain.PipelineDefinition) (*pipelineGraph, error) {
+	b := &graphBuilder{ctx: ctx, pipelines: pipelines, graph: &pipelineGraph{}}
+	if _, err := b.addPipeline(&b.graph.root, pipeline, []string{pipeline.Key}); err != nil {
+		return nil, err
+	}
+	return b.graph, nil
+}
+
+type graphBuilder struct {
+	ctx       context.Context
+	pipelines ports.PipelinesRepo
+	graph     *pipelineGraph
+}
+
+func (b *graphBuilder) addNode(cluster *graphCluster, label, shape string, critical bool) string {
+	id := fmt.Sprintf("n%d", b.graph.next)
+	b.graph.next++
+	cluster.nodes = append(cluster.nodes, graphNode{id: id, label: label, shape: shape, critical: critical})
+	return id
+}
+
+func (b *graphBuilder) addCluster(parent *graphCluster, label string) *graphCluster {
+	cluster := &graphCluster{id: fmt.Sprintf("c%d", b.graph.next), label: label}
+	b.graph.next++
+	parent.clusters = append(parent.clusters, cluster)
+	return cluster
+}
+
+func (b *graphBuilder) connect(tails []graphTail, to string) {
+	for _, tail := range tails {
+		b.graph.edges = append(b.graph.edges, graphEdge{from: tail.id, to: to, label: tail.label, dashed: tail.dashed})
+	}
+}
+
+// addPipeline draws a pipeline into cluster and returns its start node.
+// stack holds the pipelines being drawn, to stop at call cycles.
+func (b *graphBuilder) addPipeline(cluster *graphCluster, pipeline *domain.PipelineDefinition, stack []string) (string, error) {
+	start := b.addNode(cluster, pipeline.Key, shapeTerminal, false)
+	tails, err := b.addActions(cluster, pipeline.Actions, []graphTail{{id: start}}, stack)
+	if err != nil {
+		return "", err
+	}
+	b.connect(tails, b.addNode(cluster, "end", shapeTerminal, false))
+	return start, nil
+}
+
+func (b *graphBuilder) addActions(cluster *graphCluster, defs []domain.ActionDefinition, tails []graphTail, stack []string) ([]graphTail, error) {
+	for _, def := range defs {
+		if def.Parallel == nil {
+			var err error
+			if tails, err = b.addAction(cluster, def, tails, stack); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		label := "parallel"
+		if def.Parallel.MaxConcurrency > 0 {
+			label = fmt.Sprintf("parallel (max %d)", def.Parallel.MaxConcurrency)
+		}
+		group := b.addCluster(cluster, label)
+		var next []graphTail
+		for _, inner := range def.Parallel.Actions {
+			out, err := b.addAction(group, inner, tails, stack)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, out...)
+		}
+		tails = next
+	}
+	return tails, nil
+}
+
+// addAction draws one action reached from tails and returns the tails the
+// next action is reached from
+func (b *graphBuilder) addAction(cluster *graphCluster, def domain.ActionDefinition, tails []graphTail, stack []string) ([]graphTail, error) {
+	var skipped []graphTail
+	if when := strings.TrimSpace(def.When); when != "" {
+		decision := b.addNode(cluster, "when "+when, shapeDecision, false)
+		b.connect(tails, decision)
+		tails = []graphTail{{id: decision, label: "yes"}}
+		skipped = []graphTail{{id: decision, label: "no", dashed: true}}
+	}
+
+	calledKey, _ := def.Config["pipelineKey"].(string)
+	isCall := def.Name == domain.CallPipelineActionName && calledKey != ""
+	var id string
+	if isCall {
+		id = b.addNode(cluster, def.Name+": "+calledKey, shapeCall, def.Critical)
+	} else {
+		id = b.addNode(cluster, def.Name, shapeAction, def.Critical)
+	}
+	b.connect(tails, id)
+
+	if def.Compensate != nil {
+		compensate := b.addNode(cluster, def.Compensate.Name, shapeCompensate, false)
+		b.graph.edges = append(b.graph.edges, graphEdge{from: id, to: compensate, label: "compensate", dashed: true})
+	}
+
+	// A {{ reference }} key is only known at run time, so there is nothing
+	// to draw for it
+	if isCall && !strings.Contains(calledKey, "{{") {
+		if err := b.addCall(cluster, id, calledKey, stack); err != nil {
+			return nil, err
+		}
+	}
+
+	return append([]graphTail{{id: id}}, skipped...), nil
+}
+
+// addCall draws the pipeline run by a call_pipeline node next to it
+func (b *graphBuilder) addCall(cluster *graphCluster, from, key string, stack []string) error {
+	if slices.Contains(stack, key) || len(stack) >= maxGraphDepth {
+		return nil
+	}
+	called, err := b.pipelines.GetByKey(b.ctx, key)
+	if err != nil {
+		return fmt.Errorf("pipeline %s calls %s: %w", stack[len(stack)-1], key, err)
+	}
+
+	sub := b.addCluster(cluster, "pipeline "+key)
+	start, err := b.addPipeline(sub, called, append(stack[:len(stack):len(stack)], key))
+	if err != nil {
+		return err
+	}
+	b.graph.edges = append(b.graph.edges, graphEdge{from: from, to: start, label: "runs", dashed: true})
+	return nil
+}
+
+// mermaid renders the graph as a Mermaid flowchart
+func (g *pipelineGraph) mermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+
+	var critical []string
+	var writeCluster func(cluster *graphCluster, indent string)
+	writeCluster = func(cluster *graphCluster, indent string) {
+		for _, sub := range cluster.clusters {
+			fmt.Fprintf(&sb, "%ssubgraph %s [\"%s\"]\n", indent, sub.id, mermaidEscape(sub.label))
+			writeCluster(sub, indent+"  ")
+			fmt.Fprintf(&sb, "%send\n", indent)
+		}
+		for _, node := range cluster.nodes {
+			label := mermaidEscape(node.label)
+			var shape string
+			switch node.shape {
+			case shapeTerminal:
+				shape = "([\"" + label + "\"])"
+			case shapeCall:
+				shape = "[[\"" + label + "\"]]"
+			case shapeDecision:
+				shape = "{\"" + label + "\"}"
+			case shapeCompensate:
+				shape = "[/\"" + label + "\"/]"
+			default:
+				shape = "[\"" + label + "\"]"
+			}
+			fmt.Fprintf(&sb, "%s%s%s\n", indent, node.id, shape)
+			if node.critical {
+				critical = append(critical, node.id)
+			}
+		}
+	}
+	writeCluster(&g.root, "  ")
+
+	for _, edge := range g.edges {
+		arrow := "-->"
+		if edge.dashed {
+			arrow = "-.->"
+		}
+		if edge.label != "" {
+			arrow += "|\"" + mermaidEscape(edge.label) + "\"|"
+		}
+		fmt.Fprintf(&sb, "  %s %s %s\n", edge.from, arrow, edge.to)
+	}
+
+	if len(critical) > 0 {
+		sb.WriteString("  classDef critical stroke-width:3px\n")
+		fmt.Fprintf(&sb, "  class %s critical\n", strings.Join(critical, ","))
+	}
+	return sb.String()
+}
+
+// mermaidEscape makes text safe inside a quoted Mermaid label
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
+
+// dot renders the graph as a Graphviz DOT digraph
+func (g *pipelineGraph) dot(name string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %s {\n", dotQuote(name))
+	sb.WriteString("  rankdir=TB;\n  node [shape=box];\n")
+
+	var writeCluster func(cluster *graphCluster, indent string)
+	writeCluster = func(cluster *graphCluster, indent string) {
+		for _, sub := range cluster.clusters {
+			fmt.Fprintf(&sb, "%ssubgraph cluster_%s {\n%s  label=%s;\n", indent, sub.id, indent, dotQuote(sub.label))
+			writeCluster(sub, indent+"  ")
+			fmt.Fprintf(&sb, "%s}\n", indent)
+		}
+		for _, node := range cluster.nodes {
+			attrs := []string{"label=" + dotQuote(node.label)}
+			switch node.shape {
+			case shapeTerminal:
+				attrs = append(attrs, "shape=oval")
+			case shapeCall:
+				attrs = append(attrs, "shape=box", "peripheries=2")
+			case shapeDecision:
+				attrs = append(attrs, "shape=diamond")
+			case shapeCompensate:
+				attrs = append(attrs, "shape=parallelogram")
+			}
+			if node.critical {
+				attrs = append(attrs, "penwidth=3")
+			}
+			fmt.Fprintf(&sb, "%s%s [%s];\n", indent, node.id, strings.Join(attrs, ", "))
+		}
+	}
+	writeCluster(&g.root, "  ")
+
+	for _, edge := range g.edges {
+		var attrs []string
+		if edge.label != "" {
+			attrs = append(attrs, "label="+dotQuote(edge.label))
+		}
+		if edge.dashed {
+			attrs = append(attrs, "style=dashed")
+		}
+		if len(attrs) > 0 {
+			fmt.Fprintf(&sb, "  %s -> %s [%s];\n", edge.from, edge.to, strings.Join(attrs, ", "))
+		} else {
+			fmt.Fprintf(&sb, "  %s -> %s;\n", edge.from, edge.to)
+		}
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotQuote quotes text as a DOT string
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+// Default fields read by schedule_event_timers; override them with the
+// leadField and eventDateField action config
+const (
+	defaultLeadField      = "email"
+	defaultEventDateField = "eventDate"
+)
+
+// ScheduleEventTimersAction schedules the business's event timers for the lead
+// in the pipeline fields. Running it again for the same lead replaces its
+// timers, so a changed event date reschedules them.
+type ScheduleEventTimersAction struct {
+	timers *TimersService
+}
+
+// NewScheduleEventTimersAction creates the schedule_event_timers action
+func NewScheduleEventTimersAction(timers *TimersService) *ScheduleEventTimersAction {
+	return &ScheduleEventTimersAction{timers: timers}
+}
+
+func (a *ScheduleEventTimersAction) Name() string {
+	return "schedule_event_timers"
+}
+
+func (a *ScheduleEventTimersAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Schedules the business event timers for the lead",
+		Config: map[string]domain.ConfigField{
+			"leadField":      {Type: domain.ConfigTypeString, Description: "Field identifying the lead (default: email)"},
+			"eventDateField": {Type: domain.ConfigTypeString, Description: "Field holding the YYYY-MM-DD event date (default: eventDate)"},
+		},
+	}
+}
+
+func (a *ScheduleEventTimersAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	leadField, eventDateField, leadID, eventDate := a.lead(ctx, pctx)
+	if leadID == "" || eventDate == "" {
+		return a.noLead(leadField, eventDateField)
+	}
+
+	timers, err := a.timers.ScheduleEventTimers(ctx, pctx.BusinessID, leadID, eventDate, map[string]any{
+		leadField:      leadID,
+		eventDateField: eventDate,
+	})
+	if err != nil {
+		return domain.FailedStep(a.Name(), timerError(err))
+	}
+
+	scheduled := make(map[string]any, len(timers))
+	for _, timer := range timers {
+		scheduled[timer.Key] = timer.FireAt
+	}
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": fmt.Sprintf("%d event timer(s) scheduled", len(timers)),
+		},
+		Outputs: map[string]any{
+			"leadId": leadID,
+			"timers": scheduled,
+		},
+	}
+}
+
+// Plan returns the timers Execute would schedule, with their fire times
+func (a *ScheduleEventTimersAction) Plan(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	leadField, eventDateField, leadID, eventDate := a.lead(ctx, pctx)
+	if leadID == "" || eventDate == "" {
+		return a.noLead(leadField, eventDateField)
+	}
+
+	timers, err := a.timers.PlanEventTimers(ctx, pctx.BusinessID, leadID, eventDate, map[string]any{
+		leadField:      leadID,
+		eventDateField: eventDate,
+	})
+	if err != nil {
+		return domain.FailedStep(a.Name(), timerError(err))
+	}
+
+	scheduled := make(map[string]any, len(timers))
+	effects := make([]domain.SideEffect, len(timers))
+	for i, timer := range timers {
+		scheduled[timer.Key] = timer.FireAt
+		effects[i] = domain.SideEffect{
+			Kind:    "timer",
+			Summary: fmt.Sprintf("Fire %s for %s at %s", timer.TriggerKey, leadID, timer.FireAt.Format(time.RFC3339)),
+			Details: map[string]any{
+				"key":        timer.Key,
+				"triggerKey": timer.TriggerKey,
+				"leadId":     leadID,
+				"eventDate":  eventDate,
+				"fireAt":     timer.FireAt,
+			},
+		}
+	}
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": fmt.Sprintf("dry run, %d event timer(s) would be scheduled", len(timers)),
+		},
+		Outputs: map[string]any{
+			"leadId": leadID,
+			"timers": scheduled,
+		},
+		SideEffects: effects,
+	}
+}
+
+// lead reads the lead and event date from the fields named by the config
+func (a *ScheduleEventTimersAction) lead(ctx context.Context, pctx *domain.PipelineContext) (leadField, eventDateField, leadID, eventDate string) {
+	config := domain.ActionConfig(ctx)
+	leadField, _ = config["leadField"].(string)
+	if leadField == "" {
+		leadField = defaultLeadField
+	}
+	eventDateField, _ = config["eventDateField"].(string)
+	if eventDateField == "" {
+		eventDateField = defaultEventDateField
+	}
+
+	leadID, _ = pctx.Fields[leadField].(string)
+	eventDate, _ = pctx.Fields[eventDateField].(string)
+	return leadField, eventDateField, leadID, eventDate
+}
+
+// noLead is the step for fields without a lead or event date
+func (a *ScheduleEventTimersAction) noLead(leadField, eventDateField string) domain.JobStep {
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "skipped",
+		Details: map[string]any{
+			"message": fmt.Sprintf("no %s or %s, event timers not scheduled", leadField, eventDateField),
+		},
+	}
+}
+
+// timerError marks invalid timers as permanent failures, since retrying the
+// same fields or config cannot fix them
+func timerError(err error) error {
+	if errors.Is(err, ErrInvalidTimer) {
+		return domain.Permanent(err)
+	}
+	return err
+}
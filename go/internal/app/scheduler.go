@@ -0,0 +1,365 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+const (
+	// DefaultSchedulerInterval is how often the scheduler checks for due runs
+	DefaultSchedulerInterval = 30 * time.Second
+
+	// scheduleLockTTL keeps a fired run claimed long after every instance has
+	// moved past it
+	scheduleLockTTL = 24 * time.Hour
+
+	// dueTimersBatch is the most timers fired per tick
+	dueTimersBatch = 100
+)
+
+// ScheduledRun is an upcoming run of a business schedule
+type ScheduledRun struct {
+	BusinessID  string    `json:"businessId"`
+	ScheduleKey string    `json:"scheduleKey"`
+	Trigger     string    `json:"trigger,omitempty"`
+	PipelineKey string    `json:"pipelineKey"`
+	Cron        string    `json:"cron"`
+	Timezone    string    `json:"timezone"`
+	RunAt       time.Time `json:"runAt"`
+}
+
+// Scheduler fires the cron schedules declared in business configs and due
+// event-relative timers by queueing trigger runs. Every instance of the API may
+// run a scheduler: each due run is claimed through the Locker first, so only one
+// instance fires it.
+type Scheduler struct {
+	businesses      ports.BusinessesRepo
+	triggersService *TriggersService
+	timersRepo      ports.TimersRepo
+	locker          ports.Locker
+	logger          *slog.Logger
+	interval        time.Duration
+
+	mu       sync.Mutex
+	lastTick time.Time
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(
+	businesses ports.BusinessesRepo,
+	triggersService *TriggersService,
+	timersRepo ports.TimersRepo,
+	locker ports.Locker,
+	logger *slog.Logger,
+	interval time.Duration,
+) *Scheduler {
+	if interval <= 0 {
+		interval = DefaultSchedulerInterval
+	}
+	return &Scheduler{
+		businesses:      businesses,
+		triggersService: triggersService,
+		timersRepo:      timersRepo,
+		locker:          locker,
+		logger:          logger,
+		interval:        interval,
+	}
+}
+
+// Start checks for due runs every interval until ctx is cancelled. Runs that
+// were due before Start are not fired.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.Tick(ctx, time.Now())
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Tick(ctx, now)
+		}
+	}
+}
+
+// Tick fires every schedule with a run due since the previous tick and every
+// timer that is due. When several runs of one schedule were missed only the
+// latest is fired, and the first tick only records the starting point for
+// schedules. Timers are durable, so overdue timers fire on the first tick.
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) {
+	s.fireDueTimers(ctx, now)
+
+	s.mu.Lock()
+	last := s.lastTick
+	s.lastTick = now
+	s.mu.Unlock()
+
+	if last.IsZero() {
+		return
+	}
+
+	businesses, err := s.businesses.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("scheduler failed to load businesses", "error", err)
+		return
+	}
+
+	for _, business := range businesses {
+		for _, sc := range business.Pipelines.Schedules {
+			if sc.Disabled {
+				continue
+			}
+			schedule, _, err := parseSchedule(business, sc)
+			if err != nil {
+				s.logger.Error("invalid schedule", "businessId", business.ID, "schedule", sc.Key, "error", err)
+				continue
+			}
+
+			var due time.Time
+			for next := schedule.Next(last); !next.After(now); next = schedule.Next(next) {
+				due = next
+			}
+			if due.IsZero() {
+				continue
+			}
+			s.fire(ctx, business, sc, due)
+		}
+	}
+}
+
+// fire claims a due run and queues it on the triggers service
+func (s *Scheduler) fire(ctx context.Context, business *domain.BusinessConfig, sc domain.ScheduleConfig, due time.Time) {
+	logger := s.logger.With("businessId", business.ID, "schedule", sc.Key, "runAt", due)
+
+	lockKey := fmt.Sprintf("schedule:%s:%s:%d", business.ID, sc.Key, due.Unix())
+	claimed, err := s.locker.TryLock(ctx, lockKey, scheduleLockTTL)
+	if err != nil {
+		logger.Error("failed to claim scheduled run", "error", err)
+		return
+	}
+	if !claimed {
+		logger.Debug("scheduled run already claimed by another instance")
+		return
+	}
+
+	payload := make(map[string]any, len(sc.Payload))
+	for k, v := range sc.Payload {
+		payload[k] = v
+	}
+
+	result, err := s.triggersService.Run(ctx, &TriggerRequest{
+		BusinessID:  business.ID,
+		TriggerKey:  sc.Trigger,
+		PipelineKey: sc.PipelineKey,
+		Source:      "schedule",
+		Payload:     payload,
+		RequestID:   fmt.Sprintf("sched_%s_%s_%d", business.ID, sc.Key, due.Unix()),
+		Async:       true,
+	})
+	if err != nil {
+		logger.Error("failed to queue scheduled run", "error", err)
+		// Give up the claim so an instance that has not passed this run yet
+		// can still fire it
+		if err := s.locker.Unlock(ctx, lockKey); err != nil {
+			logger.Error("failed to release scheduled run", "error", err)
+		}
+		return
+	}
+	logger.Info("scheduled run queued", "jobId", result.JobID)
+}
+
+// fireDueTimers queues the trigger runs of timers that are due
+func (s *Scheduler) fireDueTimers(ctx context.Context, now time.Time) {
+	if s.timersRepo == nil {
+		return
+	}
+
+	timers, err := s.timersRepo.ListDue(ctx, now, dueTimersBatch)
+	if err != nil {
+		s.logger.Error("scheduler failed to list due timers", "error", err)
+		return
+	}
+	for _, timer := range timers {
+		s.fireTimer(ctx, timer)
+	}
+}
+
+// fireTimer claims a due timer, queues its trigger run and records the outcome
+func (s *Scheduler) fireTimer(ctx context.Context, timer *domain.Timer) {
+	logger := s.logger.With("businessId", timer.BusinessID, "timerId", timer.ID, "timer", timer.Key, "fireAt", timer.FireAt)
+
+	lockKey := fmt.Sprintf("timer:%s:%d", timer.ID, timer.FireAt.Unix())
+	claimed, err := s.locker.TryLock(ctx, lockKey, scheduleLockTTL)
+	if err != nil {
+		logger.Error("failed to claim timer", "error", err)
+		return
+	}
+	if !claimed {
+		logger.Debug("timer already claimed by another instance")
+		return
+	}
+	// release gives up the claim so a later tick, here or on another instance,
+	// fires the timer
+	release := func() {
+		if err := s.locker.Unlock(ctx, lockKey); err != nil {
+			logger.Error("failed to release timer", "error", err)
+		}
+	}
+
+	// The timer may have been cancelled or rescheduled since it was listed
+	current, err := s.timersRepo.GetByID(ctx, timer.ID)
+	if err != nil {
+		logger.Error("failed to reload timer", "error", err)
+		release()
+		return
+	}
+	if current.Status != domain.TimerStatusScheduled || !current.FireAt.Equal(timer.FireAt) {
+		return
+	}
+
+	payload := make(map[string]any, len(current.Payload)+2)
+	for k, v := range current.Payload {
+		payload[k] = v
+	}
+	payload["leadId"] = current.LeadID
+	payload["eventDate"] = current.EventDate
+
+	result, err := s.triggersService.Run(ctx, &TriggerRequest{
+		BusinessID: current.BusinessID,
+		TriggerKey: current.TriggerKey,
+		Source:     "timer",
+		Payload:    payload,
+		RequestID:  fmt.Sprintf("%s_%d", current.ID, current.FireAt.Unix()),
+		Async:      true,
+	})
+	if err != nil && !errors.Is(err, ErrUnknownTrigger) {
+		// The queue may be full or shutting down, so the timer stays scheduled
+		logger.Warn("failed to queue timer run, will retry", "error", err)
+		release()
+		return
+	}
+	if err != nil {
+		logger.Error("timer trigger is not configured", "error", err)
+		current.Status = domain.TimerStatusFailed
+		current.Error = err.Error()
+	} else {
+		logger.Info("timer run queued", "jobId", result.JobID)
+		current.Status = domain.TimerStatusFired
+		current.JobID = result.JobID
+	}
+	if err := s.timersRepo.Save(ctx, current); err != nil {
+		logger.Error("failed to save timer", "error", err)
+	}
+}
+
+// Upcoming lists the next runs of the enabled schedules of a business (or of
+// every business when businessID is empty), soonest first
+func (s *Scheduler) Upcoming(ctx context.Context, businessID string, from time.Time, limit int) ([]ScheduledRun, error) {
+	var businesses []*domain.BusinessConfig
+	if businessID != "" {
+		business, err := s.businesses.GetByID(ctx, businessID)
+		if err != nil {
+			return nil, err
+		}
+		businesses = []*domain.BusinessConfig{business}
+	} else {
+		var err error
+		if businesses, err = s.businesses.GetAll(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	runs := []ScheduledRun{}
+	for _, business := range businesses {
+		for _, sc := range business.Pipelines.Schedules {
+			if sc.Disabled {
+				continue
+			}
+			schedule, loc, err := parseSchedule(business, sc)
+			if err != nil {
+				return nil, fmt.Errorf("business %s: %w", business.ID, err)
+			}
+			pipelineKey := sc.PipelineKey
+			if pipelineKey == "" {
+				pipelineKey = business.Pipelines.Triggers[sc.Trigger]
+			}
+
+			// No schedule can contribute more than limit runs
+			next := from
+			for i := 0; i < limit; i++ {
+				next = schedule.Next(next)
+				if next.IsZero() {
+					break
+				}
+				runs = append(runs, ScheduledRun{
+					BusinessID:  business.ID,
+					ScheduleKey: sc.Key,
+					Trigger:     sc.Trigger,
+					PipelineKey: pipelineKey,
+					Cron:        sc.Cron,
+					Timezone:    loc.String(),
+					RunAt:       next,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].RunAt.Before(runs[j].RunAt)
+	})
+	if len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+// parseSchedule parses a schedule's cron expression in the business timezone
+func parseSchedule(business *domain.BusinessConfig, sc domain.ScheduleConfig) (cron.Schedule, *time.Location, error) {
+	if sc.Key == "" {
+		return nil, nil, fmt.Errorf("schedule key is required")
+	}
+	if sc.Trigger == "" && sc.PipelineKey == "" {
+		return nil, nil, fmt.Errorf("schedule %s: trigger or pipelineKey is required", sc.Key)
+	}
+	if sc.Trigger != "" {
+		if _, ok := business.Pipelines.Triggers[sc.Trigger]; !ok {
+			return nil, nil, fmt.Errorf("schedule %s: trigger '%s' not found in business config", sc.Key, sc.Trigger)
+		}
+	}
+
+	loc := time.UTC
+	if business.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(business.Timezone); err != nil {
+			return nil, nil, fmt.Errorf("schedule %s: invalid timezone %q: %w", sc.Key, business.Timezone, err)
+		}
+	}
+
+	schedule, err := cron.ParseStandard(sc.Cron)
+	if err != nil {
+		return nil, nil, fmt.Errorf("schedule %s: invalid cron %q: %w", sc.Key, sc.Cron, err)
+	}
+	return inLocation{schedule: schedule, loc: loc}, loc, nil
+}
+
+// inLocation evaluates a cron schedule in a fixed timezone regardless of the
+// location of the time it is given
+type inLocation struct {
+	schedule cron.Schedule
+	loc      *time.Location
+}
+
+func (s inLocation) Next(t time.Time) time.Time {
+	return s.schedule.Next(t.In(s.loc))
+}
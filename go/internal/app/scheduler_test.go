@@ -0,0 +1,200 @@
+package app
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/infra/db"
+)
+
+const scheduledBusinessYAML = `id: stlpartyhelpers
+timezone: America/Chicago
+pipelines:
+  triggers:
+    send_renewal_offer: renewal_followup
+  schedules:
+    - key: morning_renewals
+      cron: "0 9 * * *"
+      trigger: send_renewal_offer
+      payload:
+        segment: lapsed
+    - key: paused
+      cron: "* * * * *"
+      trigger: send_renewal_offer
+      disabled: true
+    - key: weekly_report
+      cron: "30 8 * * MON"
+      pipelineKey: renewal_followup
+`
+
+const renewalPipelineYAML = `key: renewal_followup
+actions:
+  - name: send_renewal_offer
+`
+
+func newTestScheduler(t *testing.T) (*config.Config, *slog.Logger) {
+	t.Helper()
+	cfg := writeConfigFiles(t, map[string]string{
+		"businesses/stlpartyhelpers.yaml": scheduledBusinessYAML,
+		"pipelines/renewal_followup.yaml": renewalPipelineYAML,
+	})
+	return cfg, slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestScheduler_TickFiresOnceAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	cfg, logger := newTestScheduler(t)
+	offer := &flakyAction{name: "send_renewal_offer"}
+	runner := domain.NewPipelineRunner(map[string]domain.Action{offer.name: offer})
+	jobsRepo := db.NewMemoryJobsRepo()
+	locker := db.NewMemoryLocker()
+	pool := NewWorkerPool(1, 10)
+
+	// Two API instances sharing the lock store
+	var schedulers []*Scheduler
+	for i := 0; i < 2; i++ {
+		loader := config.NewBusinessLoader(cfg)
+		businesses := config.NewYAMLBusinessesRepo(loader)
+		triggers := NewTriggersService(businesses, config.NewYAMLPipelinesRepo(loader), runner, jobsRepo, pool)
+		schedulers = append(schedulers, NewScheduler(businesses, triggers, nil, locker, logger, time.Minute))
+	}
+
+	// 09:00 in Chicago on 2026-03-14 is 14:00 UTC (CDT)
+	ticks := []time.Time{
+		time.Date(2026, 3, 14, 13, 59, 30, 0, time.UTC),
+		time.Date(2026, 3, 14, 14, 0, 10, 0, time.UTC),
+		time.Date(2026, 3, 14, 14, 0, 40, 0, time.UTC),
+	}
+	for _, now := range ticks {
+		for _, s := range schedulers {
+			s.Tick(ctx, now)
+		}
+	}
+
+	if err := pool.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if offer.calls != 1 {
+		t.Fatalf("expected one scheduled run, got %d", offer.calls)
+	}
+
+	runAt := time.Date(2026, 3, 14, 14, 0, 0, 0, time.UTC)
+	job, err := jobsRepo.GetByID(ctx, "sched_stlpartyhelpers_morning_renewals_"+strconv.FormatInt(runAt.Unix(), 10))
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if job.Status != "completed" || job.Source != "schedule" || job.Input["segment"] != "lapsed" {
+		t.Errorf("unexpected scheduled job %+v", job)
+	}
+}
+
+func TestScheduler_ReleasesRunsThatCannotBeQueued(t *testing.T) {
+	ctx := context.Background()
+	cfg, logger := newTestScheduler(t)
+	offer := &flakyAction{name: "send_renewal_offer"}
+	runner := domain.NewPipelineRunner(map[string]domain.Action{offer.name: offer})
+	jobsRepo := db.NewMemoryJobsRepo()
+	locker := db.NewMemoryLocker()
+
+	// The first instance's worker pool is shutting down, so it claims the run
+	// but cannot queue it
+	closed := NewWorkerPool(1, 10)
+	if err := closed.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	pool := NewWorkerPool(1, 10)
+	var schedulers []*Scheduler
+	for _, p := range []*WorkerPool{closed, pool} {
+		loader := config.NewBusinessLoader(cfg)
+		businesses := config.NewYAMLBusinessesRepo(loader)
+		triggers := NewTriggersService(businesses, config.NewYAMLPipelinesRepo(loader), runner, jobsRepo, p)
+		schedulers = append(schedulers, NewScheduler(businesses, triggers, nil, locker, logger, time.Minute))
+	}
+
+	ticks := []time.Time{
+		time.Date(2026, 3, 14, 13, 59, 30, 0, time.UTC),
+		time.Date(2026, 3, 14, 14, 0, 10, 0, time.UTC),
+	}
+	for _, now := range ticks {
+		for _, s := range schedulers {
+			s.Tick(ctx, now)
+		}
+	}
+
+	if err := pool.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if offer.calls != 1 {
+		t.Fatalf("expected the other instance to fire the released run, got %d runs", offer.calls)
+	}
+}
+
+func TestScheduler_Upcoming(t *testing.T) {
+	ctx := context.Background()
+	cfg, logger := newTestScheduler(t)
+	loader := config.NewBusinessLoader(cfg)
+	scheduler := NewScheduler(config.NewYAMLBusinessesRepo(loader), nil, nil, db.NewMemoryLocker(), logger, 0)
+
+	// Friday 2026-03-13 12:00 in Chicago
+	from := time.Date(2026, 3, 13, 17, 0, 0, 0, time.UTC)
+	runs, err := scheduler.Upcoming(ctx, "stlpartyhelpers", from, 4)
+	if err != nil {
+		t.Fatalf("Upcoming failed: %v", err)
+	}
+
+	want := []struct {
+		key   string
+		runAt string
+	}{
+		{"morning_renewals", "2026-03-14T09:00:00-05:00"},
+		{"morning_renewals", "2026-03-15T09:00:00-05:00"},
+		{"weekly_report", "2026-03-16T08:30:00-05:00"},
+		{"morning_renewals", "2026-03-16T09:00:00-05:00"},
+	}
+	if len(runs) != len(want) {
+		t.Fatalf("expected %d runs, got %+v", len(want), runs)
+	}
+	for i, w := range want {
+		got := runs[i]
+		if got.ScheduleKey != w.key || got.RunAt.Format(time.RFC3339) != w.runAt {
+			t.Errorf("run %d: expected %s at %s, got %s at %s", i, w.key, w.runAt, got.ScheduleKey, got.RunAt.Format(time.RFC3339))
+		}
+		if got.PipelineKey != "renewal_followup" || got.Timezone != "America/Chicago" {
+			t.Errorf("run %d: unexpected pipeline or timezone %+v", i, got)
+		}
+	}
+}
+
+func TestParseSchedule_Errors(t *testing.T) {
+	business := &domain.BusinessConfig{
+		ID:       "stlpartyhelpers",
+		Timezone: "America/Chicago",
+		Pipelines: domain.BusinessPipelineConfig{
+			Triggers: map[string]string{"send_renewal_offer": "renewal_followup"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		schedule domain.ScheduleConfig
+	}{
+		{"missing key", domain.ScheduleConfig{Cron: "@daily", Trigger: "send_renewal_offer"}},
+		{"missing target", domain.ScheduleConfig{Key: "daily", Cron: "@daily"}},
+		{"unknown trigger", domain.ScheduleConfig{Key: "daily", Cron: "@daily", Trigger: "missing"}},
+		{"invalid cron", domain.ScheduleConfig{Key: "daily", Cron: "every day", Trigger: "send_renewal_offer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := parseSchedule(business, tt.schedule); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
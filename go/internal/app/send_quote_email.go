@@ -0,0 +1,245 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// Quote email templates, chosen with the template config or the business
+// emailTemplateSettings.defaultTemplate
+const (
+	quoteTemplateOriginal   = "original"
+	quoteTemplateAppleStyle = "apple_style"
+)
+
+// SendQuoteEmailConfig is the config of send_quote_email. The pricing keys
+// usually reference calculate_estimate's outputs and the deposit keys
+// create_deposit_invoice's.
+type SendQuoteEmailConfig struct {
+	Email                 string  `json:"email"`
+	ClientName            string  `json:"clientName"`
+	Occasion              string  `json:"occasion"`
+	GuestCount            int     `json:"guestCount"`
+	EventDate             string  `json:"eventDate"`
+	EventTime             string  `json:"eventTime"`
+	EventLocation         string  `json:"eventLocation"`
+	NumHelpers            int     `json:"numHelpers"`
+	DurationHours         float64 `json:"durationHours"`
+	TotalCost             float64 `json:"totalCost"`
+	BasePerHelper         float64 `json:"basePerHelper"`
+	ExtraPerHourPerHelper float64 `json:"extraPerHourPerHelper"`
+	RateLabel             string  `json:"rateLabel"`
+	IsHighDemand          bool    `json:"isHighDemand"`
+	DepositCents          int64   `json:"depositCents"`
+	DepositLink           string  `json:"depositLink"`
+	Subject               string  `json:"subject"`
+	Template              string  `json:"template"`
+}
+
+// SendQuoteEmailOutputs are the outputs of send_quote_email
+type SendQuoteEmailOutputs struct {
+	MessageID          string `json:"messageId"`
+	ConfirmationNumber string `json:"confirmationNumber"`
+	Subject            string `json:"subject"`
+}
+
+// quoteEmailFields are the config keys of send_quote_email that default to
+// the pipeline field of the same name
+var quoteEmailFields = []string{"email", "occasion", "guestCount", "eventDate", "eventTime", "eventLocation", "numHelpers", "durationHours"}
+
+// SendQuoteEmailAction emails the client their estimate and deposit link
+type SendQuoteEmailAction struct {
+	mailer ports.Mailer
+	now    func() time.Time
+}
+
+// NewSendQuoteEmailAction creates the send_quote_email action. With a nil
+// mailer the action is skipped.
+func NewSendQuoteEmailAction(mailer ports.Mailer) *SendQuoteEmailAction {
+	return &SendQuoteEmailAction{mailer: mailer, now: time.Now}
+}
+
+func (a *SendQuoteEmailAction) Name() string {
+	return "send_quote_email"
+}
+
+func (a *SendQuoteEmailAction) Schema() domain.ActionSchema {
+	return domain.ActionSchema{
+		Description: "Emails the client their estimate with the deposit link",
+		Config: map[string]domain.ConfigField{
+			"email":                 {Type: domain.ConfigTypeString, Description: "Recipient (default: the email field)"},
+			"clientName":            {Type: domain.ConfigTypeString, Description: "Name the email is addressed to"},
+			"occasion":              {Type: domain.ConfigTypeString, Description: "Occasion (default: the occasion field)"},
+			"guestCount":            {Type: domain.ConfigTypeInteger, Description: "Guests expected (default: the guestCount field)"},
+			"eventDate":             {Type: domain.ConfigTypeString, Description: "Event date (default: the eventDate field)"},
+			"eventTime":             {Type: domain.ConfigTypeString, Description: "Start time (default: the eventTime field)"},
+			"eventLocation":         {Type: domain.ConfigTypeString, Description: "Event address (default: the eventLocation field)"},
+			"numHelpers":            {Type: domain.ConfigTypeInteger, Description: "Helpers quoted (default: the numHelpers field)"},
+			"durationHours":         {Type: domain.ConfigTypeNumber, Description: "Event length in hours (default: the durationHours field)"},
+			"totalCost":             {Type: domain.ConfigTypeNumber, Required: true, Description: "Estimate total in dollars"},
+			"basePerHelper":         {Type: domain.ConfigTypeNumber, Description: "Base rate per helper"},
+			"extraPerHourPerHelper": {Type: domain.ConfigTypeNumber, Description: "Rate per extra hour per helper"},
+			"rateLabel":             {Type: domain.ConfigTypeString, Description: "Rate name, e.g. Base Rate or a holiday"},
+			"isHighDemand":          {Type: domain.ConfigTypeBoolean, Description: "Whether the date is a high-demand date"},
+			"depositCents":          {Type: domain.ConfigTypeInteger, Description: "Deposit amount"},
+			"depositLink":           {Type: domain.ConfigTypeString, Description: "Link to pay the deposit"},
+			"subject":               {Type: domain.ConfigTypeString, Description: "Subject, overriding the standard one"},
+			"template":              {Type: domain.ConfigTypeString, Description: "original or apple_style (default: the business default template)"},
+		},
+	}
+}
+
+func (a *SendQuoteEmailAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if a.mailer == nil {
+		return a.notConfigured()
+	}
+	req, outputs, err := a.email(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	result, err := a.mailer.SendEmail(ctx, req)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+	if !result.Success {
+		errMsg := "unknown error"
+		if result.Error != nil {
+			errMsg = *result.Error
+		}
+		return domain.FailedStep(a.Name(), fmt.Errorf("quote email not sent: %s", errMsg))
+	}
+
+	outputs.MessageID = result.MessageID
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": "quote email sent to " + req.To,
+		},
+		Outputs: stepOutputs(outputs),
+	}
+}
+
+// Plan renders the email Execute would send
+func (a *SendQuoteEmailAction) Plan(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	if a.mailer == nil {
+		return a.notConfigured()
+	}
+	req, outputs, err := a.email(ctx, pctx)
+	if err != nil {
+		return domain.FailedStep(a.Name(), err)
+	}
+
+	outputs.MessageID = dryRunPlaceholder
+	return domain.JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"message": "dry run, quote email not sent",
+		},
+		Outputs: stepOutputs(outputs),
+		SideEffects: []domain.SideEffect{{
+			Kind:    "email",
+			Summary: fmt.Sprintf("Email %s %q", req.To, req.Subject),
+			Details: map[string]any{
+				"to":       req.To,
+				"fromName": req.FromName,
+				"subject":  req.Subject,
+				"htmlBody": req.HTMLBody,
+			},
+		}},
+	}
+}
+
+// email reads the config and renders the quote email
+func (a *SendQuoteEmailAction) email(ctx context.Context, pctx *domain.PipelineContext) (*ports.SendEmailRequest, SendQuoteEmailOutputs, error) {
+	var config SendQuoteEmailConfig
+	if err := decodeActionConfig(ctx, pctx, &config, quoteEmailFields...); err != nil {
+		return nil, SendQuoteEmailOutputs{}, err
+	}
+	if config.Email == "" {
+		return nil, SendQuoteEmailOutputs{}, missingConfig("email")
+	}
+	if config.EventDate == "" {
+		return nil, SendQuoteEmailOutputs{}, missingConfig("eventDate")
+	}
+	eventDate, err := util.ParseDate(config.EventDate)
+	if err != nil {
+		return nil, SendQuoteEmailOutputs{}, domain.Permanent(err)
+	}
+
+	daysUntilEvent := max(int(eventDate.Sub(a.now()).Hours()/24), 0)
+	_, expiration := util.CalculateExpirationDate(daysUntilEvent)
+	rateLabel := config.RateLabel
+	if rateLabel == "" {
+		rateLabel = "Base Rate"
+	}
+	formattedDate := eventDate.Format("Mon, Jan 2, 2006")
+	data := util.QuoteEmailData{
+		ClientName:         config.ClientName,
+		EventDate:          formattedDate,
+		EventTime:          config.EventTime,
+		EventLocation:      config.EventLocation,
+		Occasion:           config.Occasion,
+		GuestCount:         config.GuestCount,
+		Helpers:            config.NumHelpers,
+		Hours:              config.DurationHours,
+		BaseRate:           config.BasePerHelper,
+		HourlyRate:         config.ExtraPerHourPerHelper,
+		TotalCost:          config.TotalCost,
+		DepositAmount:      util.CentsToDollars(config.DepositCents),
+		RateLabel:          rateLabel,
+		ExpirationDate:     expiration,
+		DepositLink:        config.DepositLink,
+		ConfirmationNumber: util.GenerateConfirmationNumber(config.Email, config.Occasion, eventDate),
+		IsHighDemand:       config.IsHighDemand,
+		UrgencyLevel:       util.CalculateUrgencyLevel(daysUntilEvent),
+		DaysUntilEvent:     daysUntilEvent,
+	}
+
+	template := config.Template
+	fromName := ""
+	if pctx.Business != nil {
+		if template == "" {
+			template = pctx.Business.Templates.EmailTemplateSettings.DefaultTemplate
+		}
+		fromName = pctx.Business.Gmail.SenderName
+	}
+	var htmlBody string
+	switch template {
+	case "", quoteTemplateOriginal:
+		htmlBody = util.GenerateQuoteEmailHTML(data, pctx.Business)
+	case quoteTemplateAppleStyle:
+		htmlBody = util.GenerateQuoteEmailHTMLAppleStyle(data, pctx.Business)
+	default:
+		return nil, SendQuoteEmailOutputs{}, domain.Permanent(fmt.Errorf("unknown quote email template %q", template))
+	}
+
+	subject := config.Subject
+	if subject == "" {
+		subject = fmt.Sprintf("Party Helpers for %s - %s - Estimate & Details for %s", config.Occasion, formattedDate, config.ClientName)
+	}
+
+	req := &ports.SendEmailRequest{
+		To:       config.Email,
+		Subject:  subject,
+		HTMLBody: htmlBody,
+		FromName: fromName,
+	}
+	return req, SendQuoteEmailOutputs{ConfirmationNumber: data.ConfirmationNumber, Subject: subject}, nil
+}
+
+// notConfigured is the step when no mailer is configured
+func (a *SendQuoteEmailAction) notConfigured() domain.JobStep {
+	return domain.JobStep{
+		Name:    a.Name(),
+		Status:  "skipped",
+		Details: map[string]any{"message": "email is not configured"},
+	}
+}
@@ -0,0 +1,215 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	stripeService "github.com/bizops360/go-api/internal/services/stripe"
+)
+
+// fakeMailer is a ports.Mailer that records the emails it sends
+type fakeMailer struct {
+	sent []*ports.SendEmailRequest
+	fail string
+}
+
+func (m *fakeMailer) SendEmail(ctx context.Context, req *ports.SendEmailRequest) (*ports.SendEmailResult, error) {
+	if m.fail != "" {
+		return &ports.SendEmailResult{Error: &m.fail}, nil
+	}
+	m.sent = append(m.sent, req)
+	return &ports.SendEmailResult{MessageID: "msg-1", Success: true}, nil
+}
+
+func (m *fakeMailer) SendEmailDraft(ctx context.Context, req *ports.SendEmailRequest) (*ports.SendEmailResult, error) {
+	return m.SendEmail(ctx, req)
+}
+
+func newTestQuoteEmailAction(mailer ports.Mailer) *SendQuoteEmailAction {
+	action := NewSendQuoteEmailAction(mailer)
+	action.now = func() time.Time { return time.Date(2027, time.May, 1, 12, 0, 0, 0, time.UTC) }
+	return action
+}
+
+var testQuoteFields = map[string]any{
+	"email":      "jane@example.com",
+	"firstName":  "Jane",
+	"eventDate":  "2027-06-12",
+	"eventTime":  "6:00 PM",
+	"occasion":   "Birthday",
+	"numHelpers": 3,
+}
+
+func TestSendQuoteEmailAction(t *testing.T) {
+	config := map[string]any{
+		"clientName":   "Jane",
+		"totalCost":    615.0,
+		"depositCents": float64(13500),
+		"depositLink":  "https://invoice.stripe.com/i/deposit",
+	}
+	business := &domain.BusinessConfig{ID: "stlpartyhelpers", Gmail: domain.GmailConfig{SenderName: "STL Party Helpers"}}
+
+	tests := []struct {
+		name       string
+		mailer     *fakeMailer
+		config     map[string]any
+		dryRun     bool
+		wantStatus string
+		wantSent   int
+	}{
+		{name: "sends the quote", mailer: &fakeMailer{}, config: config, wantStatus: "ok", wantSent: 1},
+		{name: "dry run", mailer: &fakeMailer{}, config: config, dryRun: true, wantStatus: "ok"},
+		{name: "send failure", mailer: &fakeMailer{fail: "quota exceeded"}, config: config, wantStatus: "failed"},
+		{name: "unknown template", mailer: &fakeMailer{}, config: map[string]any{"totalCost": 615.0, "template": "fancy"}, wantStatus: "failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := newTestQuoteEmailAction(tt.mailer)
+			ctx := domain.WithActionConfig(context.Background(), tt.config)
+			pctx := &domain.PipelineContext{Fields: testQuoteFields, Business: business}
+
+			var step domain.JobStep
+			if tt.dryRun {
+				step = action.Plan(ctx, pctx)
+			} else {
+				step = action.Execute(ctx, pctx)
+			}
+
+			if step.Status != tt.wantStatus {
+				t.Fatalf("expected status %s, got %+v", tt.wantStatus, step)
+			}
+			if len(tt.mailer.sent) != tt.wantSent {
+				t.Fatalf("expected %d email(s) sent, got %d", tt.wantSent, len(tt.mailer.sent))
+			}
+			if step.Status != "ok" {
+				return
+			}
+
+			subject := "Party Helpers for Birthday - Sat, Jun 12, 2027 - Estimate & Details for Jane"
+			if step.Outputs["subject"] != subject || step.Outputs["confirmationNumber"] == "" {
+				t.Errorf("unexpected outputs: %+v", step.Outputs)
+			}
+			if tt.dryRun {
+				if len(step.SideEffects) != 1 || step.SideEffects[0].Details["subject"] != subject {
+					t.Errorf("expected the email as a side effect, got %+v", step.SideEffects)
+				}
+				return
+			}
+			sent := tt.mailer.sent[0]
+			if sent.To != "jane@example.com" || sent.FromName != "STL Party Helpers" || !strings.Contains(sent.HTMLBody, "https://invoice.stripe.com/i/deposit") {
+				t.Errorf("unexpected email: to %s from %s", sent.To, sent.FromName)
+			}
+		})
+	}
+
+	step := NewSendQuoteEmailAction(nil).Execute(domain.WithActionConfig(context.Background(), config), &domain.PipelineContext{Fields: testQuoteFields})
+	if step.Status != "skipped" {
+		t.Errorf("expected the action to be skipped without a mailer, got %+v", step)
+	}
+}
+
+// TestQuoteAndDepositDryRun plans the quote_and_deposit flow from the
+// example config: each action's stand-in outputs feed the next one's config
+func TestQuoteAndDepositDryRun(t *testing.T) {
+	payments := &fakePayments{}
+	mailer := &fakeMailer{}
+	invoices := stripeService.NewInvoiceService(payments)
+	runner := domain.NewPipelineRunner(map[string]domain.Action{
+		"calculate_estimate":     &CalculateEstimateAction{},
+		"create_deposit_invoice": NewCreateDepositInvoiceAction(invoices),
+		"send_quote_email":       newTestQuoteEmailAction(mailer),
+	})
+	pipeline := &domain.PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []domain.ActionDefinition{
+			{Name: "calculate_estimate", Critical: true},
+			{Name: "create_deposit_invoice", Critical: true, Config: map[string]any{
+				"customerName":  "{{ fields.firstName }}",
+				"estimateCents": "{{ steps.calculate_estimate.totalCents }}",
+			}},
+			{Name: "send_quote_email", Critical: true, Config: map[string]any{
+				"clientName":   "{{ fields.firstName }}",
+				"totalCost":    "{{ steps.calculate_estimate.totalCost }}",
+				"depositCents": "{{ steps.create_deposit_invoice.amountCents }}",
+				"depositLink":  "{{ steps.create_deposit_invoice.hostedInvoiceUrl }}",
+			}},
+		},
+	}
+
+	fields := map[string]any{"durationHours": 5.0}
+	for k, v := range testQuoteFields {
+		fields[k] = v
+	}
+	result, job := runner.Run(context.Background(), pipeline, &domain.PipelineContext{DryRun: true, Fields: fields})
+	if !result.Success {
+		t.Fatalf("expected the dry run to succeed, got %+v", job.Steps)
+	}
+	if len(payments.invoices) != 0 || len(mailer.sent) != 0 {
+		t.Fatalf("expected no invoice or email in a dry run, got %d invoice(s), %d email(s)", len(payments.invoices), len(mailer.sent))
+	}
+
+	var kinds []string
+	for _, step := range job.Steps {
+		for _, effect := range step.SideEffects {
+			kinds = append(kinds, effect.Kind)
+		}
+	}
+	if strings.Join(kinds, ",") != "invoice,email" {
+		t.Errorf("expected an invoice and an email to be planned, got %v", kinds)
+	}
+	total := job.Steps[0].Outputs["totalCents"].(float64)
+	if got := job.Steps[1].Outputs["amountCents"]; got != float64(int64(total)/5) {
+		t.Errorf("expected the deposit to be calculated from the estimate, got %v", got)
+	}
+}
+
+// TestQuoteAndDepositCompensation runs quote_and_deposit against fake
+// integrations: when the critical quote email fails after the deposit invoice
+// was created, the invoice is voided
+func TestQuoteAndDepositCompensation(t *testing.T) {
+	payments := &fakePayments{}
+	invoices := stripeService.NewInvoiceService(payments)
+	runner := domain.NewPipelineRunner(map[string]domain.Action{
+		"calculate_estimate":     &CalculateEstimateAction{},
+		"create_deposit_invoice": NewCreateDepositInvoiceAction(invoices),
+		"send_quote_email":       newTestQuoteEmailAction(&fakeMailer{fail: "quota exceeded"}),
+		"void_invoice":           NewVoidInvoiceAction(invoices),
+	})
+	pipeline := &domain.PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []domain.ActionDefinition{
+			{Name: "calculate_estimate", Critical: true},
+			{Name: "create_deposit_invoice", Critical: true, Config: map[string]any{
+				"estimateCents": "{{ steps.calculate_estimate.totalCents }}",
+			}, Compensate: &domain.ActionDefinition{Name: "void_invoice", Config: map[string]any{
+				"invoiceId": "{{ steps.create_deposit_invoice.invoiceId }}",
+			}}},
+			{Name: "send_quote_email", Critical: true, Config: map[string]any{
+				"totalCost":    "{{ steps.calculate_estimate.totalCost }}",
+				"depositCents": "{{ steps.create_deposit_invoice.amountCents }}",
+				"depositLink":  "{{ steps.create_deposit_invoice.hostedInvoiceUrl }}",
+			}},
+		},
+	}
+
+	fields := map[string]any{"durationHours": 5.0}
+	for k, v := range testQuoteFields {
+		fields[k] = v
+	}
+	result, job := runner.Run(context.Background(), pipeline, &domain.PipelineContext{Fields: fields})
+	if result.Success || job.Status != "failed" {
+		t.Fatalf("expected the failed email to fail the job, got success=%v status=%s", result.Success, job.Status)
+	}
+	if len(payments.invoices) != 1 || len(payments.voided) != 1 || payments.voided[0] != "in_1" {
+		t.Fatalf("expected the deposit invoice to be created and voided, got %d invoice(s), voided %v", len(payments.invoices), payments.voided)
+	}
+	last := job.Steps[len(job.Steps)-1]
+	if last.Name != "void_invoice" || last.Status != "ok" || last.Details["compensates"] != "create_deposit_invoice" {
+		t.Errorf("expected the void to be recorded as a compensation, got %+v", last)
+	}
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+const (
+	// DefaultSlowStepThreshold is how long a step without a timeout may take
+	// before it is reported as slow
+	DefaultSlowStepThreshold = 5 * time.Second
+
+	// slowStepBudgetPercent is the share of its timeout a step may use before
+	// it is reported as slow
+	slowStepBudgetPercent = 50
+)
+
+// SlowStepLogger is a domain.StepListener that warns about slow pipeline
+// steps. A step with a timeout is slow once it uses half of it; a step without
+// one is slow once it takes longer than the threshold. Retried steps are
+// judged by their slowest attempt, since the timeout applies per attempt and
+// the step duration also counts retries and backoff.
+type SlowStepLogger struct {
+	logger    ports.Logger
+	threshold time.Duration
+}
+
+// NewSlowStepLogger creates a slow step logger; a threshold of zero uses
+// DefaultSlowStepThreshold
+func NewSlowStepLogger(logger ports.Logger, threshold time.Duration) *SlowStepLogger {
+	if threshold <= 0 {
+		threshold = DefaultSlowStepThreshold
+	}
+	return &SlowStepLogger{logger: logger, threshold: threshold}
+}
+
+// StepFinished logs a warning when step was slow
+func (l *SlowStepLogger) StepFinished(ctx context.Context, pctx *domain.PipelineContext, step domain.JobStep) {
+	fields := []ports.Field{
+		ports.NewField("businessId", pctx.BusinessID),
+		ports.NewField("pipelineKey", pctx.PipelineKey),
+		ports.NewField("jobId", pctx.RequestID),
+		ports.NewField("step", step.Name),
+		ports.NewField("status", step.Status),
+		ports.NewField("durationMs", step.DurationMs),
+	}
+
+	elapsedMs := step.DurationMs
+	if attemptMs, retried := slowestAttemptMs(step); retried {
+		elapsedMs = attemptMs
+		fields = append(fields, ports.NewField("slowestAttemptMs", attemptMs))
+	}
+
+	if step.TimeoutMs > 0 {
+		percent := elapsedMs * 100 / step.TimeoutMs
+		if percent < slowStepBudgetPercent {
+			return
+		}
+		fields = append(fields,
+			ports.NewField("timeoutMs", step.TimeoutMs),
+			ports.NewField("budgetPercent", percent),
+		)
+	} else if elapsedMs < l.threshold.Milliseconds() {
+		return
+	}
+
+	l.logger.Warn("slow pipeline step", fields...)
+}
+
+// slowestAttemptMs returns the duration of the slowest attempt recorded in
+// details.attempts. It reports false when the step has no attempt log.
+func slowestAttemptMs(step domain.JobStep) (int64, bool) {
+	attempts, ok := step.Details["attempts"].([]map[string]any)
+	if !ok || len(attempts) == 0 {
+		return 0, false
+	}
+	var slowest int64
+	for _, attempt := range attempts {
+		if ms, ok := attempt["durationMs"].(int64); ok && ms > slowest {
+			slowest = ms
+		}
+	}
+	return slowest, true
+}
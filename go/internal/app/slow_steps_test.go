@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// recordingLogger is a ports.Logger that keeps its warnings
+type recordingLogger struct {
+	mu    sync.Mutex
+	warns []map[string]any
+}
+
+func (l *recordingLogger) Debug(msg string, fields ...ports.Field) {}
+func (l *recordingLogger) Info(msg string, fields ...ports.Field)  {}
+func (l *recordingLogger) Error(msg string, fields ...ports.Field) {}
+
+func (l *recordingLogger) Warn(msg string, fields ...ports.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := map[string]any{"msg": msg}
+	for _, f := range fields {
+		entry[f.Key] = f.Value
+	}
+	l.warns = append(l.warns, entry)
+}
+
+func (l *recordingLogger) With(fields ...ports.Field) ports.Logger {
+	return l
+}
+
+func TestSlowStepLogger(t *testing.T) {
+	tests := []struct {
+		name        string
+		step        domain.JobStep
+		wantWarn    bool
+		wantPercent int64
+	}{
+		{
+			name:        "most of the timeout used",
+			step:        domain.JobStep{Name: "geocode_location", Status: "ok", DurationMs: 9000, TimeoutMs: 15000},
+			wantWarn:    true,
+			wantPercent: 60,
+		},
+		{
+			name: "well within the timeout",
+			step: domain.JobStep{Name: "geocode_location", Status: "ok", DurationMs: 7000, TimeoutMs: 15000},
+		},
+		{
+			name:     "over the threshold without a timeout",
+			step:     domain.JobStep{Name: "send_quote_email", Status: "failed", DurationMs: 6000},
+			wantWarn: true,
+		},
+		{
+			name: "retries within the per-attempt timeout",
+			step: domain.JobStep{Name: "geocode_location", Status: "ok", DurationMs: 20000, TimeoutMs: 15000, Details: map[string]any{
+				"attempts": []map[string]any{
+					{"attempt": 1, "status": "failed", "durationMs": int64(6000)},
+					{"attempt": 2, "status": "ok", "durationMs": int64(5000)},
+				},
+			}},
+		},
+		{
+			name: "one slow attempt",
+			step: domain.JobStep{Name: "geocode_location", Status: "ok", DurationMs: 16000, TimeoutMs: 15000, Details: map[string]any{
+				"attempts": []map[string]any{
+					{"attempt": 1, "status": "failed", "durationMs": int64(12000)},
+					{"attempt": 2, "status": "ok", "durationMs": int64(1000)},
+				},
+			}},
+			wantWarn:    true,
+			wantPercent: 80,
+		},
+		{
+			name: "under the threshold without a timeout",
+			step: domain.JobStep{Name: "send_quote_email", Status: "ok", DurationMs: 4999},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &recordingLogger{}
+			pctx := &domain.PipelineContext{BusinessID: "biz", PipelineKey: "quote_and_deposit", RequestID: "job-1"}
+			NewSlowStepLogger(logger, 5*time.Second).StepFinished(context.Background(), pctx, tt.step)
+
+			if !tt.wantWarn {
+				if len(logger.warns) != 0 {
+					t.Fatalf("expected no warning, got %v", logger.warns)
+				}
+				return
+			}
+			if len(logger.warns) != 1 {
+				t.Fatalf("expected one warning, got %v", logger.warns)
+			}
+			warn := logger.warns[0]
+			if warn["step"] != tt.step.Name || warn["jobId"] != "job-1" || warn["durationMs"] != tt.step.DurationMs {
+				t.Errorf("unexpected warning fields: %v", warn)
+			}
+			if tt.wantPercent != 0 && warn["budgetPercent"] != tt.wantPercent {
+				t.Errorf("expected budgetPercent %d, got %v", tt.wantPercent, warn["budgetPercent"])
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+var (
+	// ErrTimerNotScheduled is returned when cancelling a timer that has already
+	// fired or been cancelled
+	ErrTimerNotScheduled = errors.New("timer is not scheduled")
+
+	// ErrInvalidTimer is returned when a timer request is missing or has
+	// malformed fields
+	ErrInvalidTimer = errors.New("invalid timer")
+)
+
+// TimersService schedules, reschedules and cancels event-relative timers. The
+// Scheduler fires them once they are due.
+type TimersService struct {
+	businesses ports.BusinessesRepo
+	timersRepo ports.TimersRepo
+}
+
+// NewTimersService creates a new timers service
+func NewTimersService(businesses ports.BusinessesRepo, timersRepo ports.TimersRepo) *TimersService {
+	return &TimersService{
+		businesses: businesses,
+		timersRepo: timersRepo,
+	}
+}
+
+// Schedule creates or replaces a lead's timer. A timer that already fired or
+// was cancelled is only replaced when the event date changed, so a repeated
+// request does not send the same reminder twice. Scheduling with a new event
+// date also moves the lead's other pending timers to that date.
+func (s *TimersService) Schedule(ctx context.Context, req *ScheduleTimerRequest) (*domain.Timer, error) {
+	business, err := s.businesses.GetByID(ctx, req.BusinessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load business: %w", err)
+	}
+	timer, err := newTimer(business, req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.timersRepo.GetByID(ctx, timer.ID)
+	if err != nil && !errors.Is(err, ports.ErrTimerNotFound) {
+		return nil, err
+	}
+	settled := existing != nil &&
+		(existing.Status == domain.TimerStatusFired || existing.Status == domain.TimerStatusCancelled)
+	if settled && existing.EventDate == timer.EventDate {
+		timer = existing
+	} else {
+		if existing != nil {
+			timer.CreatedAt = existing.CreatedAt
+		}
+		if err := s.timersRepo.Save(ctx, timer); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.reschedule(ctx, business, req.LeadID, req.EventDate); err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+// newTimer validates a schedule request and builds the timer it describes
+func newTimer(business *domain.BusinessConfig, req *ScheduleTimerRequest) (*domain.Timer, error) {
+	if req.LeadID == "" {
+		return nil, fmt.Errorf("%w: leadId is required", ErrInvalidTimer)
+	}
+	if _, ok := business.Pipelines.Triggers[req.TriggerKey]; !ok {
+		return nil, fmt.Errorf("%w: trigger key '%s' not found in business config", ErrInvalidTimer, req.TriggerKey)
+	}
+
+	key := req.Key
+	if key == "" {
+		key = req.TriggerKey
+	}
+	timer := &domain.Timer{
+		ID:         domain.TimerID(business.ID, req.LeadID, key),
+		BusinessID: business.ID,
+		LeadID:     req.LeadID,
+		Key:        key,
+		TriggerKey: req.TriggerKey,
+		EventDate:  req.EventDate,
+		OffsetDays: req.OffsetDays,
+		At:         req.At,
+		Payload:    req.Payload,
+		Status:     domain.TimerStatusScheduled,
+	}
+	var err error
+	if timer.FireAt, err = timerFireAt(business, timer); err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+// ScheduleEventTimers schedules every event timer declared in the business
+// config for a lead
+func (s *TimersService) ScheduleEventTimers(ctx context.Context, businessID, leadID, eventDate string, payload map[string]any) ([]*domain.Timer, error) {
+	business, err := s.businesses.GetByID(ctx, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load business: %w", err)
+	}
+
+	timers := []*domain.Timer{}
+	for _, req := range eventTimerRequests(business, leadID, eventDate, payload) {
+		timer, err := s.Schedule(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("event timer %s: %w", req.Key, err)
+		}
+		timers = append(timers, timer)
+	}
+	return timers, nil
+}
+
+// PlanEventTimers returns the timers ScheduleEventTimers would schedule for a
+// lead, without saving them
+func (s *TimersService) PlanEventTimers(ctx context.Context, businessID, leadID, eventDate string, payload map[string]any) ([]*domain.Timer, error) {
+	business, err := s.businesses.GetByID(ctx, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load business: %w", err)
+	}
+
+	timers := []*domain.Timer{}
+	for _, req := range eventTimerRequests(business, leadID, eventDate, payload) {
+		timer, err := newTimer(business, req)
+		if err != nil {
+			return nil, fmt.Errorf("event timer %s: %w", req.Key, err)
+		}
+		timers = append(timers, timer)
+	}
+	return timers, nil
+}
+
+// eventTimerRequests builds a schedule request for each event timer declared
+// in the business config
+func eventTimerRequests(business *domain.BusinessConfig, leadID, eventDate string, payload map[string]any) []*ScheduleTimerRequest {
+	reqs := make([]*ScheduleTimerRequest, 0, len(business.Pipelines.EventTimers))
+	for _, def := range business.Pipelines.EventTimers {
+		merged := make(map[string]any, len(payload)+len(def.Payload))
+		for k, v := range payload {
+			merged[k] = v
+		}
+		for k, v := range def.Payload {
+			merged[k] = v
+		}
+
+		reqs = append(reqs, &ScheduleTimerRequest{
+			BusinessID: business.ID,
+			LeadID:     leadID,
+			Key:        def.Key,
+			TriggerKey: def.Trigger,
+			EventDate:  eventDate,
+			OffsetDays: def.OffsetDays,
+			At:         def.At,
+			Payload:    merged,
+		})
+	}
+	return reqs
+}
+
+// Reschedule moves a lead's pending timers to a new event date and returns the
+// timers that moved. Timers that already fired or were cancelled are left alone.
+func (s *TimersService) Reschedule(ctx context.Context, businessID, leadID, eventDate string) ([]*domain.Timer, error) {
+	if _, err := time.Parse(domain.EventDateFormat, eventDate); err != nil {
+		return nil, fmt.Errorf("%w: invalid event date %q: expected YYYY-MM-DD", ErrInvalidTimer, eventDate)
+	}
+	business, err := s.businesses.GetByID(ctx, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load business: %w", err)
+	}
+	return s.reschedule(ctx, business, leadID, eventDate)
+}
+
+func (s *TimersService) reschedule(ctx context.Context, business *domain.BusinessConfig, leadID, eventDate string) ([]*domain.Timer, error) {
+	timers, err := s.timersRepo.ListByLead(ctx, business.ID, leadID)
+	if err != nil {
+		return nil, err
+	}
+
+	moved := []*domain.Timer{}
+	for _, timer := range timers {
+		if timer.Status != domain.TimerStatusScheduled || timer.EventDate == eventDate {
+			continue
+		}
+		timer.EventDate = eventDate
+		if timer.FireAt, err = timerFireAt(business, timer); err != nil {
+			return nil, err
+		}
+		if err := s.timersRepo.Save(ctx, timer); err != nil {
+			return nil, err
+		}
+		moved = append(moved, timer)
+	}
+	return moved, nil
+}
+
+// Cancel cancels a pending timer
+func (s *TimersService) Cancel(ctx context.Context, id string) (*domain.Timer, error) {
+	timer, err := s.timersRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if timer.Status != domain.TimerStatusScheduled {
+		return nil, fmt.Errorf("%w: timer %s is %s", ErrTimerNotScheduled, timer.ID, timer.Status)
+	}
+
+	timer.Status = domain.TimerStatusCancelled
+	if err := s.timersRepo.Save(ctx, timer); err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+// List returns every timer of a lead, soonest first
+func (s *TimersService) List(ctx context.Context, businessID, leadID string) ([]*domain.Timer, error) {
+	return s.timersRepo.ListByLead(ctx, businessID, leadID)
+}
+
+// timerFireAt computes when a timer fires in the business timezone
+func timerFireAt(business *domain.BusinessConfig, timer *domain.Timer) (time.Time, error) {
+	loc := time.UTC
+	if business.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(business.Timezone); err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone %q: %w", business.Timezone, err)
+		}
+	}
+	fireAt, err := domain.TimerFireAt(timer.EventDate, timer.OffsetDays, timer.At, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidTimer, err)
+	}
+	return fireAt, nil
+}
+
+// ScheduleTimerRequest represents a request to schedule a trigger relative to
+// a lead's event date
+type ScheduleTimerRequest struct {
+	BusinessID string
+	LeadID     string
+	// Key identifies the timer for the lead; defaults to TriggerKey
+	Key        string
+	TriggerKey string
+	EventDate  string
+	OffsetDays int
+	At         string
+	Payload    map[string]any
+}
@@ -0,0 +1,320 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/infra/db"
+)
+
+const timersBusinessYAML = `id: stlpartyhelpers
+timezone: America/Chicago
+pipelines:
+  triggers:
+    resend_deposit_link: deposit_only
+    send_review_request: deposit_only
+  eventTimers:
+    - key: deposit_reminder
+      trigger: resend_deposit_link
+      offsetDays: -3
+      at: "10:00"
+    - key: review_request
+      trigger: send_review_request
+      offsetDays: 2
+      at: "11:00"
+      payload:
+        template: review
+`
+
+const depositOnlyPipelineYAML = `key: deposit_only
+actions:
+  - name: resend_deposit_link
+`
+
+func newTestTimersConfig(t *testing.T) *config.Config {
+	t.Helper()
+	return writeConfigFiles(t, map[string]string{
+		"businesses/stlpartyhelpers.yaml": timersBusinessYAML,
+		"pipelines/deposit_only.yaml":     depositOnlyPipelineYAML,
+	})
+}
+
+func TestTimersService_ScheduleAndReschedule(t *testing.T) {
+	ctx := context.Background()
+	loader := config.NewBusinessLoader(newTestTimersConfig(t))
+	service := NewTimersService(config.NewYAMLBusinessesRepo(loader), db.NewMemoryTimersRepo())
+
+	timers, err := service.ScheduleEventTimers(ctx, "stlpartyhelpers", "jane@example.com", "2026-03-14", map[string]any{"email": "jane@example.com"})
+	if err != nil {
+		t.Fatalf("ScheduleEventTimers failed: %v", err)
+	}
+	if len(timers) != 2 {
+		t.Fatalf("expected 2 timers, got %d", len(timers))
+	}
+	if got := timers[0].FireAt.Format(time.RFC3339); got != "2026-03-11T10:00:00-05:00" {
+		t.Errorf("expected the deposit reminder 3 days before at 10:00, got %s", got)
+	}
+	if timers[1].Payload["template"] != "review" || timers[1].Payload["email"] != "jane@example.com" {
+		t.Errorf("expected merged payload, got %v", timers[1].Payload)
+	}
+
+	// A manually scheduled timer with a new event date moves the lead's other timers
+	if _, err := service.Schedule(ctx, &ScheduleTimerRequest{
+		BusinessID: "stlpartyhelpers",
+		LeadID:     "jane@example.com",
+		Key:        "final_invoice",
+		TriggerKey: "resend_deposit_link",
+		EventDate:  "2026-04-04",
+		OffsetDays: 1,
+		At:         "09:00",
+	}); err != nil {
+		t.Fatalf("Schedule failed: %v", err)
+	}
+
+	listed, err := service.List(ctx, "stlpartyhelpers", "jane@example.com")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	want := map[string]string{
+		"deposit_reminder": "2026-04-01T10:00:00-05:00",
+		"final_invoice":    "2026-04-05T09:00:00-05:00",
+		"review_request":   "2026-04-06T11:00:00-05:00",
+	}
+	if len(listed) != len(want) {
+		t.Fatalf("expected %d timers, got %d", len(want), len(listed))
+	}
+	chicago, _ := time.LoadLocation("America/Chicago")
+	for _, timer := range listed {
+		if got := timer.FireAt.In(chicago).Format(time.RFC3339); got != want[timer.Key] {
+			t.Errorf("%s: expected %s, got %s", timer.Key, want[timer.Key], got)
+		}
+	}
+
+	// Cancelled timers stay cancelled
+	cancelled, err := service.Cancel(ctx, listed[0].ID)
+	if err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if cancelled.Status != domain.TimerStatusCancelled {
+		t.Errorf("expected cancelled, got %s", cancelled.Status)
+	}
+	if _, err := service.Cancel(ctx, listed[0].ID); !errors.Is(err, ErrTimerNotScheduled) {
+		t.Errorf("expected ErrTimerNotScheduled, got %v", err)
+	}
+	moved, err := service.Reschedule(ctx, "stlpartyhelpers", "jane@example.com", "2026-05-02")
+	if err != nil {
+		t.Fatalf("Reschedule failed: %v", err)
+	}
+	if len(moved) != 2 {
+		t.Errorf("expected only the 2 pending timers to move, got %d", len(moved))
+	}
+
+	if _, err := service.Schedule(ctx, &ScheduleTimerRequest{BusinessID: "stlpartyhelpers", LeadID: "jane@example.com", TriggerKey: "missing", EventDate: "2026-03-14"}); !errors.Is(err, ErrInvalidTimer) {
+		t.Errorf("expected ErrInvalidTimer for an unknown trigger, got %v", err)
+	}
+}
+
+func TestTimersService_ScheduleKeepsSettledTimers(t *testing.T) {
+	ctx := context.Background()
+	loader := config.NewBusinessLoader(newTestTimersConfig(t))
+	timersRepo := db.NewMemoryTimersRepo()
+	service := NewTimersService(config.NewYAMLBusinessesRepo(loader), timersRepo)
+
+	timers, err := service.ScheduleEventTimers(ctx, "stlpartyhelpers", "jane@example.com", "2026-03-14", nil)
+	if err != nil {
+		t.Fatalf("ScheduleEventTimers failed: %v", err)
+	}
+	fired := timers[0]
+	fired.Status = domain.TimerStatusFired
+	fired.JobID = "job-1"
+	if err := timersRepo.Save(ctx, fired); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := service.Cancel(ctx, timers[1].ID); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+
+	// The same lead submitted again with the same event date
+	again, err := service.ScheduleEventTimers(ctx, "stlpartyhelpers", "jane@example.com", "2026-03-14", nil)
+	if err != nil {
+		t.Fatalf("ScheduleEventTimers failed: %v", err)
+	}
+	if again[0].Status != domain.TimerStatusFired || again[0].JobID != "job-1" || again[1].Status != domain.TimerStatusCancelled {
+		t.Fatalf("expected settled timers to be kept, got %s and %s", again[0].Status, again[1].Status)
+	}
+
+	// A new event date schedules them again
+	moved, err := service.ScheduleEventTimers(ctx, "stlpartyhelpers", "jane@example.com", "2026-04-04", nil)
+	if err != nil {
+		t.Fatalf("ScheduleEventTimers failed: %v", err)
+	}
+	for _, timer := range moved {
+		if timer.Status != domain.TimerStatusScheduled || timer.EventDate != "2026-04-04" {
+			t.Errorf("%s: expected to be scheduled for the new date, got %s on %s", timer.Key, timer.Status, timer.EventDate)
+		}
+	}
+}
+
+func TestScheduleEventTimersAction_InvalidEventDate(t *testing.T) {
+	loader := config.NewBusinessLoader(newTestTimersConfig(t))
+	action := NewScheduleEventTimersAction(NewTimersService(config.NewYAMLBusinessesRepo(loader), db.NewMemoryTimersRepo()))
+	pctx := &domain.PipelineContext{
+		BusinessID: "stlpartyhelpers",
+		Fields:     map[string]any{"email": "jane@example.com", "eventDate": "03/14/2026"},
+	}
+
+	step := action.Execute(context.Background(), pctx)
+
+	if step.Status != "failed" || !step.Permanent {
+		t.Errorf("expected a permanent failure for a malformed event date, got %+v", step)
+	}
+}
+
+func TestScheduleEventTimersAction_Plan(t *testing.T) {
+	loader := config.NewBusinessLoader(newTestTimersConfig(t))
+	timersRepo := db.NewMemoryTimersRepo()
+	action := NewScheduleEventTimersAction(NewTimersService(config.NewYAMLBusinessesRepo(loader), timersRepo))
+	pctx := &domain.PipelineContext{
+		BusinessID: "stlpartyhelpers",
+		Fields:     map[string]any{"email": "jane@example.com", "eventDate": "2026-03-14"},
+	}
+
+	step := action.Plan(context.Background(), pctx)
+
+	if step.Status != "ok" || len(step.SideEffects) != 2 {
+		t.Fatalf("expected 2 planned timers, got %+v", step)
+	}
+	if effect := step.SideEffects[0]; effect.Kind != "timer" || effect.Details["triggerKey"] != "resend_deposit_link" ||
+		effect.Summary != "Fire resend_deposit_link for jane@example.com at 2026-03-11T10:00:00-05:00" {
+		t.Errorf("unexpected planned timer: %+v", effect)
+	}
+	if timers, _ := timersRepo.ListByLead(context.Background(), "stlpartyhelpers", "jane@example.com"); len(timers) != 0 {
+		t.Errorf("expected planning not to save timers, got %d", len(timers))
+	}
+}
+
+func TestScheduler_FiresDueTimers(t *testing.T) {
+	ctx := context.Background()
+	loader := config.NewBusinessLoader(newTestTimersConfig(t))
+	timersRepo := db.NewMemoryTimersRepo()
+	businesses := config.NewYAMLBusinessesRepo(loader)
+	service := NewTimersService(businesses, timersRepo)
+
+	timers, err := service.ScheduleEventTimers(ctx, "stlpartyhelpers", "jane@example.com", "2026-03-14", nil)
+	if err != nil {
+		t.Fatalf("ScheduleEventTimers failed: %v", err)
+	}
+
+	resend := &flakyAction{name: "resend_deposit_link"}
+	runner := domain.NewPipelineRunner(map[string]domain.Action{resend.name: resend})
+	jobsRepo := db.NewMemoryJobsRepo()
+	pool := NewWorkerPool(1, 10)
+	triggers := NewTriggersService(businesses, config.NewYAMLPipelinesRepo(loader), runner, jobsRepo, pool)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	locker := db.NewMemoryLocker()
+
+	// Both instances tick after the deposit reminder is due; the first tick
+	// fires overdue timers
+	now := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		NewScheduler(businesses, triggers, timersRepo, locker, logger, time.Minute).Tick(ctx, now)
+	}
+	if err := pool.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if resend.calls != 1 {
+		t.Fatalf("expected the deposit reminder to fire once, got %d", resend.calls)
+	}
+
+	reminder, err := timersRepo.GetByID(ctx, timers[0].ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if reminder.Status != domain.TimerStatusFired || reminder.JobID == "" {
+		t.Fatalf("expected the reminder to be fired with a job, got %+v", reminder)
+	}
+	job, err := jobsRepo.GetByID(ctx, reminder.JobID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if job.Source != "timer" || job.Input["leadId"] != "jane@example.com" || job.Input["eventDate"] != "2026-03-14" {
+		t.Errorf("unexpected timer job %+v", job)
+	}
+
+	review, err := timersRepo.GetByID(ctx, timers[1].ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if review.Status != domain.TimerStatusScheduled {
+		t.Errorf("expected the review request to still be scheduled, got %s", review.Status)
+	}
+}
+
+func TestScheduler_RetriesTimersThatCannotBeQueued(t *testing.T) {
+	ctx := context.Background()
+	loader := config.NewBusinessLoader(newTestTimersConfig(t))
+	timersRepo := db.NewMemoryTimersRepo()
+	businesses := config.NewYAMLBusinessesRepo(loader)
+	service := NewTimersService(businesses, timersRepo)
+
+	timers, err := service.ScheduleEventTimers(ctx, "stlpartyhelpers", "jane@example.com", "2026-03-14", nil)
+	if err != nil {
+		t.Fatalf("ScheduleEventTimers failed: %v", err)
+	}
+	// A timer whose trigger was removed from the business config
+	orphan := timers[1].Snapshot()
+	orphan.ID = domain.TimerID("stlpartyhelpers", "jane@example.com", "removed")
+	orphan.Key = "removed"
+	orphan.TriggerKey = "removed_trigger"
+	orphan.FireAt = timers[0].FireAt
+	if err := timersRepo.Save(ctx, orphan); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	resend := &flakyAction{name: "resend_deposit_link"}
+	runner := domain.NewPipelineRunner(map[string]domain.Action{resend.name: resend})
+	jobsRepo := db.NewMemoryJobsRepo()
+	pipelines := config.NewYAMLPipelinesRepo(loader)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	locker := db.NewMemoryLocker()
+	now := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+
+	// The worker pool is shutting down, so the run cannot be queued
+	closed := NewWorkerPool(1, 10)
+	if err := closed.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	NewScheduler(businesses, NewTriggersService(businesses, pipelines, runner, jobsRepo, closed), timersRepo, locker, logger, time.Minute).Tick(ctx, now)
+
+	reminder, err := timersRepo.GetByID(ctx, timers[0].ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if reminder.Status != domain.TimerStatusScheduled {
+		t.Fatalf("expected the reminder to stay scheduled, got %s: %s", reminder.Status, reminder.Error)
+	}
+	failed, err := timersRepo.GetByID(ctx, orphan.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if failed.Status != domain.TimerStatusFailed {
+		t.Errorf("expected the timer with an unknown trigger to fail, got %s", failed.Status)
+	}
+
+	// The next tick fires it
+	pool := NewWorkerPool(1, 10)
+	NewScheduler(businesses, NewTriggersService(businesses, pipelines, runner, jobsRepo, pool), timersRepo, locker, logger, time.Minute).Tick(ctx, now.Add(time.Minute))
+	if err := pool.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if reminder, _ = timersRepo.GetByID(ctx, timers[0].ID); reminder.Status != domain.TimerStatusFired || resend.calls != 1 {
+		t.Errorf("expected the reminder to fire on the next tick, got %s with %d calls", reminder.Status, resend.calls)
+	}
+}
@@ -2,37 +2,47 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/bizops360/go-api/internal/config"
 	"github.com/bizops360/go-api/internal/domain"
 	"github.com/bizops360/go-api/internal/ports"
 )
 
+// ErrUnknownTrigger is returned when a business config has no pipeline for a
+// trigger key
+var ErrUnknownTrigger = errors.New("unknown trigger")
+
 // TriggersService handles trigger-based pipeline execution
 type TriggersService struct {
-	businessLoader *config.BusinessLoader
+	businesses     ports.BusinessesRepo
+	pipelines      ports.PipelinesRepo
 	pipelineRunner *domain.PipelineRunner
 	jobsRepo       ports.JobsRepo
+	workerPool     *WorkerPool
 }
 
 // NewTriggersService creates a new triggers service
 func NewTriggersService(
-	businessLoader *config.BusinessLoader,
+	businesses ports.BusinessesRepo,
+	pipelines ports.PipelinesRepo,
 	pipelineRunner *domain.PipelineRunner,
 	jobsRepo ports.JobsRepo,
+	workerPool *WorkerPool,
 ) *TriggersService {
 	return &TriggersService{
-		businessLoader: businessLoader,
+		businesses:     businesses,
+		pipelines:      pipelines,
 		pipelineRunner: pipelineRunner,
 		jobsRepo:       jobsRepo,
+		workerPool:     workerPool,
 	}
 }
 
 // Run executes a trigger pipeline
 func (s *TriggersService) Run(ctx context.Context, req *TriggerRequest) (*domain.PipelineResult, error) {
 	// Load business config
-	business, err := s.businessLoader.LoadBusiness(ctx, req.BusinessID)
+	business, err := s.businesses.GetByID(ctx, req.BusinessID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load business: %w", err)
 	}
@@ -43,7 +53,7 @@ func (s *TriggersService) Run(ctx context.Context, req *TriggerRequest) (*domain
 		var ok bool
 		pipelineKey, ok = business.Pipelines.Triggers[req.TriggerKey]
 		if !ok {
-			return nil, fmt.Errorf("trigger key '%s' not found in business config", req.TriggerKey)
+			return nil, fmt.Errorf("%w: trigger key '%s' not found in business config", ErrUnknownTrigger, req.TriggerKey)
 		}
 	}
 	if pipelineKey == "" {
@@ -51,7 +61,7 @@ func (s *TriggersService) Run(ctx context.Context, req *TriggerRequest) (*domain
 	}
 
 	// Load pipeline
-	pipeline, err := s.businessLoader.LoadPipeline(ctx, pipelineKey)
+	pipeline, err := s.pipelines.GetByKey(ctx, pipelineKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load pipeline: %w", err)
 	}
@@ -66,20 +76,11 @@ func (s *TriggersService) Run(ctx context.Context, req *TriggerRequest) (*domain
 		Options:     make(map[string]any),
 		Resource:    req.Resource,
 		RequestID:   req.RequestID,
+		Business:    business,
 	}
 
-	// Run pipeline
-	result, job := s.pipelineRunner.Run(ctx, pipeline, pctx)
-
-	// Save job
-	if job != nil {
-		if err := s.jobsRepo.Save(ctx, job); err != nil {
-			// Log error but don't fail the request
-			_ = err
-		}
-	}
-
-	return result, nil
+	// Run pipeline (inline, or queued on the worker pool when async) and save the job
+	return runPipelineJob(ctx, s.pipelineRunner, s.jobsRepo, s.workerPool, pipeline, pctx, nil, req.Async)
 }
 
 // TriggerRequest represents a trigger request
@@ -92,5 +93,7 @@ type TriggerRequest struct {
 	Payload     map[string]any
 	DryRun      bool
 	RequestID   string
+	// Async queues the pipeline and returns the job ID without waiting for it to finish
+	Async bool
 }
 
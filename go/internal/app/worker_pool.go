@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+)
+
+// ErrJobQueueFull is returned when the worker pool cannot accept more jobs
+var ErrJobQueueFull = errors.New("job queue is full")
+
+// ErrWorkerPoolClosed is returned when submitting to a pool that is shutting down
+var ErrWorkerPoolClosed = errors.New("worker pool is shut down")
+
+// WorkerPool runs submitted tasks on a fixed number of goroutines with a bounded queue
+type WorkerPool struct {
+	tasks  chan func(ctx context.Context)
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+	logger *slog.Logger
+}
+
+// NewWorkerPool creates and starts a worker pool
+func NewWorkerPool(workers, queueSize int) *WorkerPool {
+	if workers <= 0 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &WorkerPool{
+		tasks:  make(chan func(ctx context.Context), queueSize),
+		ctx:    ctx,
+		cancel: cancel,
+		logger: slog.Default(),
+	}
+
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+
+	return p
+}
+
+// SetLogger sets the logger that reports tasks that panic and jobs that could
+// not be saved
+func (p *WorkerPool) SetLogger(logger *slog.Logger) {
+	p.logger = logger
+}
+
+// jobLogger returns the logger of pool, which may be nil when jobs only run inline
+func jobLogger(pool *WorkerPool) *slog.Logger {
+	if pool == nil {
+		return slog.Default()
+	}
+	return pool.logger
+}
+
+// Submit enqueues a task without blocking
+func (p *WorkerPool) Submit(task func(ctx context.Context)) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrWorkerPoolClosed
+	}
+
+	select {
+	case p.tasks <- task:
+		return nil
+	default:
+		return ErrJobQueueFull
+	}
+}
+
+// Shutdown stops accepting tasks and waits for queued tasks to finish.
+// If ctx expires first, running tasks are cancelled via their context.
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return fmt.Errorf("worker pool shutdown: %w", ctx.Err())
+	}
+}
+
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+	for task := range p.tasks {
+		p.run(task)
+	}
+}
+
+// run executes a single task, isolating the worker from panics
+func (p *WorkerPool) run(task func(ctx context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Error("worker task panicked", "panic", r, "stack", string(debug.Stack()))
+		}
+	}()
+	task(p.ctx)
+}
@@ -2,15 +2,29 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/bizops360/go-api/internal/domain"
 	"gopkg.in/yaml.v3"
 )
 
+// ErrPipelineCycle is returned when pipelines call each other, or fragments
+// include each other, in a cycle
+var ErrPipelineCycle = errors.New("pipeline cycle")
+
+// PipelineValidator checks a pipeline definition when it is loaded, e.g. that
+// its actions are registered (see domain.PipelineRunner.ValidatePipeline)
+type PipelineValidator interface {
+	ValidatePipeline(pipeline *domain.PipelineDefinition) error
+}
+
 // BusinessLoader loads and caches business configurations from YAML files
 type BusinessLoader struct {
 	config     *Config
@@ -18,6 +32,10 @@ type BusinessLoader struct {
 	cacheMu   sync.RWMutex
 	pipelines map[string]*domain.PipelineDefinition
 	pipelinesMu sync.RWMutex
+	validator PipelineValidator
+	// reloadMu serializes reloads and guards reloads
+	reloadMu sync.Mutex
+	reloads  reloadState
 }
 
 // NewBusinessLoader creates a new business loader
@@ -29,6 +47,11 @@ func NewBusinessLoader(cfg *Config) *BusinessLoader {
 	}
 }
 
+// SetPipelineValidator makes LoadPipeline reject pipelines that fail validation
+func (bl *BusinessLoader) SetPipelineValidator(validator PipelineValidator) {
+	bl.validator = validator
+}
+
 // LoadBusiness loads a business configuration by ID
 func (bl *BusinessLoader) LoadBusiness(ctx context.Context, businessID string) (*domain.BusinessConfig, error) {
 	// Check cache first
@@ -55,6 +78,9 @@ func (bl *BusinessLoader) LoadBusiness(ctx context.Context, businessID string) (
 	if business.ID == "" {
 		business.ID = businessID
 	}
+	if err := bl.validateBusiness(&business); err != nil {
+		return nil, err
+	}
 
 	// Cache it
 	bl.cacheMu.Lock()
@@ -64,8 +90,15 @@ func (bl *BusinessLoader) LoadBusiness(ctx context.Context, businessID string) (
 	return &business, nil
 }
 
-// LoadPipeline loads a pipeline definition by key
+// LoadPipeline loads a pipeline definition by key. Includes are expanded and,
+// when a validator is set, the pipeline and every pipeline it calls through
+// call_pipeline are validated; cycles of calls or includes are rejected.
 func (bl *BusinessLoader) LoadPipeline(ctx context.Context, pipelineKey string) (*domain.PipelineDefinition, error) {
+	return bl.loadPipeline(ctx, pipelineKey, nil)
+}
+
+// loadPipeline loads a pipeline called from the pipelines in callers
+func (bl *BusinessLoader) loadPipeline(ctx context.Context, pipelineKey string, callers []string) (*domain.PipelineDefinition, error) {
 	// Check cache first
 	bl.pipelinesMu.RLock()
 	if cached, exists := bl.pipelines[pipelineKey]; exists {
@@ -74,6 +107,12 @@ func (bl *BusinessLoader) LoadPipeline(ctx context.Context, pipelineKey string)
 	}
 	bl.pipelinesMu.RUnlock()
 
+	for _, caller := range callers {
+		if caller == pipelineKey {
+			return nil, fmt.Errorf("%w: %s -> %s", ErrPipelineCycle, strings.Join(callers, " -> "), pipelineKey)
+		}
+	}
+
 	// Load from file
 	path := bl.config.GetPipelineConfigPath(pipelineKey)
 	data, err := os.ReadFile(path)
@@ -90,6 +129,25 @@ func (bl *BusinessLoader) LoadPipeline(ctx context.Context, pipelineKey string)
 	if pipeline.Key == "" {
 		pipeline.Key = pipelineKey
 	}
+	if pipeline.Actions, err = bl.expandIncludes(pipeline.Actions, nil); err != nil {
+		return nil, fmt.Errorf("pipeline %s: %w", pipelineKey, err)
+	}
+	if bl.validator != nil {
+		if err := bl.validator.ValidatePipeline(&pipeline); err != nil {
+			return nil, err
+		}
+	}
+
+	// Load the called pipelines, which validates them and detects cycles
+	callers = append(callers[:len(callers):len(callers)], pipelineKey)
+	for _, called := range domain.CalledPipelines(&pipeline) {
+		if _, err := bl.loadPipeline(ctx, called, callers); err != nil {
+			if errors.Is(err, ErrPipelineCycle) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("pipeline %s calls %s: %w", pipelineKey, called, err)
+		}
+	}
 
 	// Cache it
 	bl.pipelinesMu.Lock()
@@ -99,6 +157,48 @@ func (bl *BusinessLoader) LoadPipeline(ctx context.Context, pipelineKey string)
 	return &pipeline, nil
 }
 
+// expandIncludes replaces include entries, including those in parallel
+// groups, with the actions of their fragment. including holds the fragments
+// being expanded, to detect include cycles.
+func (bl *BusinessLoader) expandIncludes(defs []domain.ActionDefinition, including []string) ([]domain.ActionDefinition, error) {
+	expanded := make([]domain.ActionDefinition, 0, len(defs))
+	for _, def := range defs {
+		if def.Parallel != nil {
+			actions, err := bl.expandIncludes(def.Parallel.Actions, including)
+			if err != nil {
+				return nil, err
+			}
+			group := *def.Parallel
+			group.Actions = actions
+			def.Parallel = &group
+		}
+		if def.Include == "" {
+			expanded = append(expanded, def)
+			continue
+		}
+
+		for _, name := range including {
+			if name == def.Include {
+				return nil, fmt.Errorf("%w: include %s -> %s", ErrPipelineCycle, strings.Join(including, " -> "), def.Include)
+			}
+		}
+		data, err := os.ReadFile(bl.config.GetFragmentConfigPath(def.Include))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fragment %s: %w", def.Include, err)
+		}
+		var fragment domain.PipelineDefinition
+		if err := yaml.Unmarshal(data, &fragment); err != nil {
+			return nil, fmt.Errorf("failed to parse fragment %s: %w", def.Include, err)
+		}
+		actions, err := bl.expandIncludes(fragment.Actions, append(including[:len(including):len(including)], def.Include))
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, actions...)
+	}
+	return expanded, nil
+}
+
 // LoadAllBusinesses loads all business configurations from the config directory
 func (bl *BusinessLoader) LoadAllBusinesses(ctx context.Context) ([]*domain.BusinessConfig, error) {
 	businessesDir := filepath.Join(bl.config.ConfigDir, "businesses")
@@ -125,11 +225,164 @@ func (bl *BusinessLoader) LoadAllBusinesses(ctx context.Context) ([]*domain.Busi
 	return businesses, nil
 }
 
-// GetBusinessConfigPath returns the path to a business config file
-func (bl *BusinessLoader) GetBusinessConfigPath(businessID string) string {
-	return bl.config.GetBusinessConfigPath(businessID)
+// validateBusiness validates a business against the pipeline files in the
+// config directory
+func (bl *BusinessLoader) validateBusiness(business *domain.BusinessConfig) error {
+	return validateBusinessConfig(business, func(pipelineKey string) bool {
+		_, err := os.Stat(bl.config.GetPipelineConfigPath(pipelineKey))
+		return err == nil
+	})
+}
+
+// validateBusinessConfig checks that the default form pipeline and every
+// trigger point to a pipeline for which exists is true, that the Monday.com
+// column mappings and triggers refer to configured boards and triggers, and
+// that the CRM config is complete
+func validateBusinessConfig(business *domain.BusinessConfig, exists func(pipelineKey string) bool) error {
+	var problems []string
+	if key := business.Pipelines.DefaultForm; key != "" && !exists(key) {
+		problems = append(problems, fmt.Sprintf("pipelines.defaultForm: pipeline '%s' not found", key))
+	}
+	triggerKeys := make([]string, 0, len(business.Pipelines.Triggers))
+	for triggerKey := range business.Pipelines.Triggers {
+		triggerKeys = append(triggerKeys, triggerKey)
+	}
+	sort.Strings(triggerKeys)
+	for _, triggerKey := range triggerKeys {
+		pipelineKey := business.Pipelines.Triggers[triggerKey]
+		if pipelineKey == "" || !exists(pipelineKey) {
+			problems = append(problems, fmt.Sprintf("pipelines.triggers.%s: pipeline '%s' not found", triggerKey, pipelineKey))
+		}
+	}
+
+	problems = append(problems, validateMondayColumns(business.Monday)...)
+	problems = append(problems, validateCRM(business.CRM)...)
+	for i, trigger := range business.Monday.Triggers {
+		field := fmt.Sprintf("monday.triggers[%d]", i)
+		if _, ok := business.Monday.Boards[trigger.Board]; trigger.Board != "" && !ok {
+			problems = append(problems, fmt.Sprintf("%s: board '%s' not found in monday.boards", field, trigger.Board))
+		}
+		if trigger.Column == "" || trigger.Value == "" {
+			problems = append(problems, fmt.Sprintf("%s: column and value are required", field))
+		}
+		if _, ok := business.Pipelines.Triggers[trigger.Trigger]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: trigger '%s' not found in pipelines.triggers", field, trigger.Trigger))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &domain.ValidationError{Source: "business " + business.ID, Problems: problems}
+	}
+	return nil
+}
+
+// validateMondayColumns checks that column mappings name configured boards
+// and supported column types
+func validateMondayColumns(monday domain.MondayConfig) []string {
+	var problems []string
+	boards := make([]string, 0, len(monday.Columns))
+	for board := range monday.Columns {
+		boards = append(boards, board)
+	}
+	sort.Strings(boards)
+	for _, board := range boards {
+		if _, ok := monday.Boards[board]; !ok {
+			problems = append(problems, fmt.Sprintf("monday.columns.%s: board '%s' not found in monday.boards", board, board))
+		}
+		fields := make([]string, 0, len(monday.Columns[board]))
+		for field := range monday.Columns[board] {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			column := monday.Columns[board][field]
+			if column.ID == "" {
+				problems = append(problems, fmt.Sprintf("monday.columns.%s.%s: id is required", board, field))
+			}
+			if column.Type != "" && !slices.Contains(domain.MondayColumnTypes, column.Type) {
+				problems = append(problems, fmt.Sprintf("monday.columns.%s.%s: unknown column type '%s' (want one of %s)", board, field, column.Type, strings.Join(domain.MondayColumnTypes, ", ")))
+			}
+		}
+	}
+	return problems
+}
+
+// validateCRM checks the CRM provider and the boards and status columns of
+// the built-in CRM
+func validateCRM(crm domain.CRMConfig) []string {
+	var problems []string
+	if !slices.Contains(domain.CRMProviders, crm.ProviderName()) {
+		problems = append(problems, fmt.Sprintf("crm.provider: unknown provider '%s' (want one of %s)", crm.Provider, strings.Join(domain.CRMProviders, ", ")))
+	}
+	if crm.Builtin() && len(crm.Boards) == 0 {
+		problems = append(problems, "crm.boards: the builtin provider needs at least one board")
+	}
+
+	boards := make([]string, 0, len(crm.Boards))
+	for board := range crm.Boards {
+		boards = append(boards, board)
+	}
+	sort.Strings(boards)
+	ids := make(map[int64]string, len(boards))
+	for _, name := range boards {
+		board := crm.Boards[name]
+		if board.ID <= 0 {
+			problems = append(problems, fmt.Sprintf("crm.boards.%s: id must be a positive number", name))
+		} else if other, ok := ids[board.ID]; ok {
+			problems = append(problems, fmt.Sprintf("crm.boards.%s: id %d is also used by board '%s'", name, board.ID, other))
+		} else {
+			ids[board.ID] = name
+		}
+
+		fields := make(map[string]bool, len(board.StatusColumns))
+		for i, column := range board.StatusColumns {
+			field := fmt.Sprintf("crm.boards.%s.statusColumns[%d]", name, i)
+			switch {
+			case column.Field == "":
+				problems = append(problems, fmt.Sprintf("%s: field is required", field))
+			case fields[column.Field]:
+				problems = append(problems, fmt.Sprintf("%s: field '%s' is listed twice", field, column.Field))
+			}
+			fields[column.Field] = true
+			if len(column.Labels) == 0 {
+				problems = append(problems, fmt.Sprintf("%s: labels are required", field))
+			}
+			if column.Default != "" && !slices.Contains(column.Labels, column.Default) {
+				problems = append(problems, fmt.Sprintf("%s: default '%s' is not one of the labels", field, column.Default))
+			}
+		}
+	}
+	return problems
+}
+
+// ListBusinessIDs returns the IDs of every business YAML file in the config
+// directory, sorted
+func (bl *BusinessLoader) ListBusinessIDs() ([]string, error) {
+	return listYAMLNames(filepath.Join(bl.config.ConfigDir, "businesses"))
+}
+
+// ListPipelineKeys returns the keys of every pipeline YAML file in the config
+// directory, sorted
+func (bl *BusinessLoader) ListPipelineKeys() ([]string, error) {
+	return listYAMLNames(filepath.Join(bl.config.ConfigDir, "pipelines"))
 }
 
+// listYAMLNames returns the names, without extension, of the .yaml files in dir
+func listYAMLNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
 
 // InvalidateCache removes a business configuration from the cache
 // This forces the next LoadBusiness call to reload from disk
@@ -0,0 +1,244 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+// newTestLoader writes files, keyed by path relative to the config dir, and
+// returns a loader that validates pipelines against a runner registering
+// normalize_input and send_slack_notification by name only
+func newTestLoader(t *testing.T, files map[string]string) *BusinessLoader {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loader := NewBusinessLoader(&Config{ConfigDir: dir})
+	loader.SetPipelineValidator(domain.NewPipelineRunner(map[string]domain.Action{
+		"normalize_input":         nil,
+		"send_slack_notification": nil,
+	}))
+	return loader
+}
+
+func TestBusinessLoader_LoadPipelineIncludes(t *testing.T) {
+	loader := newTestLoader(t, map[string]string{
+		"fragments/intake.yaml":            "actions:\n  - name: normalize_input\n    critical: true\n  - include: notify\n",
+		"fragments/notify.yaml":            "actions:\n  - name: send_slack_notification\n",
+		"pipelines/quote_and_deposit.yaml": "key: quote_and_deposit\nactions:\n  - include: intake\n  - parallel:\n      actions:\n        - include: notify\n",
+	})
+
+	pipeline, err := loader.LoadPipeline(context.Background(), "quote_and_deposit")
+	if err != nil {
+		t.Fatalf("LoadPipeline failed: %v", err)
+	}
+	var names []string
+	for _, def := range pipeline.Actions {
+		if def.Parallel != nil {
+			for _, inner := range def.Parallel.Actions {
+				names = append(names, "parallel:"+inner.Name)
+			}
+			continue
+		}
+		names = append(names, def.Name)
+	}
+	if got, want := strings.Join(names, ","), "normalize_input,send_slack_notification,parallel:send_slack_notification"; got != want {
+		t.Errorf("expected actions %s, got %s", want, got)
+	}
+	if !pipeline.Actions[0].Critical {
+		t.Error("expected fragment settings to be kept")
+	}
+}
+
+func TestBusinessLoader_LoadPipelineErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		key       string
+		wantCycle bool
+		wantErr   string
+	}{
+		{
+			name: "call cycle",
+			files: map[string]string{
+				"pipelines/a.yaml": "actions:\n  - name: call_pipeline\n    config:\n      pipelineKey: b\n",
+				"pipelines/b.yaml": "actions:\n  - name: call_pipeline\n    config:\n      pipelineKey: a\n",
+			},
+			key:       "a",
+			wantCycle: true,
+			wantErr:   "pipeline cycle: a -> b -> a",
+		},
+		{
+			name: "self call",
+			files: map[string]string{
+				"pipelines/a.yaml": "actions:\n  - name: call_pipeline\n    config:\n      pipelineKey: a\n",
+			},
+			key:       "a",
+			wantCycle: true,
+			wantErr:   "pipeline cycle: a -> a",
+		},
+		{
+			name: "include cycle",
+			files: map[string]string{
+				"fragments/x.yaml": "actions:\n  - include: y\n",
+				"fragments/y.yaml": "actions:\n  - include: x\n",
+				"pipelines/a.yaml": "actions:\n  - include: x\n",
+			},
+			key:       "a",
+			wantCycle: true,
+			wantErr:   "pipeline cycle: include x -> y -> x",
+		},
+		{
+			name: "missing called pipeline",
+			files: map[string]string{
+				"pipelines/a.yaml": "actions:\n  - name: call_pipeline\n    config:\n      pipelineKey: missing\n",
+			},
+			key:     "a",
+			wantErr: "pipeline a calls missing: failed to read pipeline config missing",
+		},
+		{
+			name: "invalid called pipeline",
+			files: map[string]string{
+				"pipelines/a.yaml": "actions:\n  - name: call_pipeline\n    config:\n      pipelineKey: b\n",
+				"pipelines/b.yaml": "actions:\n  - name: normalize_inptu\n",
+			},
+			key:     "a",
+			wantErr: "pipeline a calls b: invalid pipeline b: actions[0] (normalize_inptu): action 'normalize_inptu' is not registered",
+		},
+		{
+			name: "missing fragment",
+			files: map[string]string{
+				"pipelines/a.yaml": "actions:\n  - include: missing\n",
+			},
+			key:     "a",
+			wantErr: "pipeline a: failed to read fragment missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := newTestLoader(t, tt.files)
+			_, err := loader.LoadPipeline(context.Background(), tt.key)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if errors.Is(err, ErrPipelineCycle) != tt.wantCycle {
+				t.Errorf("expected errors.Is(err, ErrPipelineCycle) to be %v, got %v", tt.wantCycle, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+
+			// Failed pipelines are not cached
+			if _, err := loader.LoadPipeline(context.Background(), tt.key); err == nil {
+				t.Error("expected the second load to fail too")
+			}
+		})
+	}
+}
+
+func TestBusinessLoader_LoadBusinessMonday(t *testing.T) {
+	business := `monday:
+  boards:
+    deals: 234567890
+  columns:
+    deals:
+      email: {id: email, type: email}
+      eventDate: {id: date4, type: date}
+      notes: {id: text1}
+    leads:
+      status: {id: status, type: colour}
+      phone: {type: phone}
+  triggers:
+    - board: tasks
+      column: Status
+      value: Send Renewal
+      trigger: send_renewal_offer
+    - column: Status
+      trigger: send_renewal_offer
+`
+	loader := newTestLoader(t, map[string]string{"businesses/acme.yaml": business})
+	_, err := loader.LoadBusiness(context.Background(), "acme")
+
+	var invalid *domain.ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	want := []string{
+		"monday.columns.leads: board 'leads' not found in monday.boards",
+		"monday.columns.leads.phone: id is required",
+		"monday.columns.leads.status: unknown column type 'colour'",
+		"monday.triggers[0]: board 'tasks' not found in monday.boards",
+		"monday.triggers[0]: trigger 'send_renewal_offer' not found in pipelines.triggers",
+		"monday.triggers[1]: column and value are required",
+		"monday.triggers[1]: trigger 'send_renewal_offer' not found in pipelines.triggers",
+	}
+	if len(invalid.Problems) != len(want) {
+		t.Fatalf("expected %d problems, got %v", len(want), invalid.Problems)
+	}
+	for i, problem := range want {
+		if !strings.HasPrefix(invalid.Problems[i], problem) {
+			t.Errorf("expected problem %q, got %q", problem, invalid.Problems[i])
+		}
+	}
+}
+
+func TestBusinessLoader_LoadBusinessCRM(t *testing.T) {
+	business := `crm:
+  provider: builtin
+  boards:
+    deals:
+      id: 1
+      statusColumns:
+        - field: status
+          labels: [Lead, Booked]
+          default: Paid
+        - field: status
+          labels: [Lead]
+        - labels: [Yes, No]
+    tasks:
+      id: 1
+      statusColumns:
+        - field: priority
+`
+	loader := newTestLoader(t, map[string]string{"businesses/acme.yaml": business})
+	_, err := loader.LoadBusiness(context.Background(), "acme")
+
+	var invalid *domain.ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	want := []string{
+		"crm.boards.deals.statusColumns[0]: default 'Paid' is not one of the labels",
+		"crm.boards.deals.statusColumns[1]: field 'status' is listed twice",
+		"crm.boards.deals.statusColumns[2]: field is required",
+		"crm.boards.tasks: id 1 is also used by board 'deals'",
+		"crm.boards.tasks.statusColumns[0]: labels are required",
+	}
+	if len(invalid.Problems) != len(want) {
+		t.Fatalf("expected %d problems, got %v", len(want), invalid.Problems)
+	}
+	for i, problem := range want {
+		if !strings.HasPrefix(invalid.Problems[i], problem) {
+			t.Errorf("expected problem %q, got %q", problem, invalid.Problems[i])
+		}
+	}
+
+	loader = newTestLoader(t, map[string]string{"businesses/acme.yaml": "crm:\n  provider: hubspot\n"})
+	if _, err := loader.LoadBusiness(context.Background(), "acme"); err == nil || !strings.Contains(err.Error(), "unknown provider 'hubspot'") {
+		t.Errorf("expected the unknown provider to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// YAMLBusinessesRepo stores business configurations as YAML files in the
+// businesses directory of the config. It reads through a BusinessLoader, so it
+// shares its cache and hot reloads.
+type YAMLBusinessesRepo struct {
+	loader *BusinessLoader
+}
+
+// NewYAMLBusinessesRepo creates a businesses repository over the loader's
+// config directory
+func NewYAMLBusinessesRepo(loader *BusinessLoader) *YAMLBusinessesRepo {
+	return &YAMLBusinessesRepo{loader: loader}
+}
+
+// GetByID loads a business configuration by ID
+func (r *YAMLBusinessesRepo) GetByID(ctx context.Context, id string) (*domain.BusinessConfig, error) {
+	business, err := r.loader.LoadBusiness(ctx, id)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrBusinessNotFound, id)
+	}
+	return business, err
+}
+
+// GetAll loads every business configuration, sorted by ID. Businesses that
+// fail to load are left out.
+func (r *YAMLBusinessesRepo) GetAll(ctx context.Context) ([]*domain.BusinessConfig, error) {
+	return r.loader.LoadAllBusinesses(ctx)
+}
+
+// Save validates a business configuration, writes it to its YAML file,
+// keeping the comments and layout of the entries that did not change, and
+// reloads the config
+func (r *YAMLBusinessesRepo) Save(ctx context.Context, business *domain.BusinessConfig) error {
+	if business.ID == "" {
+		return errors.New("business ID is required")
+	}
+	if err := r.loader.validateBusiness(business); err != nil {
+		return err
+	}
+	if err := writeYAML(r.loader.config.GetBusinessConfigPath(business.ID), business); err != nil {
+		return fmt.Errorf("failed to save business config %s: %w", business.ID, err)
+	}
+	// Only a validated reload replaces the loaded config, so a save cannot
+	// load another file's broken edit along with it
+	if err := r.loader.Reload(ctx); err != nil {
+		return fmt.Errorf("saved business config %s but the config reload was rejected: %w", business.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+func TestYAMLBusinessesRepo(t *testing.T) {
+	portstest.RunBusinessesRepoTests(t, func(t *testing.T) ports.BusinessesRepo {
+		loader := newTestLoader(t, nil)
+		if err := os.MkdirAll(loader.config.ConfigDir+"/businesses", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return NewYAMLBusinessesRepo(loader)
+	})
+}
+
+func TestYAMLBusinessesRepo_SaveKeepsLayout(t *testing.T) {
+	ctx := context.Background()
+	loader := newTestLoader(t, map[string]string{
+		"businesses/acme.yaml": `# Acme business config
+id: acme
+displayName: "Acme" # shown in emails
+slack:
+  enabled: false
+templates:
+  emailTemplateSettings:
+    defaultTemplate: original
+`,
+	})
+	repo := NewYAMLBusinessesRepo(loader)
+
+	business, err := repo.GetByID(ctx, "acme")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	updated := *business
+	updated.Templates.EmailTemplateSettings.DefaultTemplate = "apple_style"
+	updated.Timezone = "America/Chicago"
+	if err := repo.Save(ctx, &updated); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(loader.config.GetBusinessConfigPath("acme"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Acme business config
+id: acme
+displayName: "Acme" # shown in emails
+slack:
+  enabled: false
+templates:
+  emailTemplateSettings:
+    defaultTemplate: apple_style
+timezone: America/Chicago
+`
+	if got := string(data); got != want {
+		t.Errorf("unexpected YAML after save:\n%s\nwant:\n%s", got, want)
+	}
+
+	got, err := repo.GetByID(ctx, "acme")
+	if err != nil || got.Templates.EmailTemplateSettings.DefaultTemplate != "apple_style" {
+		t.Errorf("expected the saved business to be served, got %+v, %v", got, err)
+	}
+}
+
+func TestYAMLBusinessesRepo_SaveValidates(t *testing.T) {
+	loader := newTestLoader(t, map[string]string{"businesses/acme.yaml": "id: acme\n"})
+	repo := NewYAMLBusinessesRepo(loader)
+
+	business := &domain.BusinessConfig{ID: "acme", Pipelines: domain.BusinessPipelineConfig{DefaultForm: "missing"}}
+	err := repo.Save(context.Background(), business)
+	if err == nil || !strings.Contains(err.Error(), "pipelines.defaultForm: pipeline 'missing' not found") {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	data, _ := os.ReadFile(loader.config.GetBusinessConfigPath("acme"))
+	if string(data) != "id: acme\n" {
+		t.Errorf("expected the file to be left alone, got %q", data)
+	}
+}
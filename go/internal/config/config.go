@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -18,6 +19,32 @@ type Config struct {
 	LogLevel        string
 	IsProduction    bool
 	IsDevelopment   bool
+	// JobWorkers is the number of goroutines running async pipeline jobs
+	JobWorkers int
+	// JobQueueSize is the number of async jobs that can wait for a worker
+	JobQueueSize int
+	// JobsStore selects the job history backend: "memory", "sqlite" or "firestore"
+	JobsStore string
+	// SQLitePath is the database file used when JobsStore is "sqlite"
+	SQLitePath string
+	// ConfigStore selects where business and pipeline configs are stored:
+	// "yaml" (the files in ConfigDir) or "firestore"
+	ConfigStore string
+	// SchedulerEnabled starts the cron scheduler for business schedules. It
+	// defaults to off with the memory JobsStore, whose locks are not shared
+	// between instances.
+	SchedulerEnabled bool
+	// SchedulerInterval is how often the scheduler checks for due runs
+	SchedulerInterval time.Duration
+	// IdempotencyTTL is how long a pipeline response is replayed for repeats
+	// of the same request
+	IdempotencyTTL time.Duration
+	// SlowStepThreshold is how long a pipeline step without a timeout may take
+	// before a slow-step warning is logged
+	SlowStepThreshold time.Duration
+	// ConfigReloadInterval is how often business and pipeline files are
+	// checked for changes; zero disables hot reload
+	ConfigReloadInterval time.Duration
 }
 
 // LoadConfig loads configuration from environment variables
@@ -86,15 +113,53 @@ func LoadConfig() *Config {
 		defaultLogLevel = "debug"
 	}
 	
+	jobsStore := getEnv("JOBS_STORE", "memory")
+
 	return &Config{
-		Port:          getEnv("PORT", "8080"),
-		Environment:   string(env),
-		ConfigDir:     getEnv("CONFIG_DIR", "/app/config"),
-		TemplatesDir:  getEnv("TEMPLATES_DIR", "/app/templates"),
-		LogLevel:      getEnv("LOG_LEVEL", defaultLogLevel),
-		IsProduction:  isProd,
-		IsDevelopment: isDev,
+		Port:                 getEnv("PORT", "8080"),
+		Environment:          string(env),
+		ConfigDir:            getEnv("CONFIG_DIR", "/app/config"),
+		TemplatesDir:         getEnv("TEMPLATES_DIR", "/app/templates"),
+		LogLevel:             getEnv("LOG_LEVEL", defaultLogLevel),
+		IsProduction:         isProd,
+		IsDevelopment:        isDev,
+		JobWorkers:           getEnvInt("JOB_WORKERS", 4),
+		JobQueueSize:         getEnvInt("JOB_QUEUE_SIZE", 100),
+		JobsStore:            jobsStore,
+		SQLitePath:           getEnv("SQLITE_PATH", "data/bizops.db"),
+		ConfigStore:          getEnv("CONFIG_STORE", "yaml"),
+		SchedulerEnabled:     getEnvBool("SCHEDULER_ENABLED", jobsStore != "memory"),
+		SchedulerInterval:    time.Duration(getEnvInt("SCHEDULER_INTERVAL_SECONDS", 30)) * time.Second,
+		IdempotencyTTL:       time.Duration(getEnvInt("IDEMPOTENCY_TTL_HOURS", 24)) * time.Hour,
+		SlowStepThreshold:    time.Duration(getEnvInt("SLOW_STEP_THRESHOLD_SECONDS", 5)) * time.Second,
+		ConfigReloadInterval: time.Duration(getEnvInt("CONFIG_RELOAD_INTERVAL_SECONDS", 10)) * time.Second,
+	}
+}
+
+// getEnvInt gets an integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
 	}
+	return parsed
+}
+
+// getEnvBool gets a boolean environment variable or returns a default value
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
 }
 
 // getEnv gets an environment variable or returns a default value
@@ -184,6 +249,11 @@ func (c *Config) GetPipelineConfigPath(pipelineKey string) string {
 	return filepath.Join(c.ConfigDir, "pipelines", pipelineKey+".yaml")
 }
 
+// GetFragmentConfigPath returns the path to a shared pipeline fragment file
+func (c *Config) GetFragmentConfigPath(name string) string {
+	return filepath.Join(c.ConfigDir, "fragments", name+".yaml")
+}
+
 // GetTemplatePath returns the path to a template file
 func (c *Config) GetTemplatePath(businessID, templateName string) string {
 	return filepath.Join(c.TemplatesDir, businessID, templateName)
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLPipelinesRepo stores pipeline definitions as YAML files in the
+// pipelines directory of the config. It reads through a BusinessLoader, so
+// includes are expanded and pipelines are validated and cached as by
+// LoadPipeline.
+type YAMLPipelinesRepo struct {
+	loader *BusinessLoader
+}
+
+// NewYAMLPipelinesRepo creates a pipelines repository over the loader's config
+// directory
+func NewYAMLPipelinesRepo(loader *BusinessLoader) *YAMLPipelinesRepo {
+	return &YAMLPipelinesRepo{loader: loader}
+}
+
+// GetByKey loads a pipeline definition by key
+func (r *YAMLPipelinesRepo) GetByKey(ctx context.Context, key string) (*domain.PipelineDefinition, error) {
+	pipeline, err := r.loader.LoadPipeline(ctx, key)
+	if err != nil {
+		// A missing called pipeline or fragment is a broken pipeline, not a
+		// missing one
+		if _, statErr := os.Stat(r.loader.config.GetPipelineConfigPath(key)); errors.Is(statErr, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ports.ErrPipelineNotFound, key)
+		}
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// GetAll loads every pipeline definition, sorted by key. Pipelines that fail
+// to load are left out.
+func (r *YAMLPipelinesRepo) GetAll(ctx context.Context) ([]*domain.PipelineDefinition, error) {
+	keys, err := r.loader.ListPipelineKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	var pipelines []*domain.PipelineDefinition
+	for _, key := range keys {
+		pipeline, err := r.loader.LoadPipeline(ctx, key)
+		if err != nil {
+			// Skip invalid pipelines like LoadAllBusinesses skips businesses
+			continue
+		}
+		pipelines = append(pipelines, pipeline)
+	}
+	return pipelines, nil
+}
+
+// ErrPipelineIncludes is returned when saving over a pipeline file that
+// includes fragments
+var ErrPipelineIncludes = errors.New("pipeline includes fragments")
+
+// Save validates a pipeline definition, writes it to its YAML file, keeping
+// the comments and layout of the entries that did not change, and reloads
+// the config.
+// Includes in a loaded pipeline are already expanded, so writing it back
+// would replace them with the fragment's actions; Save returns
+// ErrPipelineIncludes instead and such pipelines are edited by hand.
+func (r *YAMLPipelinesRepo) Save(ctx context.Context, pipeline *domain.PipelineDefinition) error {
+	if pipeline.Key == "" {
+		return errors.New("pipeline key is required")
+	}
+	path := r.loader.config.GetPipelineConfigPath(pipeline.Key)
+	includes, err := fileIncludesFragments(path)
+	if err != nil {
+		return err
+	}
+	if includes {
+		return fmt.Errorf("%w: %s", ErrPipelineIncludes, pipeline.Key)
+	}
+	if r.loader.validator != nil {
+		if err := r.loader.validator.ValidatePipeline(pipeline); err != nil {
+			return err
+		}
+	}
+	if err := writeYAML(path, pipeline); err != nil {
+		return fmt.Errorf("failed to save pipeline config %s: %w", pipeline.Key, err)
+	}
+	// Only a validated reload replaces the loaded config, see
+	// YAMLBusinessesRepo.Save
+	if err := r.loader.Reload(ctx); err != nil {
+		return fmt.Errorf("saved pipeline config %s but the config reload was rejected: %w", pipeline.Key, err)
+	}
+	return nil
+}
+
+// fileIncludesFragments reports whether the pipeline file at path, if there
+// is one, has include entries
+func fileIncludesFragments(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read pipeline config %s: %w", path, err)
+	}
+	var pipeline domain.PipelineDefinition
+	if err := yaml.Unmarshal(data, &pipeline); err != nil {
+		return false, fmt.Errorf("failed to parse pipeline config %s: %w", path, err)
+	}
+	return hasIncludes(pipeline.Actions), nil
+}
+
+func hasIncludes(defs []domain.ActionDefinition) bool {
+	for _, def := range defs {
+		if def.Include != "" {
+			return true
+		}
+		if def.Parallel != nil && hasIncludes(def.Parallel.Actions) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+func TestYAMLPipelinesRepo(t *testing.T) {
+	portstest.RunPipelinesRepoTests(t, func(t *testing.T) ports.PipelinesRepo {
+		loader := newTestLoader(t, nil)
+		if err := os.MkdirAll(loader.config.ConfigDir+"/pipelines", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return NewYAMLPipelinesRepo(loader)
+	})
+}
+
+func TestYAMLPipelinesRepo_Errors(t *testing.T) {
+	ctx := context.Background()
+	loader := newTestLoader(t, map[string]string{
+		"pipelines/broken.yaml": "actions:\n  - name: call_pipeline\n    config:\n      pipelineKey: missing\n",
+		"pipelines/intake.yaml": intakePipelineYAML,
+		"fragments/notify.yaml": "actions:\n  - name: send_slack_notification\n",
+	})
+	repo := NewYAMLPipelinesRepo(loader)
+
+	// A pipeline calling a missing one is broken, not missing
+	_, err := repo.GetByKey(ctx, "broken")
+	if err == nil || errors.Is(err, ports.ErrPipelineNotFound) {
+		t.Errorf("expected a load error other than ErrPipelineNotFound, got %v", err)
+	}
+
+	// Pipelines are validated before they are written
+	invalid := &domain.PipelineDefinition{Key: "invalid", Actions: []domain.ActionDefinition{{Name: "unknown_action"}}}
+	var validationErr *domain.ValidationError
+	if err := repo.Save(ctx, invalid); !errors.As(err, &validationErr) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+	if _, err := repo.GetByKey(ctx, "invalid"); !errors.Is(err, ports.ErrPipelineNotFound) {
+		t.Errorf("expected the invalid pipeline not to be written, got %v", err)
+	}
+
+	// Writing back a pipeline that includes fragments would inline them
+	intake, err := repo.GetByKey(ctx, "intake")
+	if err != nil {
+		t.Fatalf("GetByKey failed: %v", err)
+	}
+	if err := repo.Save(ctx, intake); !errors.Is(err, ErrPipelineIncludes) {
+		t.Errorf("expected ErrPipelineIncludes, got %v", err)
+	}
+	data, err := os.ReadFile(loader.config.GetPipelineConfigPath("intake"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != intakePipelineYAML {
+		t.Errorf("expected the pipeline file to be left alone, got:\n%s", data)
+	}
+}
+
+const intakePipelineYAML = `actions:
+  - name: normalize_input
+  - include: notify
+`
@@ -0,0 +1,234 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+// ConfigVersion identifies the configuration a business is running with
+type ConfigVersion struct {
+	BusinessID string `json:"businessId"`
+	// Hash covers the business config and every pipeline it can run, with
+	// includes expanded, so it changes whenever any of them does
+	Hash      string   `json:"hash"`
+	Pipelines []string `json:"pipelines"`
+	// LoadedAt is when this version was first loaded
+	LoadedAt time.Time `json:"loadedAt"`
+}
+
+// ReloadStatus describes the loaded configuration and the last reload
+type ReloadStatus struct {
+	// Generation counts successful reloads
+	Generation int       `json:"generation"`
+	ReloadedAt time.Time `json:"reloadedAt,omitzero"`
+	// LastError is set when the latest reload was rejected; the previous
+	// configuration stays loaded
+	LastError   string          `json:"lastError,omitempty"`
+	LastErrorAt time.Time       `json:"lastErrorAt,omitzero"`
+	Businesses  []ConfigVersion `json:"businesses"`
+}
+
+// reloadState is the reload bookkeeping of a BusinessLoader
+type reloadState struct {
+	status ReloadStatus
+	// versions holds the loaded version of each business by ID
+	versions map[string]ConfigVersion
+	// filesHash is the hash of the config files the latest reload saw
+	filesHash string
+}
+
+// Reload loads and validates every business and pipeline file and then
+// replaces the cached configuration with them in one step. If any file fails
+// to load, the cached configuration is kept as it is and the returned
+// *domain.ValidationError lists every problem. Reloads are serialized.
+func (bl *BusinessLoader) Reload(ctx context.Context) error {
+	bl.reloadMu.Lock()
+	defer bl.reloadMu.Unlock()
+
+	filesHash, err := bl.configFilesHash()
+	if err != nil {
+		return bl.rejectReload(err)
+	}
+	return bl.reload(ctx, filesHash)
+}
+
+// ReloadIfChanged reloads the configuration when the config files changed
+// since the last reload attempt. It reports whether a reload was attempted; a
+// rejected change is not retried until the files change again.
+func (bl *BusinessLoader) ReloadIfChanged(ctx context.Context) (bool, error) {
+	bl.reloadMu.Lock()
+	defer bl.reloadMu.Unlock()
+
+	filesHash, err := bl.configFilesHash()
+	if err != nil {
+		return false, err
+	}
+	if filesHash == bl.reloads.filesHash {
+		return false, nil
+	}
+	return true, bl.reload(ctx, filesHash)
+}
+
+// ReloadStatus returns the loaded config version of every business, sorted
+// by ID, and the outcome of the last reload
+func (bl *BusinessLoader) ReloadStatus() ReloadStatus {
+	bl.reloadMu.Lock()
+	defer bl.reloadMu.Unlock()
+
+	status := bl.reloads.status
+	status.Businesses = make([]ConfigVersion, 0, len(bl.reloads.versions))
+	for _, version := range bl.reloads.versions {
+		status.Businesses = append(status.Businesses, version)
+	}
+	sort.Slice(status.Businesses, func(i, j int) bool {
+		return status.Businesses[i].BusinessID < status.Businesses[j].BusinessID
+	})
+	return status
+}
+
+// reload does the work of Reload; the caller holds reloadMu
+func (bl *BusinessLoader) reload(ctx context.Context, filesHash string) error {
+	bl.reloads.filesHash = filesHash
+
+	// Load into a fresh loader so that nothing is visible until every file
+	// has loaded
+	staging := NewBusinessLoader(bl.config)
+	staging.validator = bl.validator
+
+	var problems []string
+	pipelineKeys, err := staging.ListPipelineKeys()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return bl.rejectReload(err)
+	}
+	for _, key := range pipelineKeys {
+		if _, err := staging.LoadPipeline(ctx, key); err != nil {
+			problems = append(problems, fmt.Sprintf("pipelines/%s.yaml: %v", key, err))
+		}
+	}
+	businessIDs, err := staging.ListBusinessIDs()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return bl.rejectReload(err)
+	}
+	for _, id := range businessIDs {
+		if _, err := staging.LoadBusiness(ctx, id); err != nil {
+			problems = append(problems, fmt.Sprintf("businesses/%s.yaml: %v", id, err))
+		}
+	}
+	if len(problems) > 0 {
+		return bl.rejectReload(&domain.ValidationError{Source: "config", Problems: problems})
+	}
+
+	now := time.Now()
+	versions := make(map[string]ConfigVersion, len(staging.cache))
+	for id, business := range staging.cache {
+		version, err := staging.businessVersion(business)
+		if err != nil {
+			return bl.rejectReload(err)
+		}
+		if previous, ok := bl.reloads.versions[id]; ok && previous.Hash == version.Hash {
+			version.LoadedAt = previous.LoadedAt
+		} else {
+			version.LoadedAt = now
+		}
+		versions[id] = version
+	}
+
+	bl.cacheMu.Lock()
+	bl.pipelinesMu.Lock()
+	bl.cache = staging.cache
+	bl.pipelines = staging.pipelines
+	bl.pipelinesMu.Unlock()
+	bl.cacheMu.Unlock()
+
+	bl.reloads.versions = versions
+	bl.reloads.status.Generation++
+	bl.reloads.status.ReloadedAt = now
+	bl.reloads.status.LastError = ""
+	bl.reloads.status.LastErrorAt = time.Time{}
+	return nil
+}
+
+// rejectReload records a failed reload and returns its error
+func (bl *BusinessLoader) rejectReload(err error) error {
+	bl.reloads.status.LastError = err.Error()
+	bl.reloads.status.LastErrorAt = time.Now()
+	return err
+}
+
+// businessVersion hashes a business and the cached pipelines it can run:
+// its default form pipeline, its triggers and the pipelines those call
+func (bl *BusinessLoader) businessVersion(business *domain.BusinessConfig) (ConfigVersion, error) {
+	seen := map[string]bool{}
+	var visit func(key string)
+	visit = func(key string) {
+		if key == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		if pipeline, ok := bl.pipelines[key]; ok {
+			for _, called := range domain.CalledPipelines(pipeline) {
+				visit(called)
+			}
+		}
+	}
+	visit(business.Pipelines.DefaultForm)
+	for _, key := range business.Pipelines.Triggers {
+		visit(key)
+	}
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	encoder := json.NewEncoder(hash)
+	if err := encoder.Encode(business); err != nil {
+		return ConfigVersion{}, fmt.Errorf("failed to hash business %s: %w", business.ID, err)
+	}
+	for _, key := range keys {
+		if err := encoder.Encode(bl.pipelines[key]); err != nil {
+			return ConfigVersion{}, fmt.Errorf("failed to hash pipeline %s: %w", key, err)
+		}
+	}
+	return ConfigVersion{
+		BusinessID: business.ID,
+		Hash:       hex.EncodeToString(hash.Sum(nil)),
+		Pipelines:  keys,
+	}, nil
+}
+
+// configFilesHash hashes the names and contents of every business, pipeline
+// and fragment file, to tell whether anything changed on disk
+func (bl *BusinessLoader) configFilesHash() (string, error) {
+	hash := sha256.New()
+	for _, dir := range []string{"businesses", "pipelines", "fragments"} {
+		names, err := listYAMLNames(filepath.Join(bl.config.ConfigDir, dir))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		for _, name := range names {
+			path := filepath.Join(dir, name+".yaml")
+			data, err := os.ReadFile(filepath.Join(bl.config.ConfigDir, path))
+			if err != nil {
+				return "", fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			fmt.Fprintf(hash, "%s\x00%d\x00", path, len(data))
+			hash.Write(data)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
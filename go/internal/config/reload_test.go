@@ -0,0 +1,97 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+func writeConfigFile(t *testing.T, loader *BusinessLoader, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(loader.config.ConfigDir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBusinessLoader_Reload(t *testing.T) {
+	ctx := context.Background()
+	loader := newTestLoader(t, map[string]string{
+		"businesses/acme.yaml":   "id: acme\npipelines:\n  defaultForm: intake\n",
+		"businesses/zenith.yaml": "id: zenith\ndisplayName: Zenith\n",
+		"pipelines/intake.yaml":  "key: intake\nactions:\n  - name: normalize_input\n",
+	})
+
+	reloaded, err := loader.ReloadIfChanged(ctx)
+	if err != nil || !reloaded {
+		t.Fatalf("expected the first check to reload, got %v, %v", reloaded, err)
+	}
+	status := loader.ReloadStatus()
+	if status.Generation != 1 || len(status.Businesses) != 2 || status.Businesses[0].BusinessID != "acme" {
+		t.Fatalf("unexpected status after the first reload: %+v", status)
+	}
+	acme, zenith := status.Businesses[0], status.Businesses[1]
+	if acme.Hash == "" || strings.Join(acme.Pipelines, ",") != "intake" {
+		t.Errorf("unexpected acme version: %+v", acme)
+	}
+
+	if reloaded, err := loader.ReloadIfChanged(ctx); err != nil || reloaded {
+		t.Errorf("expected no reload without changes, got %v, %v", reloaded, err)
+	}
+
+	// A pipeline change gives the businesses running it a new version
+	writeConfigFile(t, loader, "pipelines/intake.yaml", "key: intake\nactions:\n  - name: normalize_input\n  - name: send_slack_notification\n")
+	if reloaded, err := loader.ReloadIfChanged(ctx); err != nil || !reloaded {
+		t.Fatalf("expected the change to reload, got %v, %v", reloaded, err)
+	}
+	status = loader.ReloadStatus()
+	if status.Generation != 2 || status.Businesses[0].Hash == acme.Hash {
+		t.Errorf("expected acme to get a new version, got %+v", status.Businesses[0])
+	}
+	if got := status.Businesses[1]; got.Hash != zenith.Hash || !got.LoadedAt.Equal(zenith.LoadedAt) {
+		t.Errorf("expected zenith to keep its version, got %+v", got)
+	}
+	pipeline, err := loader.LoadPipeline(ctx, "intake")
+	if err != nil || len(pipeline.Actions) != 2 {
+		t.Fatalf("expected the reloaded pipeline to be served, got %+v, %v", pipeline, err)
+	}
+	good := status.Businesses[0].Hash
+
+	// A bad edit is rejected as a whole and the previous config stays loaded
+	writeConfigFile(t, loader, "pipelines/intake.yaml", "key: intake\nactions:\n  - name: send_carrier_pigeon\n")
+	writeConfigFile(t, loader, "businesses/zenith.yaml", "id: zenith\ndisplayName: Zenith Events\n")
+	_, err = loader.ReloadIfChanged(ctx)
+	var invalid *domain.ValidationError
+	if !errors.As(err, &invalid) || len(invalid.Problems) != 1 || !strings.HasPrefix(invalid.Problems[0], "pipelines/intake.yaml: ") {
+		t.Fatalf("expected the bad pipeline to be reported, got %v", err)
+	}
+	status = loader.ReloadStatus()
+	if status.Generation != 2 || status.LastError == "" || status.Businesses[0].Hash != good {
+		t.Errorf("expected the previous config to stay loaded, got %+v", status)
+	}
+	pipeline, err = loader.LoadPipeline(ctx, "intake")
+	if err != nil || len(pipeline.Actions) != 2 {
+		t.Errorf("expected the previous pipeline to be served, got %+v, %v", pipeline, err)
+	}
+	business, err := loader.LoadBusiness(ctx, "zenith")
+	if err != nil || business.DisplayName != "Zenith" {
+		t.Errorf("expected the previous business to be served, got %+v, %v", business, err)
+	}
+
+	// Fixing the edit loads both changes
+	writeConfigFile(t, loader, "pipelines/intake.yaml", "key: intake\nactions:\n  - name: normalize_input\n")
+	if _, err := loader.ReloadIfChanged(ctx); err != nil {
+		t.Fatalf("expected the fixed config to reload, got %v", err)
+	}
+	status = loader.ReloadStatus()
+	if status.Generation != 3 || status.LastError != "" {
+		t.Errorf("unexpected status after the fix: %+v", status)
+	}
+	if business, _ := loader.LoadBusiness(ctx, "zenith"); business == nil || business.DisplayName != "Zenith Events" {
+		t.Errorf("expected the new business config to be served, got %+v", business)
+	}
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// ValidatingPipelinesRepo validates the pipelines of a database-backed
+// PipelinesRepo the way BusinessLoader validates the YAML ones, so a config
+// edited in the database is checked like a YAML file: a pipeline and every
+// pipeline it calls through call_pipeline are validated, and cycles of calls
+// are rejected
+type ValidatingPipelinesRepo struct {
+	repo      ports.PipelinesRepo
+	validator PipelineValidator
+}
+
+// NewValidatingPipelinesRepo wraps repo with pipeline validation
+func NewValidatingPipelinesRepo(repo ports.PipelinesRepo) *ValidatingPipelinesRepo {
+	return &ValidatingPipelinesRepo{repo: repo}
+}
+
+// SetPipelineValidator makes the repository reject pipelines that fail
+// validation, as BusinessLoader.SetPipelineValidator does
+func (r *ValidatingPipelinesRepo) SetPipelineValidator(validator PipelineValidator) {
+	r.validator = validator
+}
+
+// GetByKey loads and validates a pipeline definition
+func (r *ValidatingPipelinesRepo) GetByKey(ctx context.Context, key string) (*domain.PipelineDefinition, error) {
+	pipeline, err := r.repo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validate(ctx, pipeline, nil); err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// GetAll loads every pipeline definition, sorted by key. Pipelines that fail
+// validation are left out, as YAMLPipelinesRepo.GetAll leaves them out.
+func (r *ValidatingPipelinesRepo) GetAll(ctx context.Context) ([]*domain.PipelineDefinition, error) {
+	pipelines, err := r.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	valid := make([]*domain.PipelineDefinition, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		if r.validate(ctx, pipeline, nil) == nil {
+			valid = append(valid, pipeline)
+		}
+	}
+	return valid, nil
+}
+
+// Save validates a pipeline definition before saving it. The pipelines it
+// calls must already be stored, and must not call it back.
+func (r *ValidatingPipelinesRepo) Save(ctx context.Context, pipeline *domain.PipelineDefinition) error {
+	if pipeline.Key == "" {
+		return errors.New("pipeline key is required")
+	}
+	if err := r.validate(ctx, pipeline, nil); err != nil {
+		return err
+	}
+	return r.repo.Save(ctx, pipeline)
+}
+
+// validate validates a pipeline called from the pipelines in callers and,
+// in turn, the pipelines it calls
+func (r *ValidatingPipelinesRepo) validate(ctx context.Context, pipeline *domain.PipelineDefinition, callers []string) error {
+	if r.validator != nil {
+		if err := r.validator.ValidatePipeline(pipeline); err != nil {
+			return err
+		}
+	}
+
+	callers = append(callers[:len(callers):len(callers)], pipeline.Key)
+	for _, called := range domain.CalledPipelines(pipeline) {
+		for _, caller := range callers {
+			if caller == called {
+				return fmt.Errorf("%w: %s -> %s", ErrPipelineCycle, strings.Join(callers, " -> "), called)
+			}
+		}
+		// A missing called pipeline is a broken pipeline, not a missing one
+		calledPipeline, err := r.repo.GetByKey(ctx, called)
+		if errors.Is(err, ports.ErrPipelineNotFound) {
+			return fmt.Errorf("pipeline %s calls %s, which does not exist", pipeline.Key, called)
+		}
+		if err == nil {
+			err = r.validate(ctx, calledPipeline, callers)
+		}
+		if err != nil {
+			if errors.Is(err, ErrPipelineCycle) {
+				return err
+			}
+			return fmt.Errorf("pipeline %s calls %s: %w", pipeline.Key, called, err)
+		}
+	}
+	return nil
+}
+
+// ValidatingBusinessesRepo validates the businesses of a database-backed
+// BusinessesRepo the way BusinessLoader validates the YAML ones. The
+// pipelines a business points at are looked up in pipelines.
+type ValidatingBusinessesRepo struct {
+	repo      ports.BusinessesRepo
+	pipelines ports.PipelinesRepo
+}
+
+// NewValidatingBusinessesRepo wraps repo with business validation
+func NewValidatingBusinessesRepo(repo ports.BusinessesRepo, pipelines ports.PipelinesRepo) *ValidatingBusinessesRepo {
+	return &ValidatingBusinessesRepo{repo: repo, pipelines: pipelines}
+}
+
+// GetByID loads and validates a business configuration
+func (r *ValidatingBusinessesRepo) GetByID(ctx context.Context, id string) (*domain.BusinessConfig, error) {
+	business, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validate(ctx, business); err != nil {
+		return nil, err
+	}
+	return business, nil
+}
+
+// GetAll loads every business configuration, sorted by ID. Businesses that
+// fail validation are left out, as YAMLBusinessesRepo.GetAll leaves them out.
+func (r *ValidatingBusinessesRepo) GetAll(ctx context.Context) ([]*domain.BusinessConfig, error) {
+	businesses, err := r.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	valid := make([]*domain.BusinessConfig, 0, len(businesses))
+	for _, business := range businesses {
+		if r.validate(ctx, business) == nil {
+			valid = append(valid, business)
+		}
+	}
+	return valid, nil
+}
+
+// Save validates a business configuration before saving it
+func (r *ValidatingBusinessesRepo) Save(ctx context.Context, business *domain.BusinessConfig) error {
+	if business.ID == "" {
+		return errors.New("business ID is required")
+	}
+	if err := r.validate(ctx, business); err != nil {
+		return err
+	}
+	return r.repo.Save(ctx, business)
+}
+
+func (r *ValidatingBusinessesRepo) validate(ctx context.Context, business *domain.BusinessConfig) error {
+	// A pipeline that cannot be looked up is not reported as missing
+	var lookupErr error
+	exists := func(pipelineKey string) bool {
+		_, err := r.pipelines.GetByKey(ctx, pipelineKey)
+		if err != nil && !errors.Is(err, ports.ErrPipelineNotFound) && lookupErr == nil {
+			lookupErr = fmt.Errorf("failed to look up pipeline %s: %w", pipelineKey, err)
+		}
+		return err == nil
+	}
+	err := validateBusinessConfig(business, exists)
+	if lookupErr != nil {
+		return lookupErr
+	}
+	return err
+}
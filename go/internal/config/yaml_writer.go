@@ -0,0 +1,144 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeYAML writes value to a YAML file. When the file exists, value is
+// merged into it: entries that did not change keep their comments, quoting
+// and order, and new entries are appended. Entries holding zero values are
+// only written when the file already has them.
+func writeYAML(path string, value any) error {
+	var updated yaml.Node
+	if err := updated.Encode(value); err != nil {
+		return err
+	}
+
+	var existing yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(data, &existing); err != nil {
+			return err
+		}
+	}
+
+	var root *yaml.Node
+	if existing.Kind == yaml.DocumentNode && len(existing.Content) == 1 {
+		existing.Content[0] = mergeYAML(existing.Content[0], &updated)
+		root = &existing
+	} else {
+		root = pruneYAML(&updated)
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, out.Bytes(), 0o644)
+}
+
+// mergeYAML merges updated into existing and returns the merged node.
+// Mapping entries missing from updated are removed; sequences of the same
+// length are merged element by element and replaced otherwise.
+func mergeYAML(existing, updated *yaml.Node) *yaml.Node {
+	if existing.Kind != updated.Kind {
+		return pruneYAML(updated)
+	}
+
+	switch updated.Kind {
+	case yaml.MappingNode:
+		values := make(map[string]*yaml.Node, len(updated.Content)/2)
+		for i := 0; i+1 < len(updated.Content); i += 2 {
+			values[updated.Content[i].Value] = updated.Content[i+1]
+		}
+
+		merged := make([]*yaml.Node, 0, len(updated.Content))
+		seen := make(map[string]bool, len(existing.Content)/2)
+		for i := 0; i+1 < len(existing.Content); i += 2 {
+			key := existing.Content[i]
+			value, ok := values[key.Value]
+			if !ok {
+				continue
+			}
+			seen[key.Value] = true
+			merged = append(merged, key, mergeYAML(existing.Content[i+1], value))
+		}
+		for i := 0; i+1 < len(updated.Content); i += 2 {
+			key, value := updated.Content[i], pruneYAML(updated.Content[i+1])
+			if !seen[key.Value] && !isEmptyYAML(value) {
+				merged = append(merged, key, value)
+			}
+		}
+		existing.Content = merged
+		return existing
+
+	case yaml.SequenceNode:
+		if len(existing.Content) != len(updated.Content) {
+			return pruneYAML(updated)
+		}
+		for i := range updated.Content {
+			existing.Content[i] = mergeYAML(existing.Content[i], updated.Content[i])
+		}
+		return existing
+
+	default:
+		if existing.Value != updated.Value || existing.Tag != updated.Tag {
+			existing.Value, existing.Tag, existing.Style = updated.Value, updated.Tag, updated.Style
+		}
+		return existing
+	}
+}
+
+// pruneYAML removes the mapping entries of node, at any depth, that hold zero
+// values
+func pruneYAML(node *yaml.Node) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		pruned := make([]*yaml.Node, 0, len(node.Content))
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if value := pruneYAML(node.Content[i+1]); !isEmptyYAML(value) {
+				pruned = append(pruned, node.Content[i], value)
+			}
+		}
+		node.Content = pruned
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			pruneYAML(item)
+		}
+	}
+	return node
+}
+
+// isEmptyYAML reports whether node holds a zero value: null, "", 0, false or
+// an empty mapping or sequence
+func isEmptyYAML(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(node.Content) == 0
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!null":
+			return true
+		case "!!str":
+			return node.Value == ""
+		case "!!int":
+			return node.Value == "0"
+		case "!!bool":
+			return node.Value == "false"
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Config value types understood by ActionSchema
+const (
+	ConfigTypeString  = "string"
+	ConfigTypeInteger = "integer"
+	ConfigTypeNumber  = "number"
+	ConfigTypeBoolean = "boolean"
+	ConfigTypeList    = "list"
+	ConfigTypeObject  = "object"
+)
+
+// ActionSchema declares the config an action accepts in pipeline YAML
+type ActionSchema struct {
+	Description string                 `json:"description,omitempty"`
+	Config      map[string]ConfigField `json:"config"`
+}
+
+// ConfigField describes one key of an action's config
+type ConfigField struct {
+	// Type is one of the ConfigType constants; empty accepts any value
+	Type        string `json:"type,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// DescribedAction is implemented by actions that declare their config schema.
+// Pipelines using an action that does not implement it accept any config.
+type DescribedAction interface {
+	Schema() ActionSchema
+}
+
+// Validate returns a problem for every unknown key, missing required key and
+// value of the wrong type in config, sorted by key
+func (s ActionSchema) Validate(config map[string]any) []string {
+	var problems []string
+	for key, value := range config {
+		field, ok := s.Config[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown config key %q", key))
+			continue
+		}
+		if !configValueHasType(value, field.Type) {
+			problems = append(problems, fmt.Sprintf("config key %q must be of type %s, got %T", key, field.Type, value))
+		}
+	}
+	for key, field := range s.Config {
+		if _, ok := config[key]; field.Required && !ok {
+			problems = append(problems, fmt.Sprintf("missing required config key %q", key))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// configValueHasType reports whether a decoded YAML or JSON value has the
+// declared config type. A string that is exactly one {{ reference }} is
+// accepted for any type since its value is only known at run time.
+func configValueHasType(value any, configType string) bool {
+	if s, ok := value.(string); ok {
+		if s = strings.TrimSpace(s); s != "" && templatePattern.FindString(s) == s {
+			return true
+		}
+	}
+	switch configType {
+	case "":
+		return true
+	case ConfigTypeString:
+		_, ok := value.(string)
+		return ok
+	case ConfigTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case ConfigTypeInteger:
+		switch v := value.(type) {
+		case int, int64:
+			return true
+		case float64:
+			return v == math.Trunc(v)
+		}
+		return false
+	case ConfigTypeNumber:
+		switch value.(type) {
+		case int, int64, float64:
+			return true
+		}
+		return false
+	case ConfigTypeList:
+		_, ok := value.([]any)
+		return ok
+	case ConfigTypeObject:
+		_, ok := value.(map[string]any)
+		return ok
+	}
+	return false
+}
+
+// ValidationError lists every problem found in a configuration document
+type ValidationError struct {
+	// Source names what was validated, e.g. "pipeline quote_and_deposit"
+	Source   string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Source, strings.Join(e.Problems, "; "))
+}
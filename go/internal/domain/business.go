@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 // BusinessConfig represents the configuration for a business
 type BusinessConfig struct {
 	ID          string                 `yaml:"id" json:"id"`
@@ -8,18 +10,99 @@ type BusinessConfig struct {
 	Currency    string                 `yaml:"currency" json:"currency"`
 	Location    LocationConfig         `yaml:"location" json:"location"`
 	Monday      MondayConfig           `yaml:"monday" json:"monday"`
+	CRM         CRMConfig              `yaml:"crm,omitempty" json:"crm,omitempty"`
 	Stripe      StripeConfig           `yaml:"stripe" json:"stripe"`
 	Gmail       GmailConfig            `yaml:"gmail" json:"gmail"`
 	Contact     ContactConfig          `yaml:"contact" json:"contact"`
 	Slack       SlackConfig            `yaml:"slack" json:"slack"`
 	Templates   TemplateConfig         `yaml:"templates" json:"templates"`
 	Pipelines   BusinessPipelineConfig `yaml:"pipelines" json:"pipelines"`
+	Input       InputSchema            `yaml:"input" json:"input"`
 }
 
 // MondayConfig holds Monday.com integration settings
 type MondayConfig struct {
 	APITokenEnv string           `yaml:"apiTokenEnv" json:"apiTokenEnv"`
 	Boards      map[string]int64 `yaml:"boards" json:"boards"`
+	// Columns maps deal fields to board columns, by board name, e.g.
+	// columns.deals.eventDate: {id: date4, type: date}
+	Columns map[string]map[string]MondayColumn `yaml:"columns,omitempty" json:"columns,omitempty"`
+	// WebhookSecretEnv names the environment variable holding the secret
+	// inbound webhooks are verified with: the app signing secret, or the
+	// token in the webhook URL
+	WebhookSecretEnv string `yaml:"webhookSecretEnv,omitempty" json:"webhookSecretEnv,omitempty"`
+	// Triggers fire pipeline triggers when a status column changes
+	Triggers []MondayTrigger `yaml:"triggers,omitempty" json:"triggers,omitempty"`
+}
+
+// MondayTrigger fires a trigger when a column of a board changes to a value,
+// e.g. Status → Send Renewal fires send_renewal_offer
+type MondayTrigger struct {
+	// Board is a name from boards; empty matches every board
+	Board string `yaml:"board,omitempty" json:"board,omitempty"`
+	// Column is the column ID or title, e.g. status or Status
+	Column string `yaml:"column" json:"column"`
+	// Value is the label the column changes to
+	Value string `yaml:"value" json:"value"`
+	// Trigger is a key of pipelines.triggers
+	Trigger string `yaml:"trigger" json:"trigger"`
+}
+
+// MondayColumn is the board column a deal field is written to
+type MondayColumn struct {
+	ID string `yaml:"id" json:"id"`
+	// Type is one of the MondayColumn constants and decides how values are
+	// written; empty is text
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+}
+
+// Monday.com column types deal fields can be written to
+const (
+	MondayColumnText     = "text"
+	MondayColumnLongText = "long_text"
+	MondayColumnNumbers  = "numbers"
+	MondayColumnStatus   = "status"
+	MondayColumnDropdown = "dropdown"
+	MondayColumnDate     = "date"
+	MondayColumnEmail    = "email"
+	MondayColumnPhone    = "phone"
+	MondayColumnLink     = "link"
+	MondayColumnCheckbox = "checkbox"
+)
+
+// MondayColumnTypes lists the supported column types
+var MondayColumnTypes = []string{
+	MondayColumnText, MondayColumnLongText, MondayColumnNumbers, MondayColumnStatus, MondayColumnDropdown,
+	MondayColumnDate, MondayColumnEmail, MondayColumnPhone, MondayColumnLink, MondayColumnCheckbox,
+}
+
+// BoardName returns the name boardID is configured under, if any
+func (c MondayConfig) BoardName(boardID int64) (string, bool) {
+	for name, id := range c.Boards {
+		if id == boardID {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// TriggerFor returns the trigger key of the first trigger matching a change
+// of a column, given by ID and title, on boardID to value. Titles and values
+// match case-insensitively.
+func (c MondayConfig) TriggerFor(boardID int64, columnID, columnTitle, value string) (string, bool) {
+	boardName, _ := c.BoardName(boardID)
+	for _, t := range c.Triggers {
+		if t.Board != "" && t.Board != boardName {
+			continue
+		}
+		if t.Column != columnID && !strings.EqualFold(t.Column, columnTitle) {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(t.Value), strings.TrimSpace(value)) {
+			return t.Trigger, true
+		}
+	}
+	return "", false
 }
 
 // StripeConfig holds Stripe payment settings
@@ -81,8 +164,102 @@ type EmailTemplateSettings struct {
 
 // BusinessPipelineConfig holds pipeline configuration
 type BusinessPipelineConfig struct {
-	DefaultForm string            `yaml:"defaultForm" json:"defaultForm"`
-	Triggers    map[string]string `yaml:"triggers" json:"triggers"`
+	DefaultForm string             `yaml:"defaultForm" json:"defaultForm"`
+	Triggers    map[string]string  `yaml:"triggers" json:"triggers"`
+	Schedules   []ScheduleConfig   `yaml:"schedules,omitempty" json:"schedules,omitempty"`
+	EventTimers []EventTimerConfig `yaml:"eventTimers,omitempty" json:"eventTimers,omitempty"`
+}
+
+// ScheduleConfig runs a trigger on a cron schedule in the business timezone
+type ScheduleConfig struct {
+	// Key identifies the schedule within the business
+	Key string `yaml:"key" json:"key"`
+
+	// Cron is a standard 5-field cron expression ("0 9 * * MON") or a
+	// descriptor such as "@daily", evaluated in the business timezone
+	Cron string `yaml:"cron" json:"cron"`
+
+	// Trigger is a key of pipelines.triggers; PipelineKey names a pipeline
+	// directly. One of them is required.
+	Trigger     string `yaml:"trigger,omitempty" json:"trigger,omitempty"`
+	PipelineKey string `yaml:"pipelineKey,omitempty" json:"pipelineKey,omitempty"`
+
+	// Payload is passed to the pipeline as its fields
+	Payload map[string]any `yaml:"payload,omitempty" json:"payload,omitempty"`
+
+	// Disabled keeps the schedule in the config without running it
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+}
+
+// EventTimerConfig runs a trigger relative to a lead's event date. The
+// schedule_event_timers action schedules every event timer for the lead.
+type EventTimerConfig struct {
+	// Key identifies the timer within the business; a lead has at most one
+	// timer per key
+	Key string `yaml:"key" json:"key"`
+
+	// Trigger is a key of pipelines.triggers
+	Trigger string `yaml:"trigger" json:"trigger"`
+
+	// OffsetDays is negative before the event (-3 is three days before) and
+	// positive after it
+	OffsetDays int `yaml:"offsetDays" json:"offsetDays"`
+
+	// At is the HH:MM time of day in the business timezone (default 00:00)
+	At string `yaml:"at,omitempty" json:"at,omitempty"`
+
+	// Payload is added to the pipeline fields when the timer fires
+	Payload map[string]any `yaml:"payload,omitempty" json:"payload,omitempty"`
+}
+
+// InputSchema declares the canonical form fields a business accepts.
+// The normalize_input action uses it to rename aliases, apply defaults,
+// coerce values and reject invalid submissions.
+type InputSchema struct {
+	Fields []InputField `yaml:"fields" json:"fields"`
+}
+
+// Input field types understood by normalize_input
+const (
+	InputTypeString  = "string"
+	InputTypeEmail   = "email"
+	InputTypeInteger = "integer"
+	InputTypeFloat   = "float"
+	InputTypeDate    = "date"
+	InputTypeBoolean = "boolean"
+)
+
+// InputField describes one canonical input field
+type InputField struct {
+	// Name is the canonical field name written back into the pipeline fields
+	Name string `yaml:"name" json:"name"`
+
+	// Aliases are alternative incoming names (e.g. "helpers_requested" for "numHelpers").
+	// The canonical name wins when both are present.
+	Aliases []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+
+	// Type selects the coercion: string (default), email, integer (first integer
+	// in the text), float (first number in the text), date or boolean
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// Required fields must be present and non-empty after defaults are applied
+	Required bool `yaml:"required,omitempty" json:"required,omitempty"`
+
+	// Default is used when the field is missing or empty
+	Default any `yaml:"default,omitempty" json:"default,omitempty"`
+
+	// Format is the output layout for date fields (default: 2006-01-02)
+	Format string `yaml:"format,omitempty" json:"format,omitempty"`
+
+	// Fallback replaces a catch-all answer such as "Other" with a free-text field
+	Fallback *InputFallback `yaml:"fallback,omitempty" json:"fallback,omitempty"`
+}
+
+// InputFallback names the field to use when the value contains Trigger
+// (case-insensitive), e.g. occasion "Other" -> occasion_as_you_see_it
+type InputFallback struct {
+	Field   string `yaml:"field" json:"field"`
+	Trigger string `yaml:"trigger" json:"trigger"`
 }
 
 // LocationConfig holds business location settings for distance calculations
@@ -0,0 +1,40 @@
+package domain
+
+import "testing"
+
+func TestMondayConfig_TriggerFor(t *testing.T) {
+	monday := MondayConfig{
+		Boards: map[string]int64{"deals": 234567890, "leads": 123456789},
+		Triggers: []MondayTrigger{
+			{Board: "deals", Column: "Status", Value: "Send Renewal", Trigger: "send_renewal_offer"},
+			{Board: "deals", Column: "status", Value: "Deposit Paid", Trigger: "booking_confirmed"},
+			{Column: "priority", Value: "Urgent", Trigger: "notify_owner"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		boardID     int64
+		columnID    string
+		columnTitle string
+		value       string
+		want        string
+	}{
+		{name: "by column title", boardID: 234567890, columnID: "status", columnTitle: "Status", value: "Send Renewal", want: "send_renewal_offer"},
+		{name: "value case", boardID: 234567890, columnID: "status", columnTitle: "Status", value: "send renewal", want: "send_renewal_offer"},
+		{name: "by column ID", boardID: 234567890, columnID: "status", columnTitle: "Stage", value: "Deposit Paid", want: "booking_confirmed"},
+		{name: "any board", boardID: 999, columnID: "priority", columnTitle: "Priority", value: "Urgent", want: "notify_owner"},
+		{name: "other board", boardID: 123456789, columnID: "status", columnTitle: "Status", value: "Send Renewal"},
+		{name: "other value", boardID: 234567890, columnID: "status", columnTitle: "Status", value: "Booked"},
+		{name: "other column", boardID: 234567890, columnID: "status7", columnTitle: "Payment", value: "Send Renewal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := monday.TriggerFor(tt.boardID, tt.columnID, tt.columnTitle, tt.value)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("expected %q, got %q (%v)", tt.want, got, ok)
+			}
+		})
+	}
+}
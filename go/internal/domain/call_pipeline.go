@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CallPipelineActionName is the built-in action that runs another pipeline as
+// a nested sub-job
+const CallPipelineActionName = "call_pipeline"
+
+// maxPipelineDepth limits how deeply call_pipeline can nest at run time
+const maxPipelineDepth = 8
+
+// PipelineLoader loads pipeline definitions by key; ports.PipelinesRepo
+// satisfies it
+type PipelineLoader interface {
+	GetByKey(ctx context.Context, key string) (*PipelineDefinition, error)
+}
+
+// SetPipelineLoader enables the built-in call_pipeline action, which loads the
+// pipelines it calls through loader
+func (pr *PipelineRunner) SetPipelineLoader(loader PipelineLoader) {
+	pr.loader = loader
+}
+
+// CalledPipelines returns the keys of the pipelines a definition calls through
+// call_pipeline, in definition order. Keys that are {{ references }} are only
+// known at run time and are left out.
+func CalledPipelines(pipeline *PipelineDefinition) []string {
+	var keys []string
+	var walk func(defs []ActionDefinition)
+	walk = func(defs []ActionDefinition) {
+		for _, def := range defs {
+			if def.Parallel != nil {
+				walk(def.Parallel.Actions)
+				continue
+			}
+			if def.Name == CallPipelineActionName {
+				if key, ok := def.Config["pipelineKey"].(string); ok && key != "" && !strings.Contains(key, "{{") {
+					keys = append(keys, key)
+				}
+			}
+			if def.Compensate != nil {
+				walk([]ActionDefinition{*def.Compensate})
+			}
+		}
+	}
+	walk(pipeline.Actions)
+	return keys
+}
+
+type callStackKey struct{}
+
+type resumedSubJobKey struct{}
+
+// withResumedSubJob hands the sub-job recorded by a failed call_pipeline step
+// to the action resuming it
+func withResumedSubJob(ctx context.Context, subJob *Job) context.Context {
+	return context.WithValue(ctx, resumedSubJobKey{}, subJob)
+}
+
+// callPipelineAction is the built-in call_pipeline action. It runs the pipeline
+// named by its pipelineKey config with a copy of the caller's fields, merged
+// with its optional fields config, and records the nested job on its step.
+type callPipelineAction struct {
+	runner *PipelineRunner
+}
+
+func (a *callPipelineAction) Name() string {
+	return CallPipelineActionName
+}
+
+func (a *callPipelineAction) Schema() ActionSchema {
+	return ActionSchema{
+		Description: "Runs another pipeline as a nested sub-job",
+		Config: map[string]ConfigField{
+			"pipelineKey": {Type: ConfigTypeString, Required: true, Description: "Pipeline to run"},
+			"fields":      {Type: ConfigTypeObject, Description: "Fields added to the caller's fields for the nested pipeline"},
+		},
+	}
+}
+
+func (a *callPipelineAction) Execute(ctx context.Context, pctx *PipelineContext) JobStep {
+	config := ActionConfig(ctx)
+	pipelineKey, _ := config["pipelineKey"].(string)
+	if pipelineKey == "" {
+		return FailedStep(a.Name(), Permanent(fmt.Errorf("pipelineKey is required")))
+	}
+	if a.runner.loader == nil {
+		return FailedStep(a.Name(), Permanent(fmt.Errorf("no pipeline loader configured")))
+	}
+
+	// Guard against cycles that load-time validation could not see, such as
+	// a pipelineKey taken from the fields
+	stack, _ := ctx.Value(callStackKey{}).([]string)
+	if len(stack) == 0 {
+		stack = []string{pctx.PipelineKey}
+	}
+	for _, key := range stack {
+		if key == pipelineKey {
+			return FailedStep(a.Name(), Permanent(fmt.Errorf("pipeline cycle: %s -> %s", strings.Join(stack, " -> "), pipelineKey)))
+		}
+	}
+	if len(stack) >= maxPipelineDepth {
+		return FailedStep(a.Name(), Permanent(fmt.Errorf("pipelines nested more than %d levels deep", maxPipelineDepth)))
+	}
+
+	pipeline, err := a.runner.loader.GetByKey(ctx, pipelineKey)
+	if err != nil {
+		return FailedStep(a.Name(), err)
+	}
+
+	extra, _ := config["fields"].(map[string]any)
+	fields := make(map[string]any, len(pctx.Fields)+len(extra))
+	for k, v := range pctx.Fields {
+		fields[k] = v
+	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+	child := *pctx
+	child.PipelineKey = pipelineKey
+	child.Fields = fields
+	child.RequestID = pctx.RequestID + "/" + pipelineKey
+	child.ReplayOf = ""
+
+	// When resuming, steps the nested job already completed are not run again.
+	// The sub-job is cleared from the context so deeper calls do not see it.
+	previous, _ := ctx.Value(resumedSubJobKey{}).(*Job)
+	if previous != nil && previous.PipelineKey != pipelineKey {
+		previous = nil
+	}
+	ctx = withResumedSubJob(ctx, nil)
+
+	callStack := append(append([]string(nil), stack...), pipelineKey)
+	result, job := a.runner.run(context.WithValue(ctx, callStackKey{}, callStack), pipeline, &child, previous, nil)
+
+	// Later steps of the caller can reference the nested steps' outputs
+	outputs := map[string]any{}
+	for _, step := range job.Steps {
+		for k, v := range step.Outputs {
+			outputs[k] = v
+		}
+	}
+	step := JobStep{
+		Name:   a.Name(),
+		Status: "ok",
+		Details: map[string]any{
+			"pipelineKey": pipelineKey,
+			"jobId":       job.ID,
+		},
+		Outputs: outputs,
+		SubJob:  job,
+	}
+	if !result.Success {
+		step.Status = "failed"
+		step.Error = stringPtr(fmt.Sprintf("pipeline %s failed", pipelineKey))
+		if result.Error != nil {
+			step.Error = stringPtr(fmt.Sprintf("pipeline %s failed: %s", pipelineKey, *result.Error))
+		}
+	}
+	return step
+}
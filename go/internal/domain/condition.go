@@ -0,0 +1,467 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Condition is a parsed "when" expression attached to an action definition.
+//
+// Supported syntax:
+//   - literals: numbers, 'single' or "double" quoted strings, true, false, null
+//   - paths: fields.numHelpers, options.payWithCheck, source, dryRun,
+//     businessId, pipelineKey, steps.<action>.status, steps.<action>.<output>,
+//     steps.<action>.<detail>
+//     (a bare name that is not one of the roots is looked up in fields)
+//   - comparison: == != > >= < <=
+//   - logic: && || ! and parentheses
+type Condition struct {
+	expr string
+	root conditionNode
+}
+
+// ParseCondition parses a "when" expression
+func ParseCondition(expr string) (*Condition, error) {
+	tokens, err := tokenizeCondition(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition %q: %w", expr, err)
+	}
+	p := &conditionParser{tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition %q: %w", expr, err)
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("invalid condition %q: unexpected %q", expr, p.peek().text)
+	}
+
+	return &Condition{expr: expr, root: root}, nil
+}
+
+// String returns the original expression
+func (c *Condition) String() string {
+	return c.expr
+}
+
+// Evaluate evaluates the condition against the given environment
+func (c *Condition) Evaluate(env map[string]any) bool {
+	return truthy(c.root.eval(env))
+}
+
+// EvaluateCondition parses and evaluates a "when" expression in one call.
+// An empty expression always evaluates to true.
+func EvaluateCondition(expr string, env map[string]any) (bool, error) {
+	if strings.TrimSpace(expr) == "" {
+		return true, nil
+	}
+	cond, err := ParseCondition(expr)
+	if err != nil {
+		return false, err
+	}
+	return cond.Evaluate(env), nil
+}
+
+// ConditionEnv builds the evaluation environment for a "when" expression
+// from the pipeline context and the steps executed so far
+func ConditionEnv(pctx *PipelineContext, steps []JobStep) map[string]any {
+	stepsEnv := make(map[string]any, len(steps))
+	for _, step := range steps {
+		entry := make(map[string]any, len(step.Details)+len(step.Outputs)+3)
+		for k, v := range step.Details {
+			entry[k] = v
+		}
+		// Published outputs take precedence over details of the same name
+		for k, v := range step.Outputs {
+			entry[k] = v
+		}
+		entry["status"] = step.Status
+		entry["critical"] = step.Critical
+		if step.Error != nil {
+			entry["error"] = *step.Error
+		}
+		stepsEnv[step.Name] = entry
+	}
+
+	return map[string]any{
+		"fields":      pctx.Fields,
+		"options":     pctx.Options,
+		"source":      pctx.Source,
+		"dryRun":      pctx.DryRun,
+		"businessId":  pctx.BusinessID,
+		"pipelineKey": pctx.PipelineKey,
+		"steps":       stepsEnv,
+	}
+}
+
+// conditionNode is a node of the parsed expression tree
+type conditionNode interface {
+	eval(env map[string]any) any
+}
+
+type literalNode struct {
+	value any
+}
+
+func (n literalNode) eval(env map[string]any) any {
+	return n.value
+}
+
+type pathNode struct {
+	parts []string
+}
+
+func (n pathNode) eval(env map[string]any) any {
+	var current any = env
+	if _, isRoot := env[n.parts[0]]; !isRoot {
+		// Bare names refer to form fields
+		current = env["fields"]
+	}
+	for _, part := range n.parts {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[part]
+	}
+	return current
+}
+
+type notNode struct {
+	operand conditionNode
+}
+
+func (n notNode) eval(env map[string]any) any {
+	return !truthy(n.operand.eval(env))
+}
+
+type logicalNode struct {
+	op          string
+	left, right conditionNode
+}
+
+func (n logicalNode) eval(env map[string]any) any {
+	if n.op == "&&" {
+		return truthy(n.left.eval(env)) && truthy(n.right.eval(env))
+	}
+	return truthy(n.left.eval(env)) || truthy(n.right.eval(env))
+}
+
+type compareNode struct {
+	op          string
+	left, right conditionNode
+}
+
+func (n compareNode) eval(env map[string]any) any {
+	return compareValues(n.op, n.left.eval(env), n.right.eval(env))
+}
+
+// compareValues compares two values, numerically when both sides look like numbers
+func compareValues(op string, left, right any) bool {
+	if lf, lok := toFloat(left); lok {
+		if rf, rok := toFloat(right); rok {
+			switch op {
+			case "==":
+				return lf == rf
+			case "!=":
+				return lf != rf
+			case ">":
+				return lf > rf
+			case ">=":
+				return lf >= rf
+			case "<":
+				return lf < rf
+			case "<=":
+				return lf <= rf
+			}
+		}
+	}
+
+	if lb, ok := left.(bool); ok {
+		if op == "==" {
+			return lb == truthy(right)
+		}
+		if op == "!=" {
+			return lb != truthy(right)
+		}
+		return false
+	}
+	if rb, ok := right.(bool); ok {
+		return compareValues(op, rb, left)
+	}
+
+	if left == nil || right == nil {
+		switch op {
+		case "==":
+			return left == nil && right == nil
+		case "!=":
+			return !(left == nil && right == nil)
+		}
+		return false
+	}
+
+	ls, rs := fmt.Sprint(left), fmt.Sprint(right)
+	switch op {
+	case "==":
+		return strings.EqualFold(ls, rs)
+	case "!=":
+		return !strings.EqualFold(ls, rs)
+	case ">":
+		return ls > rs
+	case ">=":
+		return ls >= rs
+	case "<":
+		return ls < rs
+	case "<=":
+		return ls <= rs
+	}
+	return false
+}
+
+// toFloat converts numeric values (and numeric strings from form payloads) to float64
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// truthy reports whether a value counts as true in a condition.
+// Form payloads often carry booleans as strings, so "false", "no", "0" and "off" are false.
+func truthy(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return t
+	case string:
+		switch strings.ToLower(strings.TrimSpace(t)) {
+		case "", "false", "no", "0", "off":
+			return false
+		}
+		return true
+	case map[string]any:
+		return len(t) > 0
+	case []any:
+		return len(t) > 0
+	}
+	if f, ok := toFloat(v); ok {
+		return f != 0
+	}
+	return true
+}
+
+type conditionToken struct {
+	kind string // "ident" | "number" | "string" | "op"
+	text string
+}
+
+func tokenizeCondition(expr string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, conditionToken{kind: "op", text: string(c)})
+			i++
+		case c == '&' || c == '|':
+			if i+1 >= len(expr) || expr[i+1] != c {
+				return nil, fmt.Errorf("unexpected %q at position %d", string(c), i)
+			}
+			tokens = append(tokens, conditionToken{kind: "op", text: expr[i : i+2]})
+			i += 2
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				tokens = append(tokens, conditionToken{kind: "op", text: expr[i : i+2]})
+				i += 2
+				continue
+			}
+			if c == '=' {
+				return nil, fmt.Errorf("use '==' for comparison at position %d", i)
+			}
+			tokens = append(tokens, conditionToken{kind: "op", text: string(c)})
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, conditionToken{kind: "string", text: expr[i+1 : i+1+end]})
+			i += end + 2
+		case c == '-' || c == '.' || (c >= '0' && c <= '9'):
+			start := i
+			i++
+			for i < len(expr) && (expr[i] == '.' || (expr[i] >= '0' && expr[i] <= '9')) {
+				i++
+			}
+			tokens = append(tokens, conditionToken{kind: "number", text: expr[start:i]})
+		case isIdentByte(c):
+			start := i
+			for i < len(expr) && (isIdentByte(expr[i]) || expr[i] == '.' || (expr[i] >= '0' && expr[i] <= '9')) {
+				i++
+			}
+			tokens = append(tokens, conditionToken{kind: "ident", text: expr[start:i]})
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", string(c), i)
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func (p *conditionParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *conditionParser) peek() conditionToken {
+	if p.done() {
+		return conditionToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) acceptOp(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != "op" {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOp("||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: "||", left: left, right: right}
+	}
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOp("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: "&&", left: left, right: right}
+	}
+}
+
+func (p *conditionParser) parseComparison() (conditionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.acceptOp("==", "!=", ">=", "<=", ">", "<")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return compareNode{op: op, left: left, right: right}, nil
+}
+
+func (p *conditionParser) parseUnary() (conditionNode, error) {
+	if _, ok := p.acceptOp("!"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *conditionParser) parsePrimary() (conditionNode, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+
+	switch tok.kind {
+	case "op":
+		if tok.text != "(" {
+			return nil, fmt.Errorf("unexpected %q", tok.text)
+		}
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.acceptOp(")"); !ok {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return inner, nil
+	case "string":
+		return literalNode{value: tok.text}, nil
+	case "number":
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", tok.text)
+		}
+		return literalNode{value: f}, nil
+	default:
+		switch tok.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null", "nil":
+			return literalNode{value: nil}, nil
+		}
+		parts := strings.Split(tok.text, ".")
+		for _, part := range parts {
+			if part == "" {
+				return nil, fmt.Errorf("invalid path %q", tok.text)
+			}
+		}
+		return pathNode{parts: parts}, nil
+	}
+}
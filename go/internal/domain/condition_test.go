@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	pctx := &PipelineContext{
+		BusinessID:  "stlpartyhelpers",
+		PipelineKey: "quote_and_deposit",
+		Source:      "form",
+		DryRun:      false,
+		Fields: map[string]any{
+			"numHelpers":   float64(4),
+			"payWithCheck": "true",
+			"eventType":    "Birthday Party",
+			"hours":        "3",
+		},
+		Options: map[string]any{
+			"sendEmail": false,
+		},
+	}
+	steps := []JobStep{
+		{Name: "normalize_input", Status: "ok", Details: map[string]any{"count": 3}},
+		{Name: "geocode_location", Status: "failed", Error: stringPtr("timeout")},
+	}
+	env := ConditionEnv(pctx, steps)
+
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{name: "empty expression", expr: "", want: true},
+		{name: "numeric comparison", expr: "fields.numHelpers >= 4", want: true},
+		{name: "bare field name", expr: "numHelpers > 4", want: false},
+		{name: "numeric string", expr: "fields.hours == 3", want: true},
+		{name: "string bool field", expr: "!fields.payWithCheck", want: false},
+		{name: "bool comparison", expr: "payWithCheck == true", want: true},
+		{name: "option flag", expr: "options.sendEmail", want: false},
+		{name: "source", expr: "source == 'form'", want: true},
+		{name: "dry run", expr: "!dryRun", want: true},
+		{name: "case insensitive string", expr: `eventType == "birthday party"`, want: true},
+		{name: "step status", expr: "steps.normalize_input.status == 'ok'", want: true},
+		{name: "step detail", expr: "steps.normalize_input.count == 3", want: true},
+		{name: "failed step", expr: "steps.geocode_location.status != 'failed'", want: false},
+		{name: "missing step", expr: "steps.create_invoice.status == 'ok'", want: false},
+		{name: "missing field", expr: "fields.unknown", want: false},
+		{name: "missing field is null", expr: "fields.unknown == null", want: true},
+		{name: "and", expr: "numHelpers >= 4 && source == 'form'", want: true},
+		{name: "or", expr: "numHelpers < 2 || dryRun", want: false},
+		{name: "parentheses", expr: "!(numHelpers < 2 || dryRun) && businessId == 'stlpartyhelpers'", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateCondition(tt.expr, env)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluateCondition(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCondition_Invalid(t *testing.T) {
+	tests := []string{
+		"numHelpers = 4",
+		"numHelpers >=",
+		"(numHelpers > 4",
+		"numHelpers > 4)",
+		"source == 'form",
+		"a & b",
+		"fields..x",
+		"numHelpers # 4",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := ParseCondition(expr); err == nil {
+				t.Errorf("expected error for %q", expr)
+			}
+		})
+	}
+}
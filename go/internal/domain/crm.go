@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CRM providers a business can keep its deals in
+const (
+	CRMProviderMonday  = "monday"
+	CRMProviderBuiltin = "builtin"
+)
+
+// CRMProviders lists the supported CRM providers
+var CRMProviders = []string{CRMProviderMonday, CRMProviderBuiltin}
+
+// CRMConfig selects the CRM create_crm_deal and update_crm_deal work with.
+// The built-in CRM keeps deals in the jobs database, on the boards listed
+// here.
+type CRMConfig struct {
+	// Provider is monday (the default) or builtin
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"`
+	// Boards are the boards of the built-in CRM by name
+	Boards map[string]CRMBoardConfig `yaml:"boards,omitempty" json:"boards,omitempty"`
+}
+
+// CRMBoardConfig is a board of the built-in CRM
+type CRMBoardConfig struct {
+	ID int64 `yaml:"id" json:"id"`
+	// StatusColumns are the deal fields that hold one of a set of labels,
+	// e.g. the stage of a deal
+	StatusColumns []CRMStatusColumn `yaml:"statusColumns,omitempty" json:"statusColumns,omitempty"`
+}
+
+// CRMStatusColumn is a deal field whose value is one of Labels
+type CRMStatusColumn struct {
+	Field  string   `yaml:"field" json:"field"`
+	Labels []string `yaml:"labels" json:"labels"`
+	// Default is set on new deals without a value; empty leaves it unset
+	Default string `yaml:"default,omitempty" json:"default,omitempty"`
+}
+
+// ProviderName returns the configured provider, monday when unset
+func (c CRMConfig) ProviderName() string {
+	if c.Provider == "" {
+		return CRMProviderMonday
+	}
+	return c.Provider
+}
+
+// Builtin reports whether the business uses the built-in CRM
+func (c CRMConfig) Builtin() bool {
+	return c.Provider == CRMProviderBuiltin
+}
+
+// Board returns the built-in board with boardID and its name
+func (c CRMConfig) Board(boardID int64) (string, CRMBoardConfig, bool) {
+	for name, board := range c.Boards {
+		if board.ID == boardID {
+			return name, board, true
+		}
+	}
+	return "", CRMBoardConfig{}, false
+}
+
+// CRMBoardID returns the ID of the board configured as name for the business
+// CRM: in crm.boards for the built-in CRM, in monday.boards otherwise
+func (b *BusinessConfig) CRMBoardID(name string) (int64, bool) {
+	if b.CRM.Builtin() {
+		board, ok := b.CRM.Boards[name]
+		return board.ID, ok
+	}
+	id, ok := b.Monday.Boards[name]
+	return id, ok
+}
+
+// StatusColumn returns the status column of field, if any
+func (c CRMBoardConfig) StatusColumn(field string) (CRMStatusColumn, bool) {
+	for _, column := range c.StatusColumns {
+		if column.Field == field {
+			return column, true
+		}
+	}
+	return CRMStatusColumn{}, false
+}
+
+// Label returns the label matching value case-insensitively
+func (c CRMStatusColumn) Label(value string) (string, bool) {
+	for _, label := range c.Labels {
+		if strings.EqualFold(label, strings.TrimSpace(value)) {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// CRMBoard is a board of the built-in CRM as stored next to its deals
+type CRMBoard struct {
+	BusinessID    string            `json:"businessId"`
+	ID            int64             `json:"id"`
+	Name          string            `json:"name"`
+	StatusColumns []CRMStatusColumn `json:"statusColumns"`
+	UpdatedAt     time.Time         `json:"updatedAt"`
+}
+
+// CRMContact is the person deals of the built-in CRM are made with
+type CRMContact struct {
+	ID         string    `json:"id"`
+	BusinessID string    `json:"businessId"`
+	Name       string    `json:"name,omitempty"`
+	Email      string    `json:"email,omitempty"`
+	Phone      string    `json:"phone,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// CRMContactID returns the ID of a business contact, derived from the email,
+// else the phone number, so that every deal with a person shares it. It is
+// empty when neither is known.
+func CRMContactID(businessID, email, phone string) string {
+	key := strings.ToLower(strings.TrimSpace(email))
+	if key == "" {
+		key = strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, phone)
+	}
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(businessID + "\x00" + key))
+	return "ct_" + hex.EncodeToString(sum[:8])
+}
+
+// CRMDeal is a deal of the built-in CRM. Fields hold the values written by
+// create_crm_deal and update_crm_deal, e.g. status, email or totalCost.
+type CRMDeal struct {
+	ID         int64          `json:"id"`
+	BusinessID string         `json:"businessId"`
+	BoardID    int64          `json:"boardId"`
+	Name       string         `json:"name"`
+	ContactID  string         `json:"contactId,omitempty"`
+	Fields     map[string]any `json:"fields"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+// Snapshot returns a copy of the deal that does not share its fields map
+func (d *CRMDeal) Snapshot() *CRMDeal {
+	snapshot := *d
+	if d.Fields != nil {
+		snapshot.Fields = make(map[string]any, len(d.Fields))
+		for k, v := range d.Fields {
+			snapshot.Fields[k] = v
+		}
+	}
+	return &snapshot
+}
+
+// SearchText returns the lowercase text deal searches match against: the
+// name and the field values, in field order
+func (d *CRMDeal) SearchText() string {
+	keys := make([]string, 0, len(d.Fields))
+	for k := range d.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys)+1)
+	parts = append(parts, d.Name)
+	for _, k := range keys {
+		if d.Fields[k] != nil {
+			parts = append(parts, fmt.Sprint(d.Fields[k]))
+		}
+	}
+	return strings.ToLower(strings.Join(parts, "\n"))
+}
@@ -13,6 +13,14 @@ type Job struct {
 	Result      map[string]any         `json:"result"`
 	CreatedAt   time.Time              `json:"createdAt"`
 	UpdatedAt   time.Time              `json:"updatedAt"`
+	// Source, DryRun, Options and Resource are the rest of the request the job
+	// was started with, kept so it can be resumed or replayed
+	Source   string           `json:"source,omitempty"`
+	DryRun   bool             `json:"dryRun,omitempty"`
+	Options  map[string]any   `json:"options,omitempty"`
+	Resource *ResourceContext `json:"resource,omitempty"`
+	// ReplayOf is the ID of the job this one replays
+	ReplayOf string `json:"replayOf,omitempty"`
 }
 
 // JobStep represents a single step in a job
@@ -22,5 +30,35 @@ type JobStep struct {
 	Critical bool           `json:"critical"`
 	Error    *string        `json:"error,omitempty"`
 	Details  map[string]any `json:"details,omitempty"`
+	// Permanent marks a failure that must not be retried
+	Permanent bool `json:"permanent,omitempty"`
+	// Outputs are named values later actions can reference from their config,
+	// e.g. {{ steps.create_deposit_invoice.hostedInvoiceUrl }}
+	Outputs map[string]any `json:"outputs,omitempty"`
+	// Config is the action config after template references were resolved
+	Config map[string]any `json:"config,omitempty"`
+	// SubJob is the nested job run by call_pipeline
+	SubJob *Job `json:"subJob,omitempty"`
+	// StartedAt and FinishedAt bound the step, including every retry
+	StartedAt  time.Time `json:"startedAt,omitzero"`
+	FinishedAt time.Time `json:"finishedAt,omitzero"`
+	DurationMs int64     `json:"durationMs"`
+	// TimeoutMs is the per-attempt timeout of the action, if it has one
+	TimeoutMs int64 `json:"timeoutMs,omitempty"`
+	// SideEffects lists what a planned action would do in a dry run
+	SideEffects []SideEffect `json:"sideEffects,omitempty"`
 }
 
+// Snapshot returns a copy of the job that is safe to hand to other goroutines
+// while the original keeps running
+func (j *Job) Snapshot() *Job {
+	snapshot := *j
+	snapshot.Steps = append([]JobStep(nil), j.Steps...)
+	if j.Result != nil {
+		snapshot.Result = make(map[string]any, len(j.Result))
+		for k, v := range j.Result {
+			snapshot.Result[k] = v
+		}
+	}
+	return &snapshot
+}
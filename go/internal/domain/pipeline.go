@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // PipelineDefinition represents a pipeline configuration
 type PipelineDefinition struct {
 	Key         string             `yaml:"key" json:"key"`
@@ -12,6 +14,32 @@ type ActionDefinition struct {
 	Name     string         `yaml:"name" json:"name"`
 	Critical bool           `yaml:"critical" json:"critical"`
 	Config   map[string]any `yaml:"config" json:"config"`
+	// When is an optional condition; the action is skipped when it evaluates to false.
+	// See Condition for the expression syntax.
+	When string `yaml:"when,omitempty" json:"when,omitempty"`
+	// Retry is an optional retry policy for transient failures
+	Retry *RetryPolicy `yaml:"retry,omitempty" json:"retry,omitempty"`
+	// Timeout bounds each attempt of the action (e.g. "10s"); the action runs
+	// under a context that expires after it
+	Timeout time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	// Compensate is an optional action that undoes this one (e.g. voiding an
+	// invoice) when a later critical step fails the job
+	Compensate *ActionDefinition `yaml:"compensate,omitempty" json:"compensate,omitempty"`
+	// Parallel turns this entry into a group of actions run concurrently;
+	// the other fields are ignored when it is set
+	Parallel *ParallelGroup `yaml:"parallel,omitempty" json:"parallel,omitempty"`
+	// Include replaces this entry with the actions of a shared fragment from
+	// config/fragments when the pipeline is loaded
+	Include string `yaml:"include,omitempty" json:"include,omitempty"`
+}
+
+// ParallelGroup is a set of independent actions run concurrently. Their steps are
+// recorded in definition order once the whole group has finished. Actions in a
+// group must not modify the PipelineContext; they share data through outputs.
+type ParallelGroup struct {
+	// MaxConcurrency limits how many actions run at once (0 means no limit)
+	MaxConcurrency int                `yaml:"maxConcurrency,omitempty" json:"maxConcurrency,omitempty"`
+	Actions        []ActionDefinition `yaml:"actions" json:"actions"`
 }
 
 // PipelineContext holds context for pipeline execution
@@ -24,6 +52,10 @@ type PipelineContext struct {
 	Options     map[string]any
 	Resource    *ResourceContext
 	RequestID   string
+	// Business is the configuration of the business the pipeline runs for
+	Business *BusinessConfig
+	// ReplayOf is the ID of the job being replayed, if any
+	ReplayOf string
 }
 
 // ResourceContext holds information about the triggering resource
@@ -43,5 +75,10 @@ type PipelineResult struct {
 	Steps       []JobStep  `json:"steps"`
 	JobID       string     `json:"jobId,omitempty"`
 	Error       *string    `json:"error,omitempty"`
+	// Status is set to "pending" when the pipeline was queued for asynchronous execution
+	Status string `json:"status,omitempty"`
+	// Plan previews the side effects of a dry run, including those of nested
+	// pipelines, in execution order
+	Plan []PlannedSideEffect `json:"plan,omitzero"`
 }
 
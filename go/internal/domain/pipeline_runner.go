@@ -3,6 +3,9 @@ package domain
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 )
 
 // Action is the interface that all pipeline actions must implement
@@ -11,20 +14,150 @@ type Action interface {
 	Execute(ctx context.Context, pctx *PipelineContext) JobStep
 }
 
+// SideEffectFreeAction is implemented by actions that only compute values from
+// the pipeline context, such as normalize_input. Resumed jobs run them again
+// instead of reusing their recorded step, which restores any changes they make
+// to the context.
+type SideEffectFreeAction interface {
+	SideEffectFree() bool
+}
+
+// isSideEffectFree reports whether the action declares itself side-effect free
+func isSideEffectFree(action Action) bool {
+	pure, ok := action.(SideEffectFreeAction)
+	return ok && pure.SideEffectFree()
+}
+
+// JobObserver is called with a snapshot of the job whenever its progress changes
+type JobObserver func(job *Job)
+
+// StepListener is notified of every step the runner executes, including steps
+// of parallel groups, compensations and nested pipelines. Steps reused from a
+// resumed job are not reported. It must be safe for concurrent use.
+type StepListener interface {
+	StepFinished(ctx context.Context, pctx *PipelineContext, step JobStep)
+}
+
 // PipelineRunner executes pipelines by running actions in sequence
 type PipelineRunner struct {
 	actions map[string]Action
+	// loader loads the pipelines run by call_pipeline
+	loader PipelineLoader
+	// listener is told about every executed step, e.g. to report slow ones
+	listener StepListener
 }
 
-// NewPipelineRunner creates a new pipeline runner with registered actions
+// NewPipelineRunner creates a new pipeline runner with registered actions. The
+// built-in call_pipeline action is registered too unless actions overrides it;
+// it needs SetPipelineLoader before use.
 func NewPipelineRunner(actions map[string]Action) *PipelineRunner {
-	return &PipelineRunner{
-		actions: actions,
+	pr := &PipelineRunner{
+		actions: make(map[string]Action, len(actions)+1),
+	}
+	pr.actions[CallPipelineActionName] = &callPipelineAction{runner: pr}
+	for name, action := range actions {
+		pr.actions[name] = action
+	}
+	return pr
+}
+
+// SetStepListener registers a listener notified after every executed step
+func (pr *PipelineRunner) SetStepListener(listener StepListener) {
+	pr.listener = listener
+}
+
+// ActionSchema returns the config schema of a registered action. ok is false
+// when the action is not registered; an action that does not describe itself
+// has an empty schema.
+func (pr *PipelineRunner) ActionSchema(name string) (schema ActionSchema, ok bool) {
+	action, exists := pr.actions[name]
+	if !exists {
+		return ActionSchema{}, false
+	}
+	if described, isDescribed := action.(DescribedAction); isDescribed {
+		return described.Schema(), true
+	}
+	return ActionSchema{}, true
+}
+
+// ValidatePipeline checks a pipeline definition before it runs: every action,
+// including those in parallel groups and compensations, must be registered,
+// its config must match the action's schema and its condition must parse. It
+// returns a *ValidationError listing every problem.
+func (pr *PipelineRunner) ValidatePipeline(pipeline *PipelineDefinition) error {
+	var problems []string
+	var check func(where string, def ActionDefinition)
+	check = func(where string, def ActionDefinition) {
+		if def.Parallel != nil {
+			if len(def.Parallel.Actions) == 0 {
+				problems = append(problems, where+": parallel group has no actions")
+			}
+			for i, inner := range def.Parallel.Actions {
+				if inner.Parallel != nil {
+					problems = append(problems, fmt.Sprintf("%s.parallel[%d]: nested parallel groups are not supported", where, i))
+					continue
+				}
+				check(fmt.Sprintf("%s.parallel[%d]", where, i), inner)
+			}
+			return
+		}
+
+		if def.Include != "" {
+			problems = append(problems, fmt.Sprintf("%s: include '%s' was not expanded", where, def.Include))
+			return
+		}
+		if def.Name == "" {
+			problems = append(problems, where+": action name is required")
+			return
+		}
+		where = fmt.Sprintf("%s (%s)", where, def.Name)
+		action, exists := pr.actions[def.Name]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("%s: action '%s' is not registered", where, def.Name))
+		} else if described, ok := action.(DescribedAction); ok {
+			for _, problem := range described.Schema().Validate(def.Config) {
+				problems = append(problems, where+": "+problem)
+			}
+		}
+		if strings.TrimSpace(def.When) != "" {
+			if _, err := ParseCondition(def.When); err != nil {
+				problems = append(problems, where+": "+err.Error())
+			}
+		}
+		if def.Compensate != nil {
+			check(where+".compensate", *def.Compensate)
+		}
+	}
+
+	for i, def := range pipeline.Actions {
+		check(fmt.Sprintf("actions[%d]", i), def)
 	}
+	if len(problems) > 0 {
+		return &ValidationError{Source: "pipeline " + pipeline.Key, Problems: problems}
+	}
+	return nil
 }
 
 // Run executes a pipeline definition with the given context
 func (pr *PipelineRunner) Run(ctx context.Context, pipeline *PipelineDefinition, pctx *PipelineContext) (*PipelineResult, *Job) {
+	return pr.RunObserved(ctx, pipeline, pctx, nil)
+}
+
+// RunObserved executes a pipeline and reports a job snapshot to observe after every step
+func (pr *PipelineRunner) RunObserved(ctx context.Context, pipeline *PipelineDefinition, pctx *PipelineContext, observe JobObserver) (*PipelineResult, *Job) {
+	return pr.run(ctx, pipeline, pctx, nil, observe)
+}
+
+// ResumeObserved continues a failed job. Actions whose step succeeded in previous
+// are not executed again: the recorded step, including its outputs, is reused and
+// marked with details.resumed. Side-effect free actions always run again. A failed
+// call_pipeline step resumes its nested job the same way. The resumed job keeps
+// the ID and creation time of previous.
+func (pr *PipelineRunner) ResumeObserved(ctx context.Context, pipeline *PipelineDefinition, pctx *PipelineContext, previous *Job, observe JobObserver) (*PipelineResult, *Job) {
+	return pr.run(ctx, pipeline, pctx, previous, observe)
+}
+
+func (pr *PipelineRunner) run(ctx context.Context, pipeline *PipelineDefinition, pctx *PipelineContext, previous *Job, observe JobObserver) (*PipelineResult, *Job) {
 	job := &Job{
 		ID:          pctx.RequestID,
 		BusinessID:  pctx.BusinessID,
@@ -33,7 +166,18 @@ func (pr *PipelineRunner) Run(ctx context.Context, pipeline *PipelineDefinition,
 		Steps:       []JobStep{},
 		Input:       pctx.Fields,
 		Result:      make(map[string]any),
+		CreatedAt:   time.Now(),
+		Source:      pctx.Source,
+		DryRun:      pctx.DryRun,
+		Options:     pctx.Options,
+		Resource:    pctx.Resource,
+		ReplayOf:    pctx.ReplayOf,
 	}
+	if previous != nil {
+		job.ID = previous.ID
+		job.CreatedAt = previous.CreatedAt
+	}
+	reusable := newReusableSteps(previous)
 
 	result := &PipelineResult{
 		PipelineKey: pipeline.Key,
@@ -43,53 +187,286 @@ func (pr *PipelineRunner) Run(ctx context.Context, pipeline *PipelineDefinition,
 		JobID:       job.ID,
 	}
 
-	// Execute each action in sequence
-	for _, actionDef := range pipeline.Actions {
-		action, exists := pr.actions[actionDef.Name]
-		if !exists {
-			step := JobStep{
-				Name:     actionDef.Name,
-				Status:   "failed",
-				Critical: actionDef.Critical,
-				Error:    stringPtr(fmt.Sprintf("action '%s' not found", actionDef.Name)),
-			}
-			job.Steps = append(job.Steps, step)
-			result.Steps = append(result.Steps, step)
-			
-			if actionDef.Critical {
-				result.Success = false
-				result.Error = stringPtr(fmt.Sprintf("critical action '%s' failed: action not found", actionDef.Name))
-				job.Status = "failed"
-				return result, job
-			}
-			continue
-		}
-
-		// Execute the action
-		step := action.Execute(ctx, pctx)
+	// appendStep records a step on both the job and the result
+	appendStep := func(step JobStep) {
 		job.Steps = append(job.Steps, step)
 		result.Steps = append(result.Steps, step)
+		if observe != nil {
+			observe(job.Snapshot())
+		}
+	}
+
+	if observe != nil {
+		observe(job.Snapshot())
+	}
+
+	// Actions that succeeded and can be undone, in execution order
+	var compensable []ActionDefinition
 
-		// Check if step failed
-		if step.Status == "failed" {
-			if step.Critical {
+	// Execute each action (or parallel group) in sequence
+	for _, actionDef := range pipeline.Actions {
+		env := ConditionEnv(pctx, job.Steps)
+
+		var outcomes []actionOutcome
+		defs := []ActionDefinition{actionDef}
+		if actionDef.Parallel != nil {
+			defs = actionDef.Parallel.Actions
+			outcomes = pr.runParallel(ctx, actionDef.Parallel, pctx, env, reusable.take(defs))
+		} else {
+			outcomes = []actionOutcome{pr.runAction(ctx, actionDef, pctx, env, reusable.take(defs)[0])}
+		}
+
+		// Steps are recorded in definition order; a critical failure anywhere in
+		// a parallel group stops the pipeline only after the whole group finished
+		for i, outcome := range outcomes {
+			appendStep(outcome.step)
+			if outcome.step.Status == "ok" && defs[i].Compensate != nil {
+				compensable = append(compensable, defs[i])
+			}
+		}
+		for _, outcome := range outcomes {
+			if outcome.abort != nil {
 				result.Success = false
-				result.Error = step.Error
+				result.Error = outcome.abort
 				job.Status = "failed"
+				pr.compensate(ctx, compensable, pctx, job, appendStep)
+				if pctx.DryRun {
+					result.Plan = collectPlan(job)
+				}
 				return result, job
 			}
-			// Non-critical failure, continue
 		}
 	}
 
 	// All steps completed successfully
 	result.Success = true
 	job.Status = "completed"
+	if pctx.DryRun {
+		result.Plan = collectPlan(job)
+	}
 	return result, job
 }
 
+// actionOutcome is the step produced by one action definition
+type actionOutcome struct {
+	step JobStep
+	// abort holds the pipeline error when the step is a critical failure
+	abort *string
+	// reused is set when the step was taken from the job being resumed
+	reused bool
+}
+
+// runAction evaluates, configures and executes a single action definition and
+// records when it started and finished. env is the condition environment built
+// from the steps recorded before it. reuse is the step recorded for this action
+// by the job being resumed, if any; it keeps its original timing.
+func (pr *PipelineRunner) runAction(ctx context.Context, actionDef ActionDefinition, pctx *PipelineContext, env map[string]any, reuse *JobStep) actionOutcome {
+	start := time.Now()
+	outcome := pr.executeAction(ctx, actionDef, pctx, env, reuse)
+	if outcome.reused {
+		return outcome
+	}
+
+	finish := time.Now()
+	outcome.step.StartedAt = start
+	outcome.step.FinishedAt = finish
+	outcome.step.DurationMs = finish.Sub(start).Milliseconds()
+	outcome.step.TimeoutMs = actionDef.Timeout.Milliseconds()
+	if pr.listener != nil {
+		pr.listener.StepFinished(ctx, pctx, outcome.step)
+	}
+	return outcome
+}
+
+// executeAction runs an action definition for runAction
+func (pr *PipelineRunner) executeAction(ctx context.Context, actionDef ActionDefinition, pctx *PipelineContext, env map[string]any, reuse *JobStep) actionOutcome {
+	// failBeforeRun records a failure to evaluate the action definition itself
+	failBeforeRun := func(err error) actionOutcome {
+		outcome := actionOutcome{step: JobStep{
+			Name:     actionDef.Name,
+			Status:   "failed",
+			Critical: actionDef.Critical,
+			Error:    stringPtr(err.Error()),
+		}}
+		if actionDef.Critical {
+			outcome.abort = stringPtr(fmt.Sprintf("critical action '%s' failed: %v", actionDef.Name, err))
+		}
+		return outcome
+	}
+
+	if actionDef.Parallel != nil {
+		return failBeforeRun(fmt.Errorf("nested parallel groups are not supported"))
+	}
+
+	action, exists := pr.actions[actionDef.Name]
+	if !exists {
+		outcome := actionOutcome{step: JobStep{
+			Name:     actionDef.Name,
+			Status:   "failed",
+			Critical: actionDef.Critical,
+			Error:    stringPtr(fmt.Sprintf("action '%s' not found", actionDef.Name)),
+		}}
+		if actionDef.Critical {
+			outcome.abort = stringPtr(fmt.Sprintf("critical action '%s' failed: action not found", actionDef.Name))
+		}
+		return outcome
+	}
+
+	if reuse != nil && reuse.Status != "ok" {
+		// A failed call_pipeline resumes its sub-job instead of starting over
+		ctx = withResumedSubJob(ctx, reuse.SubJob)
+		reuse = nil
+	}
+	if reuse != nil && !isSideEffectFree(action) {
+		step := *reuse
+		details := make(map[string]any, len(step.Details)+1)
+		for k, v := range step.Details {
+			details[k] = v
+		}
+		details["resumed"] = true
+		step.Details = details
+		return actionOutcome{step: step, reused: true}
+	}
+
+	// Evaluate the action's condition against the steps executed so far
+	shouldRun, err := EvaluateCondition(actionDef.When, env)
+	if err != nil {
+		return failBeforeRun(err)
+	}
+	if !shouldRun {
+		return actionOutcome{step: JobStep{
+			Name:     actionDef.Name,
+			Status:   "skipped",
+			Critical: actionDef.Critical,
+			Details: map[string]any{
+				"reason": fmt.Sprintf("condition not met: %s", actionDef.When),
+				"when":   actionDef.When,
+			},
+		}}
+	}
+
+	// Resolve {{ ... }} references to fields and earlier step outputs
+	config, err := ResolveConfig(actionDef.Config, env)
+	if err != nil {
+		return failBeforeRun(fmt.Errorf("invalid config: %w", err))
+	}
+
+	// A dry run plans actions with side effects instead of executing them
+	if planned, ok := action.(PlannedAction); ok && pctx.DryRun {
+		action = planningAction{PlannedAction: planned, name: action.Name()}
+	}
+
+	// Execute the action, retrying transient failures per the action's policy
+	step := executeWithRetry(WithActionConfig(ctx, config), action, actionDef.Retry, actionDef.Timeout, pctx)
+	if len(config) > 0 {
+		step.Config = config
+	}
+	// Whether a failure stops the pipeline is decided by the definition, not the action
+	step.Critical = actionDef.Critical
+
+	outcome := actionOutcome{step: step}
+	if step.Status == "failed" && step.Critical {
+		outcome.abort = step.Error
+		if outcome.abort == nil {
+			outcome.abort = stringPtr(fmt.Sprintf("critical action '%s' failed", actionDef.Name))
+		}
+	}
+	return outcome
+}
+
+// compensate runs the compensation of every completed action in reverse order,
+// recording each as its own step. It runs even when ctx was cancelled so a timed
+// out job still cleans up; failed compensations are recorded and do not stop the rest.
+func (pr *PipelineRunner) compensate(ctx context.Context, completed []ActionDefinition, pctx *PipelineContext, job *Job, appendStep func(JobStep)) {
+	ctx = context.WithoutCancel(ctx)
+	for i := len(completed) - 1; i >= 0; i-- {
+		outcome := pr.runAction(ctx, *completed[i].Compensate, pctx, ConditionEnv(pctx, job.Steps), nil)
+
+		step := outcome.step
+		details := make(map[string]any, len(step.Details)+1)
+		for k, v := range step.Details {
+			details[k] = v
+		}
+		details["compensates"] = completed[i].Name
+		step.Details = details
+		appendStep(step)
+	}
+}
+
+// runParallel runs the actions of a group concurrently, at most MaxConcurrency at
+// a time, and returns their outcomes in definition order. Every action sees the
+// same env, so actions in a group cannot reference each other's outputs.
+func (pr *PipelineRunner) runParallel(ctx context.Context, group *ParallelGroup, pctx *PipelineContext, env map[string]any, reuse []*JobStep) []actionOutcome {
+	limit := group.MaxConcurrency
+	if limit <= 0 || limit > len(group.Actions) {
+		limit = len(group.Actions)
+	}
+
+	outcomes := make([]actionOutcome, len(group.Actions))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, actionDef := range group.Actions {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			outcomes[i] = pr.runAction(ctx, actionDef, pctx, env, reuse[i])
+		}()
+	}
+	wg.Wait()
+
+	return outcomes
+}
+
+// reusableSteps holds the succeeded steps of a job being resumed, queued per
+// action name so an action that appears twice reuses its steps in order.
+// Failed call_pipeline steps are queued too so their sub-job can be resumed.
+// Steps whose effects were compensated are left out so they run again.
+type reusableSteps map[string][]JobStep
+
+func newReusableSteps(previous *Job) reusableSteps {
+	if previous == nil {
+		return nil
+	}
+	compensated := make(map[string]bool)
+	for _, step := range previous.Steps {
+		if name, ok := step.Details["compensates"].(string); ok {
+			compensated[name] = true
+		}
+	}
+	steps := make(reusableSteps)
+	for _, step := range previous.Steps {
+		if step.Status != "ok" && step.SubJob == nil {
+			continue
+		}
+		// Compensations are never reused; they only ran because the job failed
+		if _, isCompensation := step.Details["compensates"]; isCompensation {
+			continue
+		}
+		if compensated[step.Name] {
+			continue
+		}
+		steps[step.Name] = append(steps[step.Name], step)
+	}
+	return steps
+}
+
+// take returns the reusable step for each definition (nil when there is none)
+func (r reusableSteps) take(defs []ActionDefinition) []*JobStep {
+	taken := make([]*JobStep, len(defs))
+	for i, def := range defs {
+		queue := r[def.Name]
+		if len(queue) == 0 {
+			continue
+		}
+		taken[i] = &queue[0]
+		r[def.Name] = queue[1:]
+	}
+	return taken
+}
+
 // stringPtr returns a pointer to the given string
 func stringPtr(s string) *string {
 	return &s
 }
-
@@ -0,0 +1,936 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testAction is a configurable action used by runner tests
+type testAction struct {
+	name  string
+	calls int
+	run   func(ctx context.Context, pctx *PipelineContext) JobStep
+}
+
+func (a *testAction) Name() string {
+	return a.name
+}
+
+func (a *testAction) Execute(ctx context.Context, pctx *PipelineContext) JobStep {
+	a.calls++
+	if a.run != nil {
+		return a.run(ctx, pctx)
+	}
+	return JobStep{Name: a.name, Status: "ok"}
+}
+
+func newTestRunner(actions ...*testAction) *PipelineRunner {
+	registry := make(map[string]Action, len(actions))
+	for _, a := range actions {
+		registry[a.name] = a
+	}
+	return NewPipelineRunner(registry)
+}
+
+func TestPipelineRunner_When(t *testing.T) {
+	normalize := &testAction{name: "normalize_input"}
+	slack := &testAction{name: "send_slack_notification"}
+	invoice := &testAction{name: "create_deposit_invoice"}
+	runner := newTestRunner(normalize, slack, invoice)
+
+	pipeline := &PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []ActionDefinition{
+			{Name: "normalize_input", Critical: true},
+			{Name: "send_slack_notification", When: "numHelpers >= 4 && steps.normalize_input.status == 'ok'"},
+			{Name: "create_deposit_invoice", Critical: true, When: "!fields.payWithCheck"},
+		},
+	}
+	pctx := &PipelineContext{
+		RequestID: "job-1",
+		Fields:    map[string]any{"numHelpers": 5, "payWithCheck": true},
+	}
+
+	result, job := runner.Run(context.Background(), pipeline, pctx)
+
+	if !result.Success {
+		t.Fatalf("expected success, got error %v", result.Error)
+	}
+	if job.Status != "completed" {
+		t.Errorf("expected job status completed, got %s", job.Status)
+	}
+	if slack.calls != 1 {
+		t.Errorf("expected slack action to run once, got %d", slack.calls)
+	}
+	if invoice.calls != 0 {
+		t.Errorf("expected invoice action to be skipped, got %d calls", invoice.calls)
+	}
+
+	skipped := result.Steps[2]
+	if skipped.Status != "skipped" {
+		t.Fatalf("expected skipped step, got %s", skipped.Status)
+	}
+	if skipped.Details["reason"] != "condition not met: !fields.payWithCheck" {
+		t.Errorf("unexpected skip reason: %v", skipped.Details["reason"])
+	}
+}
+
+func TestPipelineRunner_InvalidWhen(t *testing.T) {
+	action := &testAction{name: "send_slack_notification"}
+	runner := newTestRunner(action)
+
+	pipeline := &PipelineDefinition{
+		Key: "test",
+		Actions: []ActionDefinition{
+			{Name: "send_slack_notification", Critical: true, When: "numHelpers = 4"},
+		},
+	}
+
+	result, job := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-2"})
+
+	if result.Success {
+		t.Fatal("expected failure for invalid condition on critical action")
+	}
+	if job.Status != "failed" {
+		t.Errorf("expected job status failed, got %s", job.Status)
+	}
+	if action.calls != 0 {
+		t.Errorf("expected action not to run, got %d calls", action.calls)
+	}
+}
+
+func TestPipelineRunner_StepOutputs(t *testing.T) {
+	invoice := &testAction{name: "create_deposit_invoice", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		return JobStep{
+			Name:    "create_deposit_invoice",
+			Status:  "ok",
+			Outputs: map[string]any{"hostedInvoiceUrl": "https://invoice.stripe.com/i/abc", "amountCents": 15000},
+		}
+	}}
+	var received map[string]any
+	email := &testAction{name: "send_deposit_email", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		received = ActionConfig(ctx)
+		return JobStep{Name: "send_deposit_email", Status: "ok"}
+	}}
+	runner := newTestRunner(invoice, email)
+
+	pipeline := &PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []ActionDefinition{
+			{Name: "create_deposit_invoice", Critical: true},
+			{Name: "send_deposit_email", Critical: true, Config: map[string]any{
+				"invoiceUrl": "{{ steps.create_deposit_invoice.hostedInvoiceUrl }}",
+				"amount":     "{{ steps.create_deposit_invoice.amountCents }}",
+				"subject":    "Deposit for {{ fields.email }}",
+			}},
+		},
+	}
+	pctx := &PipelineContext{RequestID: "job-1", Fields: map[string]any{"email": "jane@example.com"}}
+
+	result, job := runner.Run(context.Background(), pipeline, pctx)
+	if !result.Success {
+		t.Fatalf("expected success, got error %v", *result.Error)
+	}
+
+	want := map[string]any{
+		"invoiceUrl": "https://invoice.stripe.com/i/abc",
+		"amount":     15000,
+		"subject":    "Deposit for jane@example.com",
+	}
+	for key, value := range want {
+		if received[key] != value {
+			t.Errorf("expected config %s=%v, got %v", key, value, received[key])
+		}
+		if job.Steps[1].Config[key] != value {
+			t.Errorf("expected resolved config %s=%v stored on step, got %v", key, value, job.Steps[1].Config[key])
+		}
+	}
+	if job.Steps[0].Config != nil {
+		t.Errorf("expected no config on step without config, got %v", job.Steps[0].Config)
+	}
+}
+
+func TestPipelineRunner_UnresolvedConfig(t *testing.T) {
+	email := &testAction{name: "send_deposit_email"}
+	runner := newTestRunner(email)
+
+	pipeline := &PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []ActionDefinition{
+			{Name: "send_deposit_email", Critical: true, Config: map[string]any{
+				"invoiceUrl": "{{ steps.create_deposit_invoice.hostedInvoiceUrl }}",
+			}},
+		},
+	}
+
+	result, job := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-1"})
+	if result.Success || job.Status != "failed" {
+		t.Fatalf("expected critical failure, got success=%v status=%s", result.Success, job.Status)
+	}
+	if email.calls != 0 {
+		t.Errorf("expected action not to run, ran %d times", email.calls)
+	}
+}
+
+func TestPipelineRunner_Parallel(t *testing.T) {
+	// Every action waits until all three have started, so the group only
+	// finishes if they really run concurrently
+	var started sync.WaitGroup
+	started.Add(3)
+	waitForSiblings := func(name string) *testAction {
+		return &testAction{name: name, run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+			started.Done()
+			done := make(chan struct{})
+			go func() { started.Wait(); close(done) }()
+			select {
+			case <-done:
+				return JobStep{Name: name, Status: "ok", Outputs: map[string]any{"by": name}}
+			case <-time.After(2 * time.Second):
+				return JobStep{Name: name, Status: "failed", Error: stringPtr("siblings did not start")}
+			}
+		}}
+	}
+	runner := newTestRunner(
+		&testAction{name: "normalize_input"},
+		waitForSiblings("geocode_location"),
+		waitForSiblings("create_calendar_event"),
+		waitForSiblings("create_crm_deal"),
+		&testAction{name: "send_quote_email"},
+	)
+
+	pipeline := &PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []ActionDefinition{
+			{Name: "normalize_input", Critical: true},
+			{Parallel: &ParallelGroup{Actions: []ActionDefinition{
+				{Name: "geocode_location"},
+				{Name: "create_calendar_event"},
+				{Name: "create_crm_deal"},
+			}}},
+			{Name: "send_quote_email", When: "steps.create_crm_deal.by == 'create_crm_deal'"},
+		},
+	}
+
+	result, job := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-1"})
+	if !result.Success {
+		t.Fatalf("expected success, got error %v", *result.Error)
+	}
+
+	want := []string{"normalize_input", "geocode_location", "create_calendar_event", "create_crm_deal", "send_quote_email"}
+	if len(job.Steps) != len(want) {
+		t.Fatalf("expected %d steps, got %+v", len(want), job.Steps)
+	}
+	for i, name := range want {
+		if job.Steps[i].Name != name || job.Steps[i].Status != "ok" {
+			t.Errorf("step %d: expected %s ok, got %s %s", i, name, job.Steps[i].Name, job.Steps[i].Status)
+		}
+	}
+}
+
+func TestPipelineRunner_ParallelConcurrencyLimit(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	var actions []*testAction
+	var group []ActionDefinition
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		actions = append(actions, &testAction{name: name, run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+			n := running.Add(1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return JobStep{Name: name, Status: "ok"}
+		}})
+		group = append(group, ActionDefinition{Name: name})
+	}
+	runner := newTestRunner(actions...)
+
+	pipeline := &PipelineDefinition{
+		Key:     "test",
+		Actions: []ActionDefinition{{Parallel: &ParallelGroup{MaxConcurrency: 2, Actions: group}}},
+	}
+
+	result, job := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-1"})
+	if !result.Success || len(job.Steps) != 5 {
+		t.Fatalf("expected 5 successful steps, got success=%v steps=%d", result.Success, len(job.Steps))
+	}
+	if got := maxRunning.Load(); got > 2 {
+		t.Errorf("expected at most 2 concurrent actions, got %d", got)
+	}
+}
+
+func TestPipelineRunner_ParallelCriticalFailure(t *testing.T) {
+	geocode := &testAction{name: "geocode_location", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		return JobStep{Name: "geocode_location", Status: "failed", Critical: true, Error: stringPtr("geocoding unavailable")}
+	}}
+	calendar := &testAction{name: "create_calendar_event", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		time.Sleep(10 * time.Millisecond)
+		return JobStep{Name: "create_calendar_event", Status: "ok"}
+	}}
+	email := &testAction{name: "send_quote_email"}
+	runner := newTestRunner(geocode, calendar, email)
+
+	pipeline := &PipelineDefinition{
+		Key: "test",
+		Actions: []ActionDefinition{
+			{Parallel: &ParallelGroup{Actions: []ActionDefinition{
+				{Name: "geocode_location", Critical: true},
+				{Name: "create_calendar_event"},
+			}}},
+			{Name: "send_quote_email"},
+		},
+	}
+
+	result, job := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-1"})
+	if result.Success || job.Status != "failed" {
+		t.Fatalf("expected critical failure, got success=%v status=%s", result.Success, job.Status)
+	}
+	if result.Error == nil || *result.Error != "geocoding unavailable" {
+		t.Errorf("expected group failure as pipeline error, got %v", result.Error)
+	}
+	// The sibling still completes and is recorded; later actions never run
+	if len(job.Steps) != 2 || job.Steps[1].Name != "create_calendar_event" || job.Steps[1].Status != "ok" {
+		t.Errorf("expected both group steps recorded, got %+v", job.Steps)
+	}
+	if email.calls != 0 {
+		t.Errorf("expected actions after the group not to run, ran %d times", email.calls)
+	}
+}
+
+func TestPipelineRunner_Compensate(t *testing.T) {
+	invoice := &testAction{name: "create_deposit_invoice", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		return JobStep{Name: "create_deposit_invoice", Status: "ok", Outputs: map[string]any{"invoiceId": "in_123"}}
+	}}
+	calendar := &testAction{name: "create_calendar_event"}
+	email := &testAction{name: "send_quote_email"}
+	crm := &testAction{name: "create_crm_deal", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		return JobStep{Name: "create_crm_deal", Status: "failed", Critical: true, Error: stringPtr("monday: board not found")}
+	}}
+
+	var order []string
+	var voidedInvoice any
+	voidInvoice := &testAction{name: "void_invoice", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		order = append(order, "void_invoice")
+		voidedInvoice = ActionConfig(ctx)["invoiceId"]
+		return JobStep{Name: "void_invoice", Status: "ok"}
+	}}
+	deleteEvent := &testAction{name: "delete_calendar_event", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		order = append(order, "delete_calendar_event")
+		return JobStep{Name: "delete_calendar_event", Status: "failed", Critical: true, Error: stringPtr("calendar unavailable")}
+	}}
+	runner := newTestRunner(invoice, calendar, email, crm, voidInvoice, deleteEvent)
+
+	pipeline := &PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []ActionDefinition{
+			{Name: "create_deposit_invoice", Critical: true, Compensate: &ActionDefinition{
+				Name:   "void_invoice",
+				Config: map[string]any{"invoiceId": "{{ steps.create_deposit_invoice.invoiceId }}"},
+			}},
+			{Name: "create_calendar_event", Compensate: &ActionDefinition{Name: "delete_calendar_event"}},
+			{Name: "send_quote_email"},
+			{Name: "create_crm_deal", Critical: true, Compensate: &ActionDefinition{Name: "void_invoice"}},
+		},
+	}
+
+	result, job := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-1"})
+	if result.Success || job.Status != "failed" {
+		t.Fatalf("expected failed job, got success=%v status=%s", result.Success, job.Status)
+	}
+	if result.Error == nil || *result.Error != "monday: board not found" {
+		t.Errorf("expected the original failure as pipeline error, got %v", result.Error)
+	}
+
+	// Compensations run in reverse order, a failing one does not stop the rest,
+	// and the failed step itself is not compensated
+	if len(order) != 2 || order[0] != "delete_calendar_event" || order[1] != "void_invoice" {
+		t.Fatalf("expected compensations in reverse order, got %v", order)
+	}
+	if voidedInvoice != "in_123" {
+		t.Errorf("expected compensation config to resolve step outputs, got %v", voidedInvoice)
+	}
+
+	if len(job.Steps) != 6 {
+		t.Fatalf("expected 4 steps plus 2 compensations, got %+v", job.Steps)
+	}
+	for i, want := range []struct{ name, status, compensates string }{
+		{"delete_calendar_event", "failed", "create_calendar_event"},
+		{"void_invoice", "ok", "create_deposit_invoice"},
+	} {
+		step := job.Steps[4+i]
+		if step.Name != want.name || step.Status != want.status || step.Details["compensates"] != want.compensates {
+			t.Errorf("compensation %d: expected %+v, got %+v", i, want, step)
+		}
+	}
+}
+
+func TestPipelineRunner_NoCompensationOnSuccess(t *testing.T) {
+	invoice := &testAction{name: "create_deposit_invoice"}
+	voidInvoice := &testAction{name: "void_invoice"}
+	runner := newTestRunner(invoice, voidInvoice)
+
+	pipeline := &PipelineDefinition{
+		Key: "deposit_only",
+		Actions: []ActionDefinition{
+			{Name: "create_deposit_invoice", Critical: true, Compensate: &ActionDefinition{Name: "void_invoice"}},
+		},
+	}
+
+	result, _ := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-1"})
+	if !result.Success {
+		t.Fatalf("expected success, got error %v", *result.Error)
+	}
+	if voidInvoice.calls != 0 {
+		t.Errorf("expected no compensation, ran %d times", voidInvoice.calls)
+	}
+}
+
+func TestPipelineRunner_CriticalFromDefinition(t *testing.T) {
+	// Library actions build their failures with FailedStep, which knows nothing
+	// about the definition; the runner decides whether the failure is critical
+	invoice := &testAction{name: "create_deposit_invoice"}
+	email := &testAction{name: "send_quote_email", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		return FailedStep("send_quote_email", fmt.Errorf("gmail: quota exceeded"))
+	}}
+	slack := &testAction{name: "send_slack_notification", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		return FailedStep("send_slack_notification", fmt.Errorf("slack: webhook not found"))
+	}}
+	calendar := &testAction{name: "create_calendar_event"}
+	voidInvoice := &testAction{name: "void_invoice"}
+	runner := newTestRunner(invoice, email, slack, calendar, voidInvoice)
+
+	pipeline := &PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []ActionDefinition{
+			{Name: "create_deposit_invoice", Critical: true, Compensate: &ActionDefinition{Name: "void_invoice"}},
+			{Name: "send_slack_notification"},
+			{Name: "send_quote_email", Critical: true},
+			{Name: "create_calendar_event"},
+		},
+	}
+
+	result, job := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-1"})
+	if result.Success || job.Status != "failed" {
+		t.Fatalf("expected critical failure, got success=%v status=%s", result.Success, job.Status)
+	}
+	if result.Error == nil || *result.Error != "gmail: quota exceeded" {
+		t.Errorf("expected the critical failure as pipeline error, got %v", result.Error)
+	}
+	if slack.calls != 1 || calendar.calls != 0 {
+		t.Errorf("expected the non-critical failure to continue and the critical one to stop, got slack=%d calendar=%d", slack.calls, calendar.calls)
+	}
+	if job.Steps[1].Critical || !job.Steps[2].Critical {
+		t.Errorf("expected step criticality to follow the definitions, got %+v", job.Steps)
+	}
+	if voidInvoice.calls != 1 {
+		t.Errorf("expected the invoice to be compensated, ran %d times", voidInvoice.calls)
+	}
+}
+
+// pureTestAction is a testAction that declares itself side-effect free
+type pureTestAction struct {
+	*testAction
+}
+
+func (a pureTestAction) SideEffectFree() bool {
+	return true
+}
+
+func TestPipelineRunner_Resume(t *testing.T) {
+	normalize := &testAction{name: "normalize_input", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		pctx.Fields = map[string]any{"email": "jane@example.com"}
+		return JobStep{Name: "normalize_input", Status: "ok"}
+	}}
+	invoice := &testAction{name: "create_deposit_invoice"}
+	var received map[string]any
+	email := &testAction{name: "send_deposit_email", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		received = ActionConfig(ctx)
+		return JobStep{Name: "send_deposit_email", Status: "ok"}
+	}}
+	runner := NewPipelineRunner(map[string]Action{
+		"normalize_input":        pureTestAction{normalize},
+		"create_deposit_invoice": invoice,
+		"send_deposit_email":     email,
+	})
+
+	pipeline := &PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []ActionDefinition{
+			{Name: "normalize_input", Critical: true},
+			{Name: "create_deposit_invoice", Critical: true},
+			{Name: "send_deposit_email", Critical: true, Config: map[string]any{
+				"to":  "{{ fields.email }}",
+				"url": "{{ steps.create_deposit_invoice.hostedInvoiceUrl }}",
+			}},
+		},
+	}
+	createdAt := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	previous := &Job{
+		ID:        "job-1",
+		Status:    "failed",
+		CreatedAt: createdAt,
+		Steps: []JobStep{
+			{Name: "normalize_input", Status: "ok"},
+			{Name: "create_deposit_invoice", Status: "ok", Outputs: map[string]any{"hostedInvoiceUrl": "https://invoice.stripe.com/i/abc"}},
+			{Name: "send_deposit_email", Status: "failed", Critical: true, Error: stringPtr("gmail: quota exceeded")},
+		},
+	}
+	pctx := &PipelineContext{RequestID: "job-1", Fields: map[string]any{"email_address": "jane@example.com"}}
+
+	result, job := runner.ResumeObserved(context.Background(), pipeline, pctx, previous, nil)
+	if !result.Success || job.Status != "completed" {
+		t.Fatalf("expected resumed job to complete, got success=%v status=%s", result.Success, job.Status)
+	}
+	if job.ID != "job-1" || !job.CreatedAt.Equal(createdAt) {
+		t.Errorf("expected resumed job to keep ID and createdAt, got %s %v", job.ID, job.CreatedAt)
+	}
+
+	// Side-effect free actions run again; succeeded side effects are reused
+	if normalize.calls != 1 || invoice.calls != 0 || email.calls != 1 {
+		t.Errorf("expected calls normalize=1 invoice=0 email=1, got %d %d %d", normalize.calls, invoice.calls, email.calls)
+	}
+	if job.Steps[1].Details["resumed"] != true {
+		t.Errorf("expected reused step to be marked resumed, got %+v", job.Steps[1])
+	}
+	if received["to"] != "jane@example.com" || received["url"] != "https://invoice.stripe.com/i/abc" {
+		t.Errorf("expected config resolved from restored fields and saved outputs, got %v", received)
+	}
+}
+
+func TestPipelineRunner_ResumeAfterCompensation(t *testing.T) {
+	invoice := &testAction{name: "create_deposit_invoice"}
+	invoice.run = func(ctx context.Context, pctx *PipelineContext) JobStep {
+		return JobStep{Name: "create_deposit_invoice", Status: "ok", Outputs: map[string]any{"invoiceId": fmt.Sprintf("in_%d", invoice.calls)}}
+	}
+	crmFails := true
+	crm := &testAction{name: "create_crm_deal", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		if crmFails {
+			return JobStep{Name: "create_crm_deal", Status: "failed", Critical: true, Error: stringPtr("monday: board not found")}
+		}
+		return JobStep{Name: "create_crm_deal", Status: "ok"}
+	}}
+	var emailedInvoice any
+	email := &testAction{name: "send_deposit_email", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		emailedInvoice = ActionConfig(ctx)["invoiceId"]
+		return JobStep{Name: "send_deposit_email", Status: "ok"}
+	}}
+	voidInvoice := &testAction{name: "void_invoice"}
+	runner := newTestRunner(invoice, crm, email, voidInvoice)
+
+	pipeline := &PipelineDefinition{
+		Key: "quote_and_deposit",
+		Actions: []ActionDefinition{
+			{Name: "create_deposit_invoice", Critical: true, Compensate: &ActionDefinition{Name: "void_invoice"}},
+			{Name: "create_crm_deal", Critical: true},
+			{Name: "send_deposit_email", Config: map[string]any{"invoiceId": "{{ steps.create_deposit_invoice.invoiceId }}"}},
+		},
+	}
+
+	result, previous := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-1"})
+	if result.Success || voidInvoice.calls != 1 {
+		t.Fatalf("expected failed job with the invoice voided, got success=%v voids=%d", result.Success, voidInvoice.calls)
+	}
+
+	// The voided invoice is dead, so the resume creates a new one instead of
+	// reusing the compensated step
+	crmFails = false
+	result, job := runner.ResumeObserved(context.Background(), pipeline, &PipelineContext{RequestID: "job-1"}, previous, nil)
+	if !result.Success || job.Status != "completed" {
+		t.Fatalf("expected resumed job to complete, got success=%v status=%s", result.Success, job.Status)
+	}
+	if invoice.calls != 2 {
+		t.Errorf("expected the compensated step to run again, ran %d times", invoice.calls)
+	}
+	if job.Steps[0].Details["resumed"] == true {
+		t.Errorf("expected the compensated step not to be reused, got %+v", job.Steps[0])
+	}
+	if emailedInvoice != "in_2" {
+		t.Errorf("expected later steps to see the new invoice, got %v", emailedInvoice)
+	}
+}
+
+// describedTestAction is a testAction that declares a config schema
+type describedTestAction struct {
+	*testAction
+	schema ActionSchema
+}
+
+func (a describedTestAction) Schema() ActionSchema {
+	return a.schema
+}
+
+func TestPipelineRunner_ValidatePipeline(t *testing.T) {
+	slack := describedTestAction{
+		testAction: &testAction{name: "send_slack_notification"},
+		schema: ActionSchema{Config: map[string]ConfigField{
+			"channel": {Type: ConfigTypeString, Required: true},
+			"retries": {Type: ConfigTypeInteger},
+		}},
+	}
+	runner := NewPipelineRunner(map[string]Action{
+		"normalize_input":         &testAction{name: "normalize_input"},
+		"create_deposit_invoice":  &testAction{name: "create_deposit_invoice"},
+		"send_slack_notification": slack,
+	})
+
+	tests := []struct {
+		name    string
+		actions []ActionDefinition
+		want    []string
+	}{
+		{
+			name: "valid",
+			actions: []ActionDefinition{
+				{Name: "normalize_input", Config: map[string]any{"anything": true}},
+				{Name: "send_slack_notification", Config: map[string]any{"channel": "#leads", "retries": 2}},
+				{Name: "send_slack_notification", Config: map[string]any{"channel": "#leads", "retries": "{{ fields.retries }}"}},
+			},
+		},
+		{
+			name:    "unregistered action",
+			actions: []ActionDefinition{{Name: "create_deposit_invoce"}},
+			want:    []string{"actions[0] (create_deposit_invoce): action 'create_deposit_invoce' is not registered"},
+		},
+		{
+			name: "config does not match schema",
+			actions: []ActionDefinition{
+				{Name: "send_slack_notification", Config: map[string]any{"chanel": "#leads", "retries": 1.5}},
+			},
+			want: []string{
+				`actions[0] (send_slack_notification): config key "retries" must be of type integer, got float64`,
+				`actions[0] (send_slack_notification): missing required config key "channel"`,
+				`actions[0] (send_slack_notification): unknown config key "chanel"`,
+			},
+		},
+		{
+			name: "invalid condition",
+			actions: []ActionDefinition{
+				{Name: "normalize_input", When: "fields.numHelpers >="},
+			},
+			want: []string{`actions[0] (normalize_input): invalid condition "fields.numHelpers >=": unexpected end of expression`},
+		},
+		{
+			name: "parallel groups and compensations",
+			actions: []ActionDefinition{
+				{Parallel: &ParallelGroup{Actions: []ActionDefinition{
+					{Name: "normalize_input"},
+					{Name: "geocode_location"},
+				}}},
+				{Name: "create_deposit_invoice", Compensate: &ActionDefinition{Name: "void_invoice"}},
+				{Parallel: &ParallelGroup{}},
+			},
+			want: []string{
+				"actions[0].parallel[1] (geocode_location): action 'geocode_location' is not registered",
+				"actions[1] (create_deposit_invoice).compensate (void_invoice): action 'void_invoice' is not registered",
+				"actions[2]: parallel group has no actions",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runner.ValidatePipeline(&PipelineDefinition{Key: "quote_and_deposit", Actions: tt.actions})
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			invalid, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("expected *ValidationError, got %v", err)
+			}
+			if invalid.Source != "pipeline quote_and_deposit" {
+				t.Errorf("expected source 'pipeline quote_and_deposit', got %q", invalid.Source)
+			}
+			if strings.Join(invalid.Problems, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("expected problems\n%s\ngot\n%s", strings.Join(tt.want, "\n"), strings.Join(invalid.Problems, "\n"))
+			}
+		})
+	}
+}
+
+// mapPipelineLoader serves pipeline definitions from a map
+type mapPipelineLoader map[string]*PipelineDefinition
+
+func (l mapPipelineLoader) GetByKey(ctx context.Context, pipelineKey string) (*PipelineDefinition, error) {
+	pipeline, ok := l[pipelineKey]
+	if !ok {
+		return nil, fmt.Errorf("pipeline %s not found", pipelineKey)
+	}
+	return pipeline, nil
+}
+
+func TestPipelineRunner_CallPipeline(t *testing.T) {
+	var seenFields map[string]any
+	slack := &testAction{name: "send_slack_notification", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		seenFields = pctx.Fields
+		return JobStep{Name: "send_slack_notification", Status: "ok", Outputs: map[string]any{"messageTs": "1712.01"}}
+	}}
+	failing := &testAction{name: "create_deposit_invoice", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		return JobStep{Name: "create_deposit_invoice", Status: "failed", Critical: true, Error: stringPtr("stripe unavailable")}
+	}}
+	after := &testAction{name: "send_deposit_email"}
+	runner := newTestRunner(slack, failing, after)
+	runner.SetPipelineLoader(mapPipelineLoader{
+		"notify_team": {Key: "notify_team", Actions: []ActionDefinition{{Name: "send_slack_notification"}}},
+		"deposit":     {Key: "deposit", Actions: []ActionDefinition{{Name: "create_deposit_invoice", Critical: true}}},
+		"loop":        {Key: "loop", Actions: []ActionDefinition{{Name: "call_pipeline", Critical: true, Config: map[string]any{"pipelineKey": "{{ fields.next }}"}}}},
+	})
+
+	t.Run("records the nested job", func(t *testing.T) {
+		pipeline := &PipelineDefinition{Key: "quote_and_deposit", Actions: []ActionDefinition{
+			{Name: "call_pipeline", Critical: true, Config: map[string]any{"pipelineKey": "notify_team", "fields": map[string]any{"channel": "#leads"}}},
+			{Name: "send_deposit_email", When: "steps.call_pipeline.messageTs == '1712.01'"},
+		}}
+		pctx := &PipelineContext{PipelineKey: "quote_and_deposit", RequestID: "job-1", Fields: map[string]any{"email": "jane@example.com"}}
+
+		result, job := runner.Run(context.Background(), pipeline, pctx)
+		if !result.Success {
+			t.Fatalf("expected success, got error %v", *result.Error)
+		}
+		sub := job.Steps[0].SubJob
+		if sub == nil || sub.ID != "job-1/notify_team" || sub.PipelineKey != "notify_team" || sub.Status != "completed" || len(sub.Steps) != 1 {
+			t.Fatalf("expected the nested job on the step, got %+v", sub)
+		}
+		if seenFields["email"] != "jane@example.com" || seenFields["channel"] != "#leads" {
+			t.Errorf("expected the caller's fields plus config fields, got %v", seenFields)
+		}
+		if _, leaked := pctx.Fields["channel"]; leaked {
+			t.Errorf("expected the caller's fields to be unchanged, got %v", pctx.Fields)
+		}
+		if job.Steps[1].Status != "ok" {
+			t.Errorf("expected the nested outputs to be visible to later steps, got %+v", job.Steps[1])
+		}
+	})
+
+	t.Run("critical failure stops the caller", func(t *testing.T) {
+		pipeline := &PipelineDefinition{Key: "quote_and_deposit", Actions: []ActionDefinition{
+			{Name: "call_pipeline", Critical: true, Config: map[string]any{"pipelineKey": "deposit"}},
+			{Name: "send_deposit_email"},
+		}}
+		result, job := runner.Run(context.Background(), pipeline, &PipelineContext{PipelineKey: "quote_and_deposit", RequestID: "job-2"})
+		if result.Success || len(job.Steps) != 1 {
+			t.Fatalf("expected the caller to stop after the failed call, got %+v", job.Steps)
+		}
+		if want := "pipeline deposit failed: stripe unavailable"; result.Error == nil || *result.Error != want {
+			t.Errorf("expected error %q, got %v", want, result.Error)
+		}
+		if job.Steps[0].SubJob == nil || job.Steps[0].SubJob.Status != "failed" {
+			t.Errorf("expected the failed nested job on the step, got %+v", job.Steps[0].SubJob)
+		}
+	})
+
+	t.Run("non-critical failure continues", func(t *testing.T) {
+		pipeline := &PipelineDefinition{Key: "quote_and_deposit", Actions: []ActionDefinition{
+			{Name: "call_pipeline", Config: map[string]any{"pipelineKey": "deposit"}},
+			{Name: "send_deposit_email"},
+		}}
+		result, job := runner.Run(context.Background(), pipeline, &PipelineContext{PipelineKey: "quote_and_deposit", RequestID: "job-3"})
+		if !result.Success || len(job.Steps) != 2 || job.Steps[0].Status != "failed" {
+			t.Errorf("expected the caller to continue past the failed call, got %+v", job.Steps)
+		}
+	})
+
+	t.Run("cycle at run time", func(t *testing.T) {
+		pipeline := &PipelineDefinition{Key: "loop", Actions: []ActionDefinition{
+			{Name: "call_pipeline", Critical: true, Config: map[string]any{"pipelineKey": "{{ fields.next }}"}},
+		}}
+		result, _ := runner.Run(context.Background(), pipeline, &PipelineContext{PipelineKey: "loop", RequestID: "job-4", Fields: map[string]any{"next": "loop"}})
+		if want := "pipeline cycle: loop -> loop"; result.Success || result.Error == nil || !strings.Contains(*result.Error, want) {
+			t.Errorf("expected error containing %q, got %v", want, result.Error)
+		}
+	})
+}
+
+func TestPipelineRunner_ResumeCallPipeline(t *testing.T) {
+	invoice := &testAction{name: "create_deposit_invoice"}
+	failEmail := true
+	email := &testAction{name: "send_deposit_email", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		if failEmail {
+			return JobStep{Name: "send_deposit_email", Status: "failed", Error: stringPtr("gmail: quota exceeded")}
+		}
+		return JobStep{Name: "send_deposit_email", Status: "ok"}
+	}}
+	runner := newTestRunner(invoice, email)
+	runner.SetPipelineLoader(mapPipelineLoader{
+		"deposit": {Key: "deposit", Actions: []ActionDefinition{
+			{Name: "create_deposit_invoice", Critical: true},
+			{Name: "send_deposit_email", Critical: true},
+		}},
+	})
+	pipeline := &PipelineDefinition{Key: "quote_and_deposit", Actions: []ActionDefinition{
+		{Name: "call_pipeline", Critical: true, Config: map[string]any{"pipelineKey": "deposit"}},
+	}}
+	pctx := &PipelineContext{PipelineKey: "quote_and_deposit", RequestID: "job-1"}
+
+	result, previous := runner.Run(context.Background(), pipeline, pctx)
+	if result.Success {
+		t.Fatal("expected the first run to fail")
+	}
+
+	failEmail = false
+	result, job := runner.ResumeObserved(context.Background(), pipeline, pctx, previous, nil)
+	if !result.Success {
+		t.Fatalf("expected the resumed job to complete, got %v", result.Error)
+	}
+	// The invoice created by the nested job is not created again
+	if invoice.calls != 1 || email.calls != 2 {
+		t.Errorf("expected calls invoice=1 email=2, got %d %d", invoice.calls, email.calls)
+	}
+	sub := job.Steps[0].SubJob
+	if sub == nil || sub.ID != "job-1/deposit" || sub.Status != "completed" || sub.Steps[0].Details["resumed"] != true {
+		t.Errorf("expected the nested job to be resumed, got %+v", sub)
+	}
+}
+
+func TestCalledPipelines(t *testing.T) {
+	pipeline := &PipelineDefinition{Actions: []ActionDefinition{
+		{Name: "call_pipeline", Config: map[string]any{"pipelineKey": "notify_team"}},
+		{Parallel: &ParallelGroup{Actions: []ActionDefinition{
+			{Name: "call_pipeline", Config: map[string]any{"pipelineKey": "geocode"}},
+		}}},
+		{Name: "create_deposit_invoice", Compensate: &ActionDefinition{Name: "call_pipeline", Config: map[string]any{"pipelineKey": "void_deposit"}}},
+		{Name: "call_pipeline", Config: map[string]any{"pipelineKey": "{{ fields.followup }}"}},
+	}}
+	if got, want := CalledPipelines(pipeline), []string{"notify_team", "geocode", "void_deposit"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// recordingListener is a StepListener that keeps every step it is told about
+type recordingListener struct {
+	mu    sync.Mutex
+	steps []JobStep
+}
+
+func (l *recordingListener) StepFinished(ctx context.Context, pctx *PipelineContext, step JobStep) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.steps = append(l.steps, step)
+}
+
+func TestPipelineRunner_StepTiming(t *testing.T) {
+	slow := &testAction{name: "geocode_location", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		time.Sleep(10 * time.Millisecond)
+		return JobStep{Name: "geocode_location", Status: "ok"}
+	}}
+	runner := newTestRunner(slow, &testAction{name: "send_quote_email"})
+	listener := &recordingListener{}
+	runner.SetStepListener(listener)
+
+	pipeline := &PipelineDefinition{
+		Key: "test",
+		Actions: []ActionDefinition{
+			{Name: "geocode_location", Timeout: 15 * time.Second},
+			{Name: "send_quote_email"},
+		},
+	}
+
+	result, _ := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-timing"})
+
+	geocode := result.Steps[0]
+	if geocode.StartedAt.IsZero() || geocode.FinishedAt.Before(geocode.StartedAt) {
+		t.Errorf("expected startedAt and finishedAt to be set, got %v and %v", geocode.StartedAt, geocode.FinishedAt)
+	}
+	if geocode.DurationMs < 10 || geocode.DurationMs != geocode.FinishedAt.Sub(geocode.StartedAt).Milliseconds() {
+		t.Errorf("expected durationMs to match the step's bounds, got %d", geocode.DurationMs)
+	}
+	if geocode.TimeoutMs != 15000 {
+		t.Errorf("expected timeoutMs 15000, got %d", geocode.TimeoutMs)
+	}
+	if result.Steps[1].StartedAt.IsZero() || result.Steps[1].TimeoutMs != 0 {
+		t.Errorf("unexpected timing on the second step: %+v", result.Steps[1])
+	}
+
+	if len(listener.steps) != 2 || listener.steps[0].Name != "geocode_location" || listener.steps[0].DurationMs != geocode.DurationMs {
+		t.Errorf("expected the listener to see both timed steps, got %+v", listener.steps)
+	}
+}
+
+// plannedTestAction is a testAction that can plan its side effect
+type plannedTestAction struct {
+	*testAction
+	plans int
+}
+
+func (a *plannedTestAction) Plan(ctx context.Context, pctx *PipelineContext) JobStep {
+	a.plans++
+	to, _ := pctx.Fields["email"].(string)
+	return JobStep{
+		Name:        a.name,
+		Status:      "ok",
+		Outputs:     map[string]any{"invoiceUrl": "https://example.com/planned"},
+		SideEffects: []SideEffect{{Kind: "email", Summary: a.name + " to " + to}},
+	}
+}
+
+func TestPipelineRunner_DryRunPlan(t *testing.T) {
+	normalize := pureTestAction{&testAction{name: "normalize_input", run: func(ctx context.Context, pctx *PipelineContext) JobStep {
+		pctx.Fields = map[string]any{"email": "jane@example.com"}
+		return JobStep{Name: "normalize_input", Status: "ok"}
+	}}}
+	quote := &plannedTestAction{testAction: &testAction{name: "send_quote_email"}}
+	deposit := &plannedTestAction{testAction: &testAction{name: "send_deposit_email"}}
+	runner := NewPipelineRunner(map[string]Action{
+		"normalize_input":    normalize,
+		"send_quote_email":   quote,
+		"send_deposit_email": deposit,
+	})
+	runner.SetPipelineLoader(mapPipelineLoader{
+		"deposit_only": {Key: "deposit_only", Actions: []ActionDefinition{{Name: "send_deposit_email"}}},
+	})
+
+	pipeline := &PipelineDefinition{Key: "quote_and_deposit", Actions: []ActionDefinition{
+		{Name: "normalize_input", Critical: true},
+		{Name: "send_quote_email"},
+		{Name: "call_pipeline", When: "steps.send_quote_email.status == 'ok'", Config: map[string]any{"pipelineKey": "deposit_only"}},
+	}}
+
+	t.Run("dry run plans", func(t *testing.T) {
+		pctx := &PipelineContext{PipelineKey: "quote_and_deposit", RequestID: "job-plan", DryRun: true, Fields: map[string]any{}}
+		result, _ := runner.Run(context.Background(), pipeline, pctx)
+
+		if !result.Success {
+			t.Fatalf("expected success, got error %v", *result.Error)
+		}
+		if normalize.calls != 1 || quote.calls != 0 || quote.plans != 1 || deposit.calls != 0 || deposit.plans != 1 {
+			t.Errorf("expected side-effect free actions to run and the others to be planned, got normalize=%d quote=%d/%d deposit=%d/%d",
+				normalize.calls, quote.calls, quote.plans, deposit.calls, deposit.plans)
+		}
+		want := []PlannedSideEffect{
+			{PipelineKey: "quote_and_deposit", Step: "send_quote_email", SideEffect: SideEffect{Kind: "email", Summary: "send_quote_email to jane@example.com"}},
+			{PipelineKey: "deposit_only", Step: "send_deposit_email", SideEffect: SideEffect{Kind: "email", Summary: "send_deposit_email to jane@example.com"}},
+		}
+		if len(result.Plan) != len(want) {
+			t.Fatalf("expected plan %+v, got %+v", want, result.Plan)
+		}
+		for i := range want {
+			if got := result.Plan[i]; got.PipelineKey != want[i].PipelineKey || got.Step != want[i].Step || got.Summary != want[i].Summary {
+				t.Errorf("plan[%d]: expected %+v, got %+v", i, want[i], got)
+			}
+		}
+	})
+
+	t.Run("real run executes", func(t *testing.T) {
+		quote.plans, deposit.plans = 0, 0
+		pctx := &PipelineContext{PipelineKey: "quote_and_deposit", RequestID: "job-real", Fields: map[string]any{}}
+		result, _ := runner.Run(context.Background(), pipeline, pctx)
+
+		if quote.calls != 1 || quote.plans != 0 || deposit.calls != 1 || deposit.plans != 0 {
+			t.Errorf("expected actions to execute, got quote=%d/%d deposit=%d/%d", quote.calls, quote.plans, deposit.calls, deposit.plans)
+		}
+		if result.Plan != nil {
+			t.Errorf("expected no plan outside a dry run, got %+v", result.Plan)
+		}
+	})
+}
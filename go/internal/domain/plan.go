@@ -0,0 +1,65 @@
+package domain
+
+import "context"
+
+// SideEffect describes something an action does outside the pipeline, such
+// as sending an email or creating an invoice
+type SideEffect struct {
+	// Kind names the effect, e.g. "email", "invoice", "calendar_event"
+	Kind string `json:"kind"`
+	// Summary is one line for the person approving a dry run
+	Summary string `json:"summary"`
+	// Details holds the specifics: recipient and subject, amount and line
+	// items, event title and time
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// PlannedSideEffect is a side effect in a dry-run preview, with the step and
+// pipeline that would cause it
+type PlannedSideEffect struct {
+	PipelineKey string `json:"pipelineKey"`
+	Step        string `json:"step"`
+	SideEffect
+}
+
+// PlannedAction is implemented by actions with side effects. In a dry run
+// the runner calls Plan instead of Execute. Plan resolves everything Execute
+// would (recipients, amounts, templates) and returns a step with the
+// SideEffects it would cause, without causing them. It should publish
+// stand-in Outputs so that later actions can be planned too.
+//
+// In a dry run, actions that implement neither PlannedAction nor
+// SideEffectFreeAction are executed and must check PipelineContext.DryRun
+// themselves.
+type PlannedAction interface {
+	Plan(ctx context.Context, pctx *PipelineContext) JobStep
+}
+
+// planningAction runs a PlannedAction's Plan in place of Execute
+type planningAction struct {
+	PlannedAction
+	name string
+}
+
+func (a planningAction) Name() string {
+	return a.name
+}
+
+func (a planningAction) Execute(ctx context.Context, pctx *PipelineContext) JobStep {
+	return a.Plan(ctx, pctx)
+}
+
+// collectPlan returns the side effects of a job's steps, including those of
+// nested jobs, in execution order
+func collectPlan(job *Job) []PlannedSideEffect {
+	plan := []PlannedSideEffect{}
+	for _, step := range job.Steps {
+		for _, effect := range step.SideEffects {
+			plan = append(plan, PlannedSideEffect{PipelineKey: job.PipelineKey, Step: step.Name, SideEffect: effect})
+		}
+		if step.SubJob != nil {
+			plan = append(plan, collectPlan(step.SubJob)...)
+		}
+	}
+	return plan
+}
@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Default backoff settings used when a retry policy leaves them unset
+const (
+	DefaultInitialBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff     = 30 * time.Second
+)
+
+// RetryPolicy controls how a failed action is retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int `yaml:"maxAttempts" json:"maxAttempts"`
+	// InitialBackoff is the wait before the second attempt; it doubles after each attempt
+	InitialBackoff time.Duration `yaml:"initialBackoff" json:"initialBackoff"`
+	// MaxBackoff caps the wait between attempts
+	MaxBackoff time.Duration `yaml:"maxBackoff" json:"maxBackoff"`
+	// RetryOn limits retries to failures whose error (or details.errorCode) contains
+	// one of these substrings, case-insensitively (e.g. "timeout", "429", "rate limit").
+	// An empty list retries every failure that is not permanent.
+	RetryOn []string `yaml:"retryOn" json:"retryOn,omitempty"`
+}
+
+// Backoff returns the wait before the given attempt (2 = first retry)
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = DefaultInitialBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	backoff := initial
+	for i := 2; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// ShouldRetry reports whether a failed step qualifies for another attempt
+func (p *RetryPolicy) ShouldRetry(step JobStep) bool {
+	if step.Status != "failed" || step.Permanent {
+		return false
+	}
+	if len(p.RetryOn) == 0 {
+		return true
+	}
+
+	var candidates []string
+	if step.Error != nil {
+		candidates = append(candidates, strings.ToLower(*step.Error))
+	}
+	if code, ok := step.Details["errorCode"]; ok {
+		candidates = append(candidates, strings.ToLower(fmt.Sprint(code)))
+	}
+	for _, pattern := range p.RetryOn {
+		pattern = strings.ToLower(pattern)
+		for _, candidate := range candidates {
+			if strings.Contains(candidate, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PermanentError marks an error that must not be retried (e.g. invalid input, card declined)
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wraps err so that the pipeline runner does not retry it
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err (or any error it wraps) is permanent
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+// FailedStep builds a failed JobStep from an error, marking it permanent when
+// the error was wrapped with Permanent
+func FailedStep(name string, err error) JobStep {
+	return JobStep{
+		Name:      name,
+		Status:    "failed",
+		Error:     stringPtr(err.Error()),
+		Permanent: IsPermanent(err),
+	}
+}
+
+// executeWithRetry runs an action, retrying failed attempts according to the policy.
+// Each attempt runs under its own timeout when one is set. Every attempt is
+// recorded in the step details when a policy is configured.
+func executeWithRetry(ctx context.Context, action Action, policy *RetryPolicy, timeout time.Duration, pctx *PipelineContext) JobStep {
+	if policy == nil || policy.MaxAttempts <= 1 {
+		return executeWithTimeout(ctx, action, timeout, pctx)
+	}
+
+	var attempts []map[string]any
+	var step JobStep
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(policy.Backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				step.Error = stringPtr(fmt.Sprintf("retry aborted after %d attempt(s): %v", attempt-1, ctx.Err()))
+				return withAttempts(step, attempts)
+			case <-timer.C:
+			}
+		}
+
+		start := time.Now()
+		step = executeWithTimeout(ctx, action, timeout, pctx)
+		record := map[string]any{
+			"attempt":    attempt,
+			"status":     step.Status,
+			"durationMs": time.Since(start).Milliseconds(),
+		}
+		if step.Error != nil {
+			record["error"] = *step.Error
+		}
+		attempts = append(attempts, record)
+
+		if !policy.ShouldRetry(step) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	return withAttempts(step, attempts)
+}
+
+// executeWithTimeout runs one attempt of an action under a context derived
+// from ctx that expires after timeout (no deadline of its own when timeout is
+// not positive). A failure caused by the timeout is reported as one, so that
+// retryOn: ["timeout"] matches it.
+func executeWithTimeout(ctx context.Context, action Action, timeout time.Duration, pctx *PipelineContext) JobStep {
+	if timeout <= 0 {
+		return action.Execute(ctx, pctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	step := action.Execute(attemptCtx, pctx)
+	if step.Status == "failed" && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		reason := "deadline exceeded"
+		if step.Error != nil {
+			reason = *step.Error
+		}
+		step.Error = stringPtr(fmt.Sprintf("timeout after %s: %s", timeout, reason))
+	}
+	return step
+}
+
+// withAttempts attaches the attempt log to a step's details
+func withAttempts(step JobStep, attempts []map[string]any) JobStep {
+	details := make(map[string]any, len(step.Details)+1)
+	for k, v := range step.Details {
+		details[k] = v
+	}
+	details["attempts"] = attempts
+	step.Details = details
+	return step
+}
@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     500 * time.Millisecond,
+	}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 2, want: 100 * time.Millisecond},
+		{attempt: 3, want: 200 * time.Millisecond},
+		{attempt: 4, want: 400 * time.Millisecond},
+		{attempt: 5, want: 500 * time.Millisecond},
+		{attempt: 10, want: 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt %d", tt.attempt), func(t *testing.T) {
+			if got := policy.Backoff(tt.attempt); got != tt.want {
+				t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		retryOn []string
+		step    JobStep
+		want    bool
+	}{
+		{
+			name: "ok step",
+			step: JobStep{Status: "ok"},
+			want: false,
+		},
+		{
+			name: "any failure",
+			step: JobStep{Status: "failed", Error: stringPtr("boom")},
+			want: true,
+		},
+		{
+			name: "permanent failure",
+			step: FailedStep("send_quote_email", Permanent(errors.New("invalid recipient"))),
+			want: false,
+		},
+		{
+			name:    "matching error message",
+			retryOn: []string{"timeout", "429"},
+			step:    JobStep{Status: "failed", Error: stringPtr("gmail: request Timeout")},
+			want:    true,
+		},
+		{
+			name:    "matching error code",
+			retryOn: []string{"rate_limit"},
+			step:    JobStep{Status: "failed", Error: stringPtr("stripe error"), Details: map[string]any{"errorCode": "rate_limit"}},
+			want:    true,
+		},
+		{
+			name:    "non-matching error",
+			retryOn: []string{"timeout"},
+			step:    JobStep{Status: "failed", Error: stringPtr("card declined")},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &RetryPolicy{MaxAttempts: 3, RetryOn: tt.retryOn}
+			if got := policy.ShouldRetry(tt.step); got != tt.want {
+				t.Errorf("ShouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPipelineRunner_Retry(t *testing.T) {
+	flaky := &testAction{name: "create_deposit_invoice"}
+	flaky.run = func(ctx context.Context, pctx *PipelineContext) JobStep {
+		if flaky.calls < 3 {
+			return JobStep{Name: "create_deposit_invoice", Status: "failed", Critical: true, Error: stringPtr("stripe: 503 service unavailable")}
+		}
+		return JobStep{Name: "create_deposit_invoice", Status: "ok", Critical: true}
+	}
+	runner := newTestRunner(flaky)
+
+	pipeline := &PipelineDefinition{
+		Key: "deposit_only",
+		Actions: []ActionDefinition{
+			{
+				Name:     "create_deposit_invoice",
+				Critical: true,
+				Retry:    &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+			},
+		},
+	}
+
+	result, _ := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-retry"})
+
+	if !result.Success {
+		t.Fatalf("expected success after retries, got error %v", result.Error)
+	}
+	if flaky.calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", flaky.calls)
+	}
+	attempts, ok := result.Steps[0].Details["attempts"].([]map[string]any)
+	if !ok || len(attempts) != 3 {
+		t.Fatalf("expected 3 recorded attempts, got %v", result.Steps[0].Details["attempts"])
+	}
+	if attempts[0]["error"] != "stripe: 503 service unavailable" {
+		t.Errorf("expected first attempt error to be recorded, got %v", attempts[0]["error"])
+	}
+	if attempts[2]["status"] != "ok" {
+		t.Errorf("expected last attempt to succeed, got %v", attempts[2]["status"])
+	}
+}
+
+func TestPipelineRunner_RetryPermanent(t *testing.T) {
+	action := &testAction{name: "send_quote_email"}
+	action.run = func(ctx context.Context, pctx *PipelineContext) JobStep {
+		step := FailedStep("send_quote_email", Permanent(errors.New("invalid recipient")))
+		step.Critical = true
+		return step
+	}
+	runner := newTestRunner(action)
+
+	pipeline := &PipelineDefinition{
+		Key: "test",
+		Actions: []ActionDefinition{
+			{Name: "send_quote_email", Critical: true, Retry: &RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond}},
+		},
+	}
+
+	result, job := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-permanent"})
+
+	if result.Success || job.Status != "failed" {
+		t.Fatal("expected job to fail")
+	}
+	if action.calls != 1 {
+		t.Errorf("expected permanent error not to be retried, got %d attempts", action.calls)
+	}
+}
+
+func TestPipelineRunner_RetryCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	action := &testAction{name: "geocode_location"}
+	action.run = func(ctx context.Context, pctx *PipelineContext) JobStep {
+		cancel()
+		return JobStep{Name: "geocode_location", Status: "failed", Error: stringPtr("timeout")}
+	}
+	runner := newTestRunner(action)
+
+	pipeline := &PipelineDefinition{
+		Key: "test",
+		Actions: []ActionDefinition{
+			{Name: "geocode_location", Retry: &RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}},
+		},
+	}
+
+	result, _ := runner.Run(ctx, pipeline, &PipelineContext{RequestID: "job-cancel"})
+
+	if action.calls != 1 {
+		t.Errorf("expected no retries after cancellation, got %d attempts", action.calls)
+	}
+	if result.Steps[0].Status != "failed" {
+		t.Errorf("expected failed step, got %s", result.Steps[0].Status)
+	}
+}
+
+func TestPipelineRunner_Timeout(t *testing.T) {
+	action := &testAction{name: "geocode_location"}
+	action.run = func(ctx context.Context, pctx *PipelineContext) JobStep {
+		<-ctx.Done()
+		return FailedStep("geocode_location", ctx.Err())
+	}
+	runner := newTestRunner(action)
+
+	pipeline := &PipelineDefinition{
+		Key: "test",
+		Actions: []ActionDefinition{
+			{
+				Name:    "geocode_location",
+				Timeout: 5 * time.Millisecond,
+				Retry:   &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, RetryOn: []string{"timeout"}},
+			},
+		},
+	}
+
+	result, _ := runner.Run(context.Background(), pipeline, &PipelineContext{RequestID: "job-timeout"})
+
+	step := result.Steps[0]
+	if step.Status != "failed" || step.Error == nil || !strings.HasPrefix(*step.Error, "timeout after 5ms") {
+		t.Fatalf("expected a timeout failure, got %+v", step)
+	}
+	if action.calls != 2 {
+		t.Errorf("expected the timed out attempt to be retried, got %d attempts", action.calls)
+	}
+	if step.TimeoutMs != 5 {
+		t.Errorf("expected timeoutMs 5, got %d", step.TimeoutMs)
+	}
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// templatePattern matches {{ path }} references in action config values
+var templatePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.\-]+)\s*\}\}`)
+
+type actionConfigKey struct{}
+
+// WithActionConfig returns a context carrying the resolved config of the action
+// about to run
+func WithActionConfig(ctx context.Context, config map[string]any) context.Context {
+	return context.WithValue(ctx, actionConfigKey{}, config)
+}
+
+// ActionConfig returns the resolved config of the running action, or nil when
+// the action has none
+func ActionConfig(ctx context.Context) map[string]any {
+	config, _ := ctx.Value(actionConfigKey{}).(map[string]any)
+	return config
+}
+
+// ResolveConfig returns a copy of config with every {{ path }} reference replaced
+// by its value in env (see ConditionEnv), e.g.
+// {{ steps.create_deposit_invoice.hostedInvoiceUrl }} or {{ fields.email }}.
+//
+// A string that is exactly one reference takes the referenced value with its
+// type; references inside longer strings are interpolated as text. Referencing
+// a missing value is an error.
+func ResolveConfig(config map[string]any, env map[string]any) (map[string]any, error) {
+	if config == nil {
+		return nil, nil
+	}
+	resolved, err := resolveTemplateValue(config, env)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.(map[string]any), nil
+}
+
+func resolveTemplateValue(value any, env map[string]any) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved, err := resolveTemplateValue(item, env)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			out[key] = resolved
+		}
+		return out, nil
+
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := resolveTemplateValue(item, env)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out[i] = resolved
+		}
+		return out, nil
+
+	case string:
+		return resolveTemplateString(v, env)
+
+	default:
+		return value, nil
+	}
+}
+
+func resolveTemplateString(s string, env map[string]any) (any, error) {
+	matches := templatePattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, nil
+	}
+
+	// A lone reference keeps the referenced value's type
+	if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(s) {
+		return lookupTemplatePath(s[matches[0][2]:matches[0][3]], env)
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(s[last:m[0]])
+		value, err := lookupTemplatePath(s[m[2]:m[3]], env)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(fmt.Sprint(value))
+		last = m[1]
+	}
+	b.WriteString(s[last:])
+	return b.String(), nil
+}
+
+func lookupTemplatePath(path string, env map[string]any) (any, error) {
+	value := pathNode{parts: strings.Split(path, ".")}.eval(env)
+	if value == nil {
+		return nil, fmt.Errorf("unresolved template reference {{ %s }}", path)
+	}
+	return value, nil
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveConfig(t *testing.T) {
+	env := map[string]any{
+		"fields":     map[string]any{"email": "jane@example.com", "numHelpers": float64(3)},
+		"businessId": "stlpartyhelpers",
+		"steps": map[string]any{
+			"create_deposit_invoice": map[string]any{"status": "ok", "hostedInvoiceUrl": "https://invoice.stripe.com/i/abc"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name:   "no templates",
+			config: map[string]any{"channel": "#leads", "retries": 2},
+			want:   map[string]any{"channel": "#leads", "retries": 2},
+		},
+		{
+			name:   "lone reference keeps type",
+			config: map[string]any{"helpers": "{{ fields.numHelpers }}"},
+			want:   map[string]any{"helpers": float64(3)},
+		},
+		{
+			name:   "interpolation",
+			config: map[string]any{"message": "{{numHelpers}} helpers for {{ businessId }}: {{ steps.create_deposit_invoice.hostedInvoiceUrl }}"},
+			want:   map[string]any{"message": "3 helpers for stlpartyhelpers: https://invoice.stripe.com/i/abc"},
+		},
+		{
+			name: "nested maps and lists",
+			config: map[string]any{
+				"email": map[string]any{"to": "{{ fields.email }}", "cc": []any{"team@stlpartyhelpers.com", "{{ fields.email }}"}},
+			},
+			want: map[string]any{
+				"email": map[string]any{"to": "jane@example.com", "cc": []any{"team@stlpartyhelpers.com", "jane@example.com"}},
+			},
+		},
+		{
+			name:    "missing reference",
+			config:  map[string]any{"email": map[string]any{"url": "{{ steps.create_final_invoice.hostedInvoiceUrl }}"}},
+			wantErr: "email: url: unresolved template reference {{ steps.create_final_invoice.hostedInvoiceUrl }}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveConfig(tt.config, env)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Timer statuses
+const (
+	TimerStatusScheduled = "scheduled"
+	TimerStatusFired     = "fired"
+	TimerStatusCancelled = "cancelled"
+	TimerStatusFailed    = "failed"
+)
+
+// EventDateFormat is the layout of Timer.EventDate
+const EventDateFormat = "2006-01-02"
+
+// Timer is a trigger run scheduled relative to a lead's event date, e.g. three
+// days before the event at 10:00 business time
+type Timer struct {
+	ID         string `json:"id"`
+	BusinessID string `json:"businessId"`
+	// LeadID identifies the lead the timer belongs to, usually its email
+	LeadID     string `json:"leadId"`
+	Key        string `json:"key"`
+	TriggerKey string `json:"triggerKey"`
+	EventDate  string `json:"eventDate"`
+	// OffsetDays is negative before the event and positive after it
+	OffsetDays int `json:"offsetDays"`
+	// At is the HH:MM time of day in the business timezone
+	At        string         `json:"at"`
+	FireAt    time.Time      `json:"fireAt"`
+	Payload   map[string]any `json:"payload,omitempty"`
+	Status    string         `json:"status"`
+	JobID     string         `json:"jobId,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// Snapshot returns a copy of the timer that does not share its payload map
+func (t *Timer) Snapshot() *Timer {
+	snapshot := *t
+	if t.Payload != nil {
+		snapshot.Payload = make(map[string]any, len(t.Payload))
+		for k, v := range t.Payload {
+			snapshot.Payload[k] = v
+		}
+	}
+	return &snapshot
+}
+
+// TimerID returns the ID of a lead's timer. It is derived from the timer key so
+// scheduling the same timer again replaces it.
+func TimerID(businessID, leadID, key string) string {
+	sum := sha256.Sum256([]byte(businessID + "\x00" + leadID + "\x00" + key))
+	return "tmr_" + hex.EncodeToString(sum[:8])
+}
+
+// TimerFireAt returns when a timer fires: offsetDays from eventDate
+// (YYYY-MM-DD) at the HH:MM time at in loc
+func TimerFireAt(eventDate string, offsetDays int, at string, loc *time.Location) (time.Time, error) {
+	day, err := time.ParseInLocation(EventDateFormat, eventDate, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid event date %q: expected YYYY-MM-DD", eventDate)
+	}
+	if at == "" {
+		at = "00:00"
+	}
+	clock, err := time.Parse("15:04", at)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time of day %q: expected HH:MM", at)
+	}
+	day = day.AddDate(0, 0, offsetDays)
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc), nil
+}
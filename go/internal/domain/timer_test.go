@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerFireAt(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		eventDate  string
+		offsetDays int
+		at         string
+		want       string
+		wantErr    bool
+	}{
+		{name: "days before", eventDate: "2026-03-14", offsetDays: -3, at: "10:00", want: "2026-03-11T10:00:00-05:00"},
+		{name: "day after", eventDate: "2026-03-14", offsetDays: 1, at: "09:30", want: "2026-03-15T09:30:00-05:00"},
+		{name: "across DST change", eventDate: "2026-03-10", offsetDays: -3, at: "10:00", want: "2026-03-07T10:00:00-06:00"},
+		{name: "midnight by default", eventDate: "2026-03-14", offsetDays: 0, want: "2026-03-14T00:00:00-05:00"},
+		{name: "invalid date", eventDate: "03/14/2026", at: "10:00", wantErr: true},
+		{name: "invalid time", eventDate: "2026-03-14", at: "10am", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TimerFireAt(tt.eventDate, tt.offsetDays, tt.at, chicago)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TimerFireAt failed: %v", err)
+			}
+			if got.Format(time.RFC3339) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got.Format(time.RFC3339))
+			}
+		})
+	}
+}
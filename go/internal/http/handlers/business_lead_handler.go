@@ -6,24 +6,24 @@ import (
 	"os"
 	"strings"
 
-	"github.com/bizops360/go-api/internal/config"
 	"github.com/bizops360/go-api/internal/infra/calendar"
 	"github.com/bizops360/go-api/internal/infra/email"
 	"github.com/bizops360/go-api/internal/infra/geo"
+	"github.com/bizops360/go-api/internal/ports"
 	"github.com/bizops360/go-api/internal/services/lead"
 	"github.com/bizops360/go-api/internal/util"
 )
 
 // BusinessLeadHandler handles business-specific lead processing
 type BusinessLeadHandler struct {
-	businessLoader *config.BusinessLoader
+	businesses     ports.BusinessesRepo
 	leadProcessor  *lead.Processor
 	logger         *slog.Logger
 }
 
 // NewBusinessLeadHandler creates a new business lead handler
 func NewBusinessLeadHandler(
-	businessLoader *config.BusinessLoader,
+	businesses ports.BusinessesRepo,
 	logger *slog.Logger,
 ) *BusinessLeadHandler {
 	// Initialize services (these could be injected, but for now we'll create them here)
@@ -62,7 +62,7 @@ func NewBusinessLeadHandler(
 	)
 
 	return &BusinessLeadHandler{
-		businessLoader: businessLoader,
+		businesses:     businesses,
 		leadProcessor:  leadProcessor,
 		logger:         logger,
 	}
@@ -85,7 +85,7 @@ func (h *BusinessLeadHandler) HandleProcessLead(w http.ResponseWriter, r *http.R
 
 	// Load business configuration
 	ctx := r.Context()
-	businessConfig, err := h.businessLoader.LoadBusiness(ctx, businessID)
+	businessConfig, err := h.businesses.GetByID(ctx, businessID)
 	if err != nil {
 		h.logger.Warn("business not found", "businessId", businessID, "error", err)
 		util.WriteError(w, http.StatusNotFound, "business not found")
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// ConfigHandler handles the admin endpoints for the loaded configuration
+type ConfigHandler struct {
+	businessLoader *config.BusinessLoader
+}
+
+// NewConfigHandler creates a new config handler. businessLoader is nil when
+// the config is stored in a database, which has no files to reload.
+func NewConfigHandler(businessLoader *config.BusinessLoader) *ConfigHandler {
+	return &ConfigHandler{businessLoader: businessLoader}
+}
+
+// fileConfig reports whether the config is loaded from files, writing a 404
+// when it is not
+func (h *ConfigHandler) fileConfig(w http.ResponseWriter) bool {
+	if h.businessLoader == nil {
+		util.WriteError(w, http.StatusNotFound, "config is not loaded from files")
+		return false
+	}
+	return true
+}
+
+// HandleStatus handles GET /v1/admin/config, returning the loaded config
+// version of every business and the outcome of the last reload
+func (h *ConfigHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !h.fileConfig(w) {
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, h.businessLoader.ReloadStatus())
+}
+
+// HandleReload handles POST /v1/admin/config/reload, reloading the
+// configuration now. A config that fails validation is rejected with 422 and
+// the list of problems, and the previous config stays loaded.
+func (h *ConfigHandler) HandleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !h.fileConfig(w) {
+		return
+	}
+
+	if err := h.businessLoader.Reload(r.Context()); err != nil {
+		var invalid *domain.ValidationError
+		if errors.As(err, &invalid) {
+			util.WriteJSON(w, http.StatusUnprocessableEntity, map[string]any{
+				"error":    "config rejected, keeping the previous config",
+				"problems": invalid.Problems,
+				"status":   h.businessLoader.ReloadStatus(),
+			})
+			return
+		}
+		util.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, h.businessLoader.ReloadStatus())
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strconv"
+
+	"github.com/bizops360/go-api/internal/app"
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// Page sizes for GET /v1/crm/deals
+const (
+	defaultCRMDealsListLimit = 50
+	maxCRMDealsListLimit     = 200
+)
+
+// CRMHandler handles the built-in CRM endpoints
+type CRMHandler struct {
+	service *app.CRMService
+}
+
+// NewCRMHandler creates a new built-in CRM handler
+func NewCRMHandler(service *app.CRMService) *CRMHandler {
+	return &CRMHandler{service: service}
+}
+
+// HandleBoards handles GET /v1/crm/boards?businessId=, listing the boards
+// with their status columns
+func (h *CRMHandler) HandleBoards(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := crmBusinessID(r, "")
+	if businessID == "" {
+		util.WriteError(w, http.StatusBadRequest, "businessId is required")
+		return
+	}
+
+	boards, err := h.service.Boards(r.Context(), businessID)
+	if err != nil {
+		writeCRMError(w, err)
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, map[string]any{
+		"businessId": businessID,
+		"boards":     boards,
+	})
+}
+
+// HandleDeals handles GET /v1/crm/deals?businessId=
+//
+// Optional filters: board (a name from crm.boards), q (searched in the deal
+// name and fields), limit, and a label per status column, e.g.
+// status=Booked.
+func (h *CRMHandler) HandleDeals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	params := r.URL.Query()
+	req := &app.ListCRMDealsRequest{
+		BusinessID: crmBusinessID(r, ""),
+		Board:      params.Get("board"),
+		Search:     params.Get("q"),
+		Limit:      defaultCRMDealsListLimit,
+	}
+	if req.BusinessID == "" {
+		util.WriteError(w, http.StatusBadRequest, "businessId is required")
+		return
+	}
+
+	if limitParam := params.Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed <= 0 || parsed > maxCRMDealsListLimit {
+			util.WriteError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxCRMDealsListLimit))
+			return
+		}
+		req.Limit = parsed
+	}
+
+	// Any other parameter filters a status column
+	for name, values := range params {
+		switch name {
+		case "businessId", "board", "q", "limit":
+			continue
+		}
+		if req.Statuses == nil {
+			req.Statuses = make(map[string]string)
+		}
+		req.Statuses[name] = values[0]
+	}
+
+	list, err := h.service.ListDeals(r.Context(), req)
+	if err != nil {
+		writeCRMError(w, err)
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, list)
+}
+
+// HandleDeal handles GET /v1/crm/deals/{id}?businessId= and
+// PATCH /v1/crm/deals/{id}, which merges the name and fields of the body into
+// the deal
+func (h *CRMHandler) HandleDeal(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		util.WriteError(w, http.StatusBadRequest, "deal ID must be a positive number")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		businessID := crmBusinessID(r, "")
+		if businessID == "" {
+			util.WriteError(w, http.StatusBadRequest, "businessId is required")
+			return
+		}
+		view, err := h.service.GetDeal(r.Context(), businessID, id)
+		if err != nil {
+			writeCRMError(w, err)
+			return
+		}
+		util.WriteJSON(w, http.StatusOK, view)
+	case http.MethodPatch:
+		h.handleUpdate(w, r, id)
+	default:
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (h *CRMHandler) handleUpdate(w http.ResponseWriter, r *http.Request, id int64) {
+	var body struct {
+		BusinessID string         `json:"businessId"`
+		Name       string         `json:"name"`
+		Fields     map[string]any `json:"fields"`
+	}
+	if err := util.ReadJSON(r, &body); err != nil {
+		util.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	businessID := crmBusinessID(r, body.BusinessID)
+	if businessID == "" {
+		util.WriteError(w, http.StatusBadRequest, "businessId is required")
+		return
+	}
+	if body.Name == "" && len(body.Fields) == 0 {
+		util.WriteError(w, http.StatusBadRequest, "name or fields is required")
+		return
+	}
+
+	view, err := h.service.UpdateDeal(r.Context(), &app.UpdateCRMDealRequest{
+		BusinessID: businessID,
+		DealID:     id,
+		Name:       body.Name,
+		Fields:     body.Fields,
+	})
+	if err != nil {
+		writeCRMError(w, err)
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, view)
+}
+
+// crmBusinessID returns the business of a CRM request: the X-Business-Id
+// header, else the businessId query parameter, else fromBody
+func crmBusinessID(r *http.Request, fromBody string) string {
+	if businessID := r.Header.Get("X-Business-Id"); businessID != "" {
+		return businessID
+	}
+	if businessID := r.URL.Query().Get("businessId"); businessID != "" {
+		return businessID
+	}
+	return fromBody
+}
+
+// writeCRMError maps built-in CRM errors to HTTP status codes
+func writeCRMError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrInvalidCRMRequest):
+		util.WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, app.ErrBuiltinCRMDisabled):
+		util.WriteError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, ports.ErrCRMDealNotFound):
+		util.WriteError(w, http.StatusNotFound, "deal not found")
+	case errors.Is(err, fs.ErrNotExist):
+		util.WriteError(w, http.StatusNotFound, "business not found")
+	default:
+		util.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
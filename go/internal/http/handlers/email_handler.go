@@ -14,7 +14,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/bizops360/go-api/internal/config"
 	"github.com/bizops360/go-api/internal/domain"
 	"github.com/bizops360/go-api/internal/infra/email"
 	"github.com/bizops360/go-api/internal/infra/firestore"
@@ -44,21 +43,22 @@ type EmailHandler struct {
 	geocodingService      *geo.GeocodingService
 	distanceMatrixService *geo.DistanceMatrixService
 	weatherService        *weather.WeatherService
-	businessLoader        *config.BusinessLoader
+	businesses            ports.BusinessesRepo
 	pdfService            *pdf.Service
 	logger                *slog.Logger
 }
 
 // NewEmailHandler creates a new email handler
 func NewEmailHandler(logger *slog.Logger) *EmailHandler {
-	return NewEmailHandlerWithBusinessLoader(logger, nil)
+	return NewEmailHandlerWithBusinesses(logger, nil)
 }
 
-// NewEmailHandlerWithBusinessLoader creates a new email handler with business loader
-func NewEmailHandlerWithBusinessLoader(logger *slog.Logger, businessLoader *config.BusinessLoader) *EmailHandler {
+// NewEmailHandlerWithBusinesses creates a new email handler that reads business
+// configs from businesses
+func NewEmailHandlerWithBusinesses(logger *slog.Logger, businesses ports.BusinessesRepo) *EmailHandler {
 	handler := &EmailHandler{
-		logger:         logger,
-		businessLoader: businessLoader,
+		logger:     logger,
+		businesses: businesses,
 	}
 
 	// Try to use email service client first (if EMAIL_SERVICE_URL is set)
@@ -778,12 +778,12 @@ func (h *EmailHandler) HandleQuoteEmail(w http.ResponseWriter, r *http.Request)
 		var originLat, originLng, radiusMiles float64
 		var originAddress string
 
-		// Try to get business config if businessLoader is available
+		// Try to get business config if businesses is available
 		// Default to "stlpartyhelpers" if no business ID in request
 		businessID := "stlpartyhelpers" // Default business ID
-		if h.businessLoader != nil {
+		if h.businesses != nil {
 			var businessConfig *domain.BusinessConfig
-			businessConfig, err := h.businessLoader.LoadBusiness(r.Context(), businessID)
+			businessConfig, err := h.businesses.GetByID(r.Context(), businessID)
 			if err == nil && businessConfig != nil {
 				// Get location from business config
 				lat, lng, radius, err := geo.LocationFromBusinessConfig(r.Context(), &businessConfig.Location, h.geocodingService)
@@ -955,8 +955,8 @@ func (h *EmailHandler) HandleQuoteEmail(w http.ResponseWriter, r *http.Request)
 		// Get default template from business config
 		businessID := "stlpartyhelpers" // Default business ID
 		defaultTemplate := "original"   // Fallback default
-		if h.businessLoader != nil {
-			if businessConfig, err := h.businessLoader.LoadBusiness(r.Context(), businessID); err == nil && businessConfig != nil {
+		if h.businesses != nil {
+			if businessConfig, err := h.businesses.GetByID(r.Context(), businessID); err == nil && businessConfig != nil {
 				if businessConfig.Templates.EmailTemplateSettings.DefaultTemplate != "" {
 					defaultTemplate = businessConfig.Templates.EmailTemplateSettings.DefaultTemplate
 				}
@@ -982,8 +982,8 @@ func (h *EmailHandler) HandleQuoteEmail(w http.ResponseWriter, r *http.Request)
 		// Load business config for contact info
 		businessID := "stlpartyhelpers"
 		var businessConfig *domain.BusinessConfig
-		if h.businessLoader != nil {
-			if config, err := h.businessLoader.LoadBusiness(r.Context(), businessID); err == nil {
+		if h.businesses != nil {
+			if config, err := h.businesses.GetByID(r.Context(), businessID); err == nil {
 				businessConfig = config
 			}
 		}
@@ -1009,8 +1009,8 @@ func (h *EmailHandler) HandleQuoteEmail(w http.ResponseWriter, r *http.Request)
 		// Load business config for contact info
 		businessID := "stlpartyhelpers"
 		var businessConfig *domain.BusinessConfig
-		if h.businessLoader != nil {
-			if config, err := h.businessLoader.LoadBusiness(r.Context(), businessID); err == nil {
+		if h.businesses != nil {
+			if config, err := h.businesses.GetByID(r.Context(), businessID); err == nil {
 				businessConfig = config
 			}
 		}
@@ -1359,8 +1359,8 @@ func (h *EmailHandler) HandleQuoteEmailPreview(w http.ResponseWriter, r *http.Re
 		// Get default template from business config
 		businessID := "stlpartyhelpers" // Default business ID
 		defaultTemplate := "original"   // Fallback default
-		if h.businessLoader != nil {
-			if businessConfig, err := h.businessLoader.LoadBusiness(r.Context(), businessID); err == nil && businessConfig != nil {
+		if h.businesses != nil {
+			if businessConfig, err := h.businesses.GetByID(r.Context(), businessID); err == nil && businessConfig != nil {
 				if businessConfig.Templates.EmailTemplateSettings.DefaultTemplate != "" {
 					defaultTemplate = businessConfig.Templates.EmailTemplateSettings.DefaultTemplate
 				}
@@ -1386,8 +1386,8 @@ func (h *EmailHandler) HandleQuoteEmailPreview(w http.ResponseWriter, r *http.Re
 		// Load business config for contact info
 		businessID := "stlpartyhelpers"
 		var businessConfig *domain.BusinessConfig
-		if h.businessLoader != nil {
-			if config, err := h.businessLoader.LoadBusiness(r.Context(), businessID); err == nil {
+		if h.businesses != nil {
+			if config, err := h.businesses.GetByID(r.Context(), businessID); err == nil {
 				businessConfig = config
 			}
 		}
@@ -1413,8 +1413,8 @@ func (h *EmailHandler) HandleQuoteEmailPreview(w http.ResponseWriter, r *http.Re
 		// Load business config for contact info
 		businessID := "stlpartyhelpers"
 		var businessConfig *domain.BusinessConfig
-		if h.businessLoader != nil {
-			if config, err := h.businessLoader.LoadBusiness(r.Context(), businessID); err == nil {
+		if h.businesses != nil {
+			if config, err := h.businesses.GetByID(r.Context(), businessID); err == nil {
 				businessConfig = config
 			}
 		}
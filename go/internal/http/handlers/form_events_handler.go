@@ -1,20 +1,23 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/bizops360/go-api/internal/app"
+	"github.com/bizops360/go-api/internal/domain"
 	"github.com/bizops360/go-api/internal/util"
 )
 
 // FormEventsHandler handles POST /v1/form-events
 type FormEventsHandler struct {
-	service *app.FormEventsService
+	service     *app.FormEventsService
+	idempotency *Idempotency
 }
 
 // NewFormEventsHandler creates a new form events handler
-func NewFormEventsHandler(service *app.FormEventsService) *FormEventsHandler {
-	return &FormEventsHandler{service: service}
+func NewFormEventsHandler(service *app.FormEventsService, idempotency *Idempotency) *FormEventsHandler {
+	return &FormEventsHandler{service: service, idempotency: idempotency}
 }
 
 // ServeHTTP handles the HTTP request
@@ -32,6 +35,7 @@ func (h *FormEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		BusinessID  string         `json:"businessId"`
 		PipelineKey string         `json:"pipelineKey"`
 		DryRun      bool           `json:"dryRun"`
+		Async       bool           `json:"async"`
 		Options     map[string]any `json:"options"`
 		Fields      map[string]any `json:"fields"`
 	}
@@ -61,11 +65,17 @@ func (h *FormEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		source = "form"
 	}
 
+	// A dry run returns a preview of the side effects in result.plan
 	dryRun := body.DryRun
-	if r.Header.Get("X-Dry-Run") == "true" {
+	if r.Header.Get("X-Dry-Run") == "true" || r.URL.Query().Get("dryRun") == "true" {
 		dryRun = true
 	}
 
+	async := body.Async
+	if r.Header.Get("X-Async") == "true" {
+		async = true
+	}
+
 	// Build request
 	req := &app.FormEventsRequest{
 		BusinessID:  businessID,
@@ -75,16 +85,44 @@ func (h *FormEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Fields:      body.Fields,
 		Options:     body.Options,
 		RequestID:   requestID,
+		Async:       async,
 	}
 
-	// Execute pipeline
-	result, err := h.service.Run(ctx, req)
-	if err != nil {
-		util.WriteError(w, http.StatusInternalServerError, err.Error())
-		return
+	// Retries of the same lead get the first result instead of running again
+	scope := "form-events"
+	if dryRun {
+		scope += "-dry-run"
 	}
+	email := firstString(body.Fields, "email", "email_address")
+	eventDate := firstString(body.Fields, "eventDate", "event_date")
+	key := idempotencyKey(r, scope, businessID, email, eventDate, source+"/"+pipelineKey)
+
+	h.idempotency.Serve(w, r, key, func(w http.ResponseWriter) {
+		// Execute pipeline
+		result, err := h.service.Run(ctx, req)
+		if err != nil {
+			writePipelineError(w, err)
+			return
+		}
+		writePipelineResult(w, result)
+	})
+}
 
-	// Return result
+// writePipelineResult writes a pipeline result (202 when the job was queued)
+func writePipelineResult(w http.ResponseWriter, result *domain.PipelineResult) {
+	if result.Status == "pending" {
+		util.WriteJSON(w, http.StatusAccepted, result)
+		return
+	}
 	util.WriteJSON(w, http.StatusOK, result)
 }
 
+// writePipelineError maps a pipeline service error to an HTTP error response
+func writePipelineError(w http.ResponseWriter, err error) {
+	if errors.Is(err, app.ErrJobQueueFull) || errors.Is(err, app.ErrWorkerPoolClosed) {
+		w.Header().Set("Retry-After", "5")
+		util.WriteError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	util.WriteError(w, http.StatusInternalServerError, err.Error())
+}
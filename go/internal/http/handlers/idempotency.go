@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// IdempotencyKeyHeader is the request header carrying a client-chosen idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyLease is how long a request holds its key before a crashed
+// instance's claim lapses; it must outlast the server's write timeout
+const idempotencyLease = 5 * time.Minute
+
+// Idempotency answers repeated pipeline requests with the stored response of
+// the first one, so that webhook retries (e.g. from Zapier) do not send the
+// same emails or create the same calendar events twice
+type Idempotency struct {
+	store ports.IdempotencyStore
+	ttl   time.Duration
+}
+
+// NewIdempotency creates an idempotency guard that keeps responses for ttl
+func NewIdempotency(store ports.IdempotencyStore, ttl time.Duration) *Idempotency {
+	return &Idempotency{store: store, ttl: ttl}
+}
+
+// idempotencyKey returns the key a request is deduplicated by within scope
+// (the endpoint). The Idempotency-Key header wins; without it the key is
+// derived from the lead's email and event date and the request source. It
+// returns "" when neither is available.
+func idempotencyKey(r *http.Request, scope, businessID, email, eventDate, source string) string {
+	parts := []string{businessID}
+	if header := strings.TrimSpace(r.Header.Get(IdempotencyKeyHeader)); header != "" {
+		parts = append(parts, "header", header)
+	} else {
+		email = strings.ToLower(strings.TrimSpace(email))
+		eventDate = strings.TrimSpace(eventDate)
+		if email == "" || eventDate == "" {
+			return ""
+		}
+		parts = append(parts, "derived", email, eventDate, source)
+	}
+
+	return hashedIdempotencyKey(scope, parts...)
+}
+
+// hashedIdempotencyKey returns the key for parts within scope, hashed so keys
+// are safe to use as document IDs whatever the client sends
+func hashedIdempotencyKey(scope string, parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return scope + ":" + hex.EncodeToString(sum[:16])
+}
+
+// firstString returns the first non-empty string value among keys
+func firstString(fields map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := fields[key].(string); ok && strings.TrimSpace(s) != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// Serve runs handle at most once per key while its response is stored. A
+// repeat gets the stored response with the Idempotent-Replayed header, and a
+// repeat arriving while the first request is still running gets 409. Server
+// errors are not stored so the request can be retried. An empty key, or a nil
+// guard, runs handle directly.
+func (i *Idempotency) Serve(w http.ResponseWriter, r *http.Request, key string, handle func(w http.ResponseWriter)) {
+	if i == nil || key == "" {
+		handle(w)
+		return
+	}
+
+	stored, err := i.store.Begin(r.Context(), key, idempotencyLease)
+	if errors.Is(err, ports.ErrIdempotencyKeyInFlight) {
+		util.WriteError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		// Fail open: a duplicate is better than dropping a lead
+		handle(w)
+		return
+	}
+	if stored != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(stored.StatusCode)
+		w.Write(stored.Body)
+		return
+	}
+
+	recorder := &recordingResponseWriter{ResponseWriter: w}
+	handle(recorder)
+
+	// Keep the outcome even if the client has gone away
+	ctx := context.WithoutCancel(r.Context())
+	if recorder.statusCode >= http.StatusInternalServerError {
+		i.store.Release(ctx, key)
+		return
+	}
+	i.store.Complete(ctx, key, &ports.IdempotentResponse{
+		StatusCode: recorder.statusCode,
+		Body:       recorder.body.Bytes(),
+	}, i.ttl)
+}
+
+// recordingResponseWriter keeps a copy of the response it writes through
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rw *recordingResponseWriter) WriteHeader(code int) {
+	rw.statusCode = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recordingResponseWriter) Write(b []byte) (int, error) {
+	if rw.statusCode == 0 {
+		rw.statusCode = http.StatusOK
+	}
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bizops360/go-api/internal/app"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// Page sizes for GET /v1/jobs
+const (
+	defaultJobsListLimit = 50
+	maxJobsListLimit     = 200
+)
+
+// JobsHandler handles the job status, resume and replay endpoints
+type JobsHandler struct {
+	jobsRepo ports.JobsRepo
+	service  *app.JobsService
+}
+
+// NewJobsHandler creates a new jobs handler
+func NewJobsHandler(jobsRepo ports.JobsRepo, service *app.JobsService) *JobsHandler {
+	return &JobsHandler{jobsRepo: jobsRepo, service: service}
+}
+
+// HandleGet handles GET /v1/jobs/{id}
+func (h *JobsHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id := r.PathValue("id")
+	if id == "" {
+		util.WriteError(w, http.StatusBadRequest, "job ID is required")
+		return
+	}
+
+	job, err := h.jobsRepo.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ports.ErrJobNotFound) {
+			util.WriteError(w, http.StatusNotFound, "job not found")
+			return
+		}
+		util.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, job)
+}
+
+// HandleList handles GET /v1/jobs?businessId=
+//
+// Optional filters: status, pipelineKey, from and to (RFC3339 timestamps or
+// YYYY-MM-DD dates; "to" is exclusive), limit and cursor (nextCursor from the
+// previous page).
+func (h *JobsHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	params := r.URL.Query()
+
+	businessID := r.Header.Get("X-Business-Id")
+	if businessID == "" {
+		businessID = params.Get("businessId")
+	}
+	if businessID == "" {
+		util.WriteError(w, http.StatusBadRequest, "businessId is required")
+		return
+	}
+
+	query := ports.JobQuery{
+		BusinessID:  businessID,
+		Status:      params.Get("status"),
+		PipelineKey: params.Get("pipelineKey"),
+		Limit:       defaultJobsListLimit,
+		Cursor:      params.Get("cursor"),
+	}
+
+	if limitParam := params.Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed <= 0 || parsed > maxJobsListLimit {
+			util.WriteError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxJobsListLimit))
+			return
+		}
+		query.Limit = parsed
+	}
+
+	var err error
+	if query.CreatedAfter, err = parseJobsTimeParam(params.Get("from")); err != nil {
+		util.WriteError(w, http.StatusBadRequest, "invalid from: "+err.Error())
+		return
+	}
+	if query.CreatedBefore, err = parseJobsTimeParam(params.Get("to")); err != nil {
+		util.WriteError(w, http.StatusBadRequest, "invalid to: "+err.Error())
+		return
+	}
+
+	page, err := h.jobsRepo.List(r.Context(), query)
+	if err != nil {
+		if errors.Is(err, ports.ErrInvalidCursor) {
+			util.WriteError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		util.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, map[string]any{
+		"businessId": businessID,
+		"jobs":       page.Jobs,
+		"count":      len(page.Jobs),
+		"nextCursor": page.NextCursor,
+	})
+}
+
+// HandleResume handles POST /v1/jobs/{id}/resume
+//
+// The failed job continues from its failed step; steps that already succeeded
+// are not executed again. Body (optional): {"async": true}
+func (h *JobsHandler) HandleResume(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var body struct {
+		Async bool `json:"async"`
+	}
+	if r.ContentLength != 0 {
+		if err := util.ReadJSON(r, &body); err != nil {
+			util.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+	}
+
+	result, err := h.service.Resume(r.Context(), &app.ResumeJobRequest{
+		JobID: r.PathValue("id"),
+		Async: body.Async || r.Header.Get("X-Async") == "true",
+	})
+	writeJobRunResponse(w, result, err)
+}
+
+// HandleReplay handles POST /v1/jobs/{id}/replay
+//
+// The job's input is run through the current pipeline definition as a new job.
+// Body (optional): {"dryRun": false, "async": true}; dryRun defaults to true.
+func (h *JobsHandler) HandleReplay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	body := struct {
+		DryRun bool `json:"dryRun"`
+		Async  bool `json:"async"`
+	}{DryRun: true}
+	if r.ContentLength != 0 {
+		if err := util.ReadJSON(r, &body); err != nil {
+			util.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+	}
+
+	result, err := h.service.Replay(r.Context(), &app.ReplayJobRequest{
+		JobID:     r.PathValue("id"),
+		RequestID: util.GetRequestID(r.Context()),
+		DryRun:    body.DryRun,
+		Async:     body.Async || r.Header.Get("X-Async") == "true",
+	})
+	writeJobRunResponse(w, result, err)
+}
+
+// writeJobRunResponse writes the result of a resumed or replayed job
+func writeJobRunResponse(w http.ResponseWriter, result *domain.PipelineResult, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrJobNotFound):
+			util.WriteError(w, http.StatusNotFound, "job not found")
+		case errors.Is(err, app.ErrJobNotResumable):
+			util.WriteError(w, http.StatusConflict, err.Error())
+		default:
+			writePipelineError(w, err)
+		}
+		return
+	}
+
+	if result.Status == "pending" {
+		util.WriteJSON(w, http.StatusAccepted, result)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, result)
+}
+
+// parseJobsTimeParam parses an RFC3339 timestamp or a YYYY-MM-DD date (UTC midnight)
+func parseJobsTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC3339 timestamp or YYYY-MM-DD date")
+	}
+	return t, nil
+}
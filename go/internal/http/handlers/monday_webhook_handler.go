@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/bizops360/go-api/internal/app"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/infra/monday"
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// maxMondayWebhookBody limits the webhook bodies read; events are a few KB
+const maxMondayWebhookBody = 1 << 20
+
+// MondayWebhookHandler handles POST /v1/integrations/monday/{businessId}:
+// Monday.com board webhooks whose column changes fire pipeline triggers
+type MondayWebhookHandler struct {
+	businesses  ports.BusinessesRepo
+	triggers    *app.TriggersService
+	idempotency *Idempotency
+	logger      *slog.Logger
+}
+
+// NewMondayWebhookHandler creates a new Monday.com webhook handler
+func NewMondayWebhookHandler(businesses ports.BusinessesRepo, triggers *app.TriggersService, idempotency *Idempotency, logger *slog.Logger) *MondayWebhookHandler {
+	return &MondayWebhookHandler{businesses: businesses, triggers: triggers, idempotency: idempotency, logger: logger}
+}
+
+// ServeHTTP verifies the request, answers the challenge Monday.com sends when
+// the webhook is created, and runs the trigger the business maps the column
+// change to. Changes without a trigger are acknowledged and ignored, so that
+// Monday.com does not retry them.
+func (h *MondayWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ctx := r.Context()
+	businessID := r.PathValue("businessId")
+	business, err := h.businesses.GetByID(ctx, businessID)
+	if errors.Is(err, ports.ErrBusinessNotFound) {
+		util.WriteError(w, http.StatusNotFound, "business not found")
+		return
+	}
+	if err != nil {
+		util.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	secret := ""
+	if business.Monday.WebhookSecretEnv != "" {
+		secret = os.Getenv(business.Monday.WebhookSecretEnv)
+	}
+	if secret == "" {
+		util.WriteError(w, http.StatusForbidden, "monday webhooks are not configured for this business (set monday.webhookSecretEnv)")
+		return
+	}
+	if err := monday.VerifyWebhook(r, secret, time.Now()); err != nil {
+		h.logger.Warn("rejected monday webhook", "businessId", businessID, "error", err)
+		util.WriteError(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMondayWebhookBody))
+	if err != nil {
+		util.WriteError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+	var webhook monday.WebhookRequest
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		util.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if webhook.Challenge != "" {
+		util.WriteJSON(w, http.StatusOK, map[string]any{"challenge": webhook.Challenge})
+		return
+	}
+	if webhook.Event == nil {
+		util.WriteError(w, http.StatusBadRequest, "event is required")
+		return
+	}
+
+	event := webhook.Event
+	label := event.Label()
+	triggerKey, ok := business.Monday.TriggerFor(event.BoardID, event.ColumnID, event.ColumnTitle, label)
+	if !event.IsColumnChange() || !ok {
+		h.logger.Debug("ignored monday webhook event",
+			"businessId", businessID,
+			"type", event.Type,
+			"boardId", event.BoardID,
+			"columnId", event.ColumnID,
+			"value", label,
+		)
+		util.WriteJSON(w, http.StatusOK, map[string]any{"ok": true, "ignored": true})
+		return
+	}
+
+	boardID, itemID := event.BoardID, event.ItemID
+	req := &app.TriggerRequest{
+		BusinessID: businessID,
+		TriggerKey: triggerKey,
+		Source:     "monday",
+		Resource: &domain.ResourceContext{
+			Type:    "monday_item",
+			BoardID: &boardID,
+			ItemID:  &itemID,
+			Data: map[string]any{
+				"columnId":      event.ColumnID,
+				"columnTitle":   event.ColumnTitle,
+				"value":         label,
+				"previousValue": event.PreviousLabel(),
+				"userId":        event.UserID,
+			},
+		},
+		Payload: map[string]any{
+			"itemName": event.ItemName,
+			"status":   label,
+		},
+		RequestID: util.GetRequestID(ctx),
+		// Monday.com retries slow webhooks; the job is followed at /v1/jobs/{id}
+		Async: true,
+	}
+
+	// Monday.com retries an event with the same trigger UUID
+	key := ""
+	if event.TriggerUUID != "" {
+		key = hashedIdempotencyKey("monday", businessID, event.TriggerUUID)
+	}
+	h.idempotency.Serve(w, r, key, func(w http.ResponseWriter) {
+		result, err := h.triggers.Run(ctx, req)
+		if err != nil {
+			writePipelineError(w, err)
+			return
+		}
+		writePipelineResult(w, result)
+	})
+}
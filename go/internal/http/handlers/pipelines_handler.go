@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+
+	"github.com/bizops360/go-api/internal/app"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// PipelinesHandler handles the pipeline documentation endpoints
+type PipelinesHandler struct {
+	service *app.PipelineDocsService
+}
+
+// NewPipelinesHandler creates a new pipelines handler
+func NewPipelinesHandler(service *app.PipelineDocsService) *PipelinesHandler {
+	return &PipelinesHandler{service: service}
+}
+
+// HandleList handles GET /v1/pipelines
+func (h *PipelinesHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	docs, err := h.service.List(r.Context())
+	if err != nil {
+		util.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, map[string]any{"pipelines": docs})
+}
+
+// HandleGet handles GET /v1/pipelines/{key}
+func (h *PipelinesHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	doc, err := h.service.Get(r.Context(), r.PathValue("key"))
+	if err != nil {
+		writePipelineDocsError(w, err)
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, doc)
+}
+
+// HandleGraph handles GET /v1/pipelines/{key}/graph?format=mermaid|dot,
+// returning the flow as Mermaid (the default) or Graphviz DOT source
+func (h *PipelinesHandler) HandleGraph(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = app.GraphFormatMermaid
+	}
+	graph, err := h.service.Render(r.Context(), r.PathValue("key"), format)
+	if err != nil {
+		writePipelineDocsError(w, err)
+		return
+	}
+
+	contentType := "text/plain; charset=utf-8"
+	if format == app.GraphFormatDOT {
+		contentType = "text/vnd.graphviz; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(graph))
+}
+
+// writePipelineDocsError maps pipeline docs errors to HTTP status codes
+func writePipelineDocsError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrUnknownGraphFormat):
+		util.WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, fs.ErrNotExist):
+		util.WriteError(w, http.StatusNotFound, "pipeline not found")
+	default:
+		util.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bizops360/go-api/internal/app"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// Page sizes for GET /v1/schedules
+const (
+	defaultSchedulesLimit = 20
+	maxSchedulesLimit     = 200
+)
+
+// SchedulesHandler lists upcoming scheduled pipeline runs
+type SchedulesHandler struct {
+	scheduler *app.Scheduler
+}
+
+// NewSchedulesHandler creates a new schedules handler
+func NewSchedulesHandler(scheduler *app.Scheduler) *SchedulesHandler {
+	return &SchedulesHandler{scheduler: scheduler}
+}
+
+// HandleUpcoming handles GET /v1/schedules?businessId=&limit=
+//
+// Without a businessId the upcoming runs of every business are listed.
+func (h *SchedulesHandler) HandleUpcoming(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	params := r.URL.Query()
+
+	businessID := r.Header.Get("X-Business-Id")
+	if businessID == "" {
+		businessID = params.Get("businessId")
+	}
+
+	limit := defaultSchedulesLimit
+	if limitParam := params.Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed <= 0 || parsed > maxSchedulesLimit {
+			util.WriteError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxSchedulesLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := h.scheduler.Upcoming(r.Context(), businessID, time.Now(), limit)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			util.WriteError(w, http.StatusNotFound, "business not found")
+			return
+		}
+		util.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, map[string]any{
+		"businessId": businessID,
+		"runs":       runs,
+	})
+}
@@ -2,27 +2,28 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"os"
 	"time"
 
-	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
 	"github.com/bizops360/go-api/internal/util"
-	"gopkg.in/yaml.v3"
 )
 
 // SettingsHandler handles settings-related endpoints
 type SettingsHandler struct {
-	businessLoader *config.BusinessLoader
-	logger         *slog.Logger
+	businesses ports.BusinessesRepo
+	logger     *slog.Logger
 }
 
 // NewSettingsHandler creates a new settings handler
-func NewSettingsHandler(businessLoader *config.BusinessLoader, logger *slog.Logger) *SettingsHandler {
+func NewSettingsHandler(businesses ports.BusinessesRepo, logger *slog.Logger) *SettingsHandler {
 	return &SettingsHandler{
-		businessLoader: businessLoader,
-		logger:         logger,
+		businesses: businesses,
+		logger:     logger,
 	}
 }
 
@@ -50,8 +51,8 @@ func (h *SettingsHandler) HandleGetEmailTemplateSettings(w http.ResponseWriter,
 	settings.AvailableTemplates = []string{"original", "apple_style"}
 	settings.DefaultTemplate = "original" // Fallback
 
-	if h.businessLoader != nil {
-		if businessConfig, err := h.businessLoader.LoadBusiness(r.Context(), businessID); err == nil && businessConfig != nil {
+	if h.businesses != nil {
+		if businessConfig, err := h.businesses.GetByID(r.Context(), businessID); err == nil && businessConfig != nil {
 			if businessConfig.Templates.EmailTemplateSettings.DefaultTemplate != "" {
 				settings.DefaultTemplate = businessConfig.Templates.EmailTemplateSettings.DefaultTemplate
 			}
@@ -65,7 +66,7 @@ func (h *SettingsHandler) HandleGetEmailTemplateSettings(w http.ResponseWriter,
 }
 
 // HandleUpdateEmailTemplateSettings handles POST /api/settings/email-template
-// This updates the YAML config file (dev only)
+// This updates the business config through the businesses repo
 func (h *SettingsHandler) HandleUpdateEmailTemplateSettings(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
@@ -102,80 +103,41 @@ func (h *SettingsHandler) HandleUpdateEmailTemplateSettings(w http.ResponseWrite
 	}
 
 	// Load current config
-	if h.businessLoader == nil {
-		util.WriteError(w, http.StatusInternalServerError, "business loader not available")
+	if h.businesses == nil {
+		util.WriteError(w, http.StatusInternalServerError, "business config not available")
 		return
 	}
 
-	businessConfig, err := h.businessLoader.LoadBusiness(r.Context(), businessID)
+	businessConfig, err := h.businesses.GetByID(r.Context(), businessID)
 	if err != nil {
+		if errors.Is(err, ports.ErrBusinessNotFound) {
+			util.WriteError(w, http.StatusNotFound, "business not found")
+			return
+		}
 		util.WriteError(w, http.StatusInternalServerError, "failed to load business config: "+err.Error())
 		return
 	}
 
-	// Update template settings
-	businessConfig.Templates.EmailTemplateSettings.DefaultTemplate = body.DefaultTemplate
-	if len(businessConfig.Templates.EmailTemplateSettings.AvailableTemplates) == 0 {
-		businessConfig.Templates.EmailTemplateSettings.AvailableTemplates = validTemplates
-	}
-
-	// Save to YAML file (dev only - in production this should use a database or config service)
-	// Get config path from business loader
-	configPath := h.businessLoader.GetBusinessConfigPath(businessID)
-	if configPath == "" {
-		util.WriteError(w, http.StatusInternalServerError, "config path not available")
-		return
-	}
-
-	// Read existing YAML to preserve other settings
-	configBytes, err := os.ReadFile(configPath)
-	if err != nil {
-		util.WriteError(w, http.StatusInternalServerError, "failed to read config file: "+err.Error())
-		return
-	}
-
-	// Parse YAML
-	var configMap map[string]interface{}
-	if err := yaml.Unmarshal(configBytes, &configMap); err != nil {
-		util.WriteError(w, http.StatusInternalServerError, "failed to parse config file: "+err.Error())
-		return
+	// Update template settings on a copy, so a failed save leaves the loaded
+	// config untouched
+	updated := *businessConfig
+	updated.Templates.EmailTemplateSettings.DefaultTemplate = body.DefaultTemplate
+	if len(updated.Templates.EmailTemplateSettings.AvailableTemplates) == 0 {
+		updated.Templates.EmailTemplateSettings.AvailableTemplates = validTemplates
 	}
 
-	// Update template settings in map
-	if templates, ok := configMap["templates"].(map[string]interface{}); ok {
-		if emailTemplateSettings, ok := templates["emailTemplateSettings"].(map[string]interface{}); ok {
-			emailTemplateSettings["defaultTemplate"] = body.DefaultTemplate
-		} else {
-			if templates["emailTemplateSettings"] == nil {
-				templates["emailTemplateSettings"] = make(map[string]interface{})
-			}
-			templates["emailTemplateSettings"].(map[string]interface{})["defaultTemplate"] = body.DefaultTemplate
-			templates["emailTemplateSettings"].(map[string]interface{})["availableTemplates"] = validTemplates
-		}
-	} else {
-		configMap["templates"] = map[string]interface{}{
-			"emailTemplateSettings": map[string]interface{}{
-				"defaultTemplate":    body.DefaultTemplate,
-				"availableTemplates": validTemplates,
-			},
+	// Save through the businesses repo (the YAML file, or the database in a
+	// database-backed deployment)
+	if err := h.businesses.Save(r.Context(), &updated); err != nil {
+		var invalid *domain.ValidationError
+		if errors.As(err, &invalid) {
+			util.WriteError(w, http.StatusUnprocessableEntity, err.Error())
+			return
 		}
-	}
-
-	// Write back to file
-	updatedBytes, err := yaml.Marshal(configMap)
-	if err != nil {
-		util.WriteError(w, http.StatusInternalServerError, "failed to marshal config: "+err.Error())
+		util.WriteError(w, http.StatusInternalServerError, "failed to save business config: "+err.Error())
 		return
 	}
 
-	if err := os.WriteFile(configPath, updatedBytes, 0644); err != nil {
-		util.WriteError(w, http.StatusInternalServerError, "failed to write config file: "+err.Error())
-		return
-	}
-
-	// Invalidate cache so changes take effect immediately
-	h.businessLoader.InvalidateCache(businessID)
-
 	// #region agent log
 	if f, err := os.OpenFile(GetLogPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err == nil {
 		json.NewEncoder(f).Encode(map[string]interface{}{
@@ -225,8 +187,8 @@ func (h *SettingsHandler) HandleGetAllSettings(w http.ResponseWriter, r *http.Re
 	}
 
 	// Load from config if available
-	if h.businessLoader != nil {
-		if businessConfig, err := h.businessLoader.LoadBusiness(r.Context(), businessID); err == nil && businessConfig != nil {
+	if h.businesses != nil {
+		if businessConfig, err := h.businesses.GetByID(r.Context(), businessID); err == nil && businessConfig != nil {
 			if businessConfig.Templates.EmailTemplateSettings.DefaultTemplate != "" {
 				settings["categories"].(map[string]interface{})["emailTemplates"].(map[string]interface{})["defaultTemplate"] = businessConfig.Templates.EmailTemplateSettings.DefaultTemplate
 			}
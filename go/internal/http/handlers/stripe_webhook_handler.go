@@ -17,6 +17,8 @@ type StripeWebhookHandler struct {
 	paymentsProvider ports.PaymentsProvider
 	emailClient      *email.EmailServiceClient
 	gmailSender      *email.GmailSender
+	businesses       ports.BusinessesRepo
+	notifiers        ports.BusinessNotifiers
 	logger           *slog.Logger
 }
 
@@ -25,12 +27,16 @@ func NewStripeWebhookHandler(
 	paymentsProvider ports.PaymentsProvider,
 	emailClient *email.EmailServiceClient,
 	gmailSender *email.GmailSender,
+	businesses ports.BusinessesRepo,
+	notifiers ports.BusinessNotifiers,
 	logger *slog.Logger,
 ) *StripeWebhookHandler {
 	return &StripeWebhookHandler{
 		paymentsProvider: paymentsProvider,
 		emailClient:      emailClient,
 		gmailSender:      gmailSender,
+		businesses:       businesses,
+		notifiers:        notifiers,
 		logger:           logger,
 	}
 }
@@ -172,9 +178,10 @@ func (h *StripeWebhookHandler) handleBookingDepositPaid(ctx context.Context, inv
 		}
 	}
 
+	h.notifyPaid(ctx, invoice, "Booking deposit paid")
+
 	// Here you could also:
 	// - Update CRM (Monday.com, etc.)
-	// - Send Slack notification
 	// - Update calendar event status
 	// - Trigger other workflows
 }
@@ -223,6 +230,8 @@ func (h *StripeWebhookHandler) handleFinalInvoicePaid(ctx context.Context, invoi
 		}
 	}
 
+	h.notifyPaid(ctx, invoice, "Final invoice paid")
+
 	// Here you could also:
 	// - Update CRM status to "Paid in Full"
 	// - Send receipt
@@ -231,6 +240,76 @@ func (h *StripeWebhookHandler) handleFinalInvoicePaid(ctx context.Context, invoi
 }
 
 // generateBookingDepositConfirmationEmail generates HTML for booking deposit confirmation
+// notifyPaid posts a paid invoice to the Slack channel of the business named
+// in its metadata. Invoices created before business_id was recorded belong to
+// the default business. Failures are logged: the payment is already recorded
+// and Stripe must still get its 200.
+func (h *StripeWebhookHandler) notifyPaid(ctx context.Context, invoice *StripeInvoiceObject, title string) {
+	if h.businesses == nil || h.notifiers == nil {
+		return
+	}
+
+	businessID := invoice.Metadata["business_id"]
+	if businessID == "" {
+		businessID = "stlpartyhelpers" // Default business ID
+	}
+	business, err := h.businesses.GetByID(ctx, businessID)
+	if err != nil {
+		h.logger.Warn("failed to load business for slack notification",
+			"error", err,
+			"business_id", businessID,
+			"invoice_id", invoice.ID,
+		)
+		return
+	}
+	if !business.Slack.Enabled {
+		return
+	}
+
+	notifier, err := h.notifiers.ForBusiness(business)
+	if err != nil {
+		h.logger.Warn("slack not available for paid invoice notification",
+			"error", err,
+			"business_id", businessID,
+			"invoice_id", invoice.ID,
+		)
+		return
+	}
+
+	customer := invoice.CustomerName
+	if customer == "" {
+		customer = invoice.CustomerEmail
+	}
+	fields := []ports.NotificationField{
+		{Title: "Amount", Value: "$" + formatCurrency(float64(invoice.AmountPaid)/100), Short: true},
+		{Title: "Invoice", Value: invoice.ID, Short: true},
+	}
+	if invoice.CustomerEmail != "" {
+		fields = append(fields, ports.NotificationField{Title: "Email", Value: invoice.CustomerEmail, Short: true})
+	}
+	if invoice.HostedInvoiceURL != "" {
+		fields = append(fields, ports.NotificationField{Title: "Invoice link", Value: invoice.HostedInvoiceURL})
+	}
+
+	if _, err := notifier.SendNotification(ctx, &ports.NotificationRequest{
+		Title:   title,
+		Message: fmt.Sprintf("%s paid $%s", customer, formatCurrency(float64(invoice.AmountPaid)/100)),
+		Color:   "good",
+		Fields:  fields,
+	}); err != nil {
+		h.logger.Error("failed to send paid invoice slack notification",
+			"error", err,
+			"business_id", businessID,
+			"invoice_id", invoice.ID,
+		)
+		return
+	}
+	h.logger.Info("paid invoice slack notification sent",
+		"business_id", businessID,
+		"invoice_id", invoice.ID,
+	)
+}
+
 func generateBookingDepositConfirmationEmail(name string, invoice *StripeInvoiceObject) string {
 	amountPaid := float64(invoice.AmountPaid) / 100
 	return `<!DOCTYPE html>
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+
+	"github.com/bizops360/go-api/internal/app"
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/util"
+)
+
+// TimersHandler handles the event-relative timer endpoints
+type TimersHandler struct {
+	service *app.TimersService
+}
+
+// NewTimersHandler creates a new timers handler
+func NewTimersHandler(service *app.TimersService) *TimersHandler {
+	return &TimersHandler{service: service}
+}
+
+// HandleTimers handles GET /v1/timers?businessId=&leadId= and POST /v1/timers
+func (h *TimersHandler) HandleTimers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleList(w, r)
+	case http.MethodPost:
+		h.handleSchedule(w, r)
+	default:
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (h *TimersHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	businessID := r.Header.Get("X-Business-Id")
+	if businessID == "" {
+		businessID = params.Get("businessId")
+	}
+	leadID := params.Get("leadId")
+	if businessID == "" || leadID == "" {
+		util.WriteError(w, http.StatusBadRequest, "businessId and leadId are required")
+		return
+	}
+
+	timers, err := h.service.List(r.Context(), businessID, leadID)
+	if err != nil {
+		util.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, map[string]any{
+		"businessId": businessID,
+		"leadId":     leadID,
+		"timers":     timers,
+	})
+}
+
+func (h *TimersHandler) handleSchedule(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		BusinessID string         `json:"businessId"`
+		LeadID     string         `json:"leadId"`
+		Key        string         `json:"key"`
+		TriggerKey string         `json:"triggerKey"`
+		EventDate  string         `json:"eventDate"`
+		OffsetDays int            `json:"offsetDays"`
+		At         string         `json:"at"`
+		Payload    map[string]any `json:"payload"`
+	}
+	if err := util.ReadJSON(r, &body); err != nil {
+		util.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	businessID := r.Header.Get("X-Business-Id")
+	if businessID == "" {
+		businessID = body.BusinessID
+	}
+	if businessID == "" {
+		util.WriteError(w, http.StatusBadRequest, "businessId is required")
+		return
+	}
+
+	timer, err := h.service.Schedule(r.Context(), &app.ScheduleTimerRequest{
+		BusinessID: businessID,
+		LeadID:     body.LeadID,
+		Key:        body.Key,
+		TriggerKey: body.TriggerKey,
+		EventDate:  body.EventDate,
+		OffsetDays: body.OffsetDays,
+		At:         body.At,
+		Payload:    body.Payload,
+	})
+	if err != nil {
+		writeTimerError(w, err)
+		return
+	}
+
+	util.WriteJSON(w, http.StatusCreated, timer)
+}
+
+// HandleReschedule handles POST /v1/timers/reschedule, moving a lead's pending
+// timers to a new event date
+func (h *TimersHandler) HandleReschedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var body struct {
+		BusinessID string `json:"businessId"`
+		LeadID     string `json:"leadId"`
+		EventDate  string `json:"eventDate"`
+	}
+	if err := util.ReadJSON(r, &body); err != nil {
+		util.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	businessID := r.Header.Get("X-Business-Id")
+	if businessID == "" {
+		businessID = body.BusinessID
+	}
+	if businessID == "" || body.LeadID == "" {
+		util.WriteError(w, http.StatusBadRequest, "businessId and leadId are required")
+		return
+	}
+
+	timers, err := h.service.Reschedule(r.Context(), businessID, body.LeadID, body.EventDate)
+	if err != nil {
+		writeTimerError(w, err)
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, map[string]any{
+		"businessId":  businessID,
+		"leadId":      body.LeadID,
+		"rescheduled": timers,
+	})
+}
+
+// HandleCancel handles POST /v1/timers/{id}/cancel
+func (h *TimersHandler) HandleCancel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		util.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	timer, err := h.service.Cancel(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeTimerError(w, err)
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, timer)
+}
+
+// writeTimerError maps timer service errors to HTTP status codes
+func writeTimerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrInvalidTimer):
+		util.WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, ports.ErrTimerNotFound):
+		util.WriteError(w, http.StatusNotFound, "timer not found")
+	case errors.Is(err, fs.ErrNotExist):
+		util.WriteError(w, http.StatusNotFound, "business not found")
+	case errors.Is(err, app.ErrTimerNotScheduled):
+		util.WriteError(w, http.StatusConflict, err.Error())
+	default:
+		util.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
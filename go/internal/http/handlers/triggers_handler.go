@@ -10,12 +10,13 @@ import (
 
 // TriggersHandler handles POST /v1/triggers
 type TriggersHandler struct {
-	service *app.TriggersService
+	service     *app.TriggersService
+	idempotency *Idempotency
 }
 
 // NewTriggersHandler creates a new triggers handler
-func NewTriggersHandler(service *app.TriggersService) *TriggersHandler {
-	return &TriggersHandler{service: service}
+func NewTriggersHandler(service *app.TriggersService, idempotency *Idempotency) *TriggersHandler {
+	return &TriggersHandler{service: service, idempotency: idempotency}
 }
 
 // ServeHTTP handles the HTTP request
@@ -36,6 +37,7 @@ func (h *TriggersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		PipelineKey string                 `json:"pipelineKey"`
 		Resource    *domain.ResourceContext `json:"resource"`
 		Payload     map[string]any         `json:"payload"`
+		Async       bool                   `json:"async"`
 	}
 
 	if err := util.ReadJSON(r, &body); err != nil {
@@ -73,6 +75,11 @@ func (h *TriggersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	dryRun := r.Header.Get("X-Dry-Run") == "true"
 
+	async := body.Async
+	if r.Header.Get("X-Async") == "true" {
+		async = true
+	}
+
 	// Build request
 	req := &app.TriggerRequest{
 		BusinessID:  businessID,
@@ -83,16 +90,26 @@ func (h *TriggersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Payload:     body.Payload,
 		DryRun:      dryRun,
 		RequestID:   requestID,
+		Async:       async,
 	}
 
-	// Execute pipeline
-	result, err := h.service.Run(ctx, req)
-	if err != nil {
-		util.WriteError(w, http.StatusInternalServerError, err.Error())
-		return
+	// Retries of the same trigger for a lead get the first result
+	scope := "triggers"
+	if dryRun {
+		scope += "-dry-run"
 	}
-
-	// Return result
-	util.WriteJSON(w, http.StatusOK, result)
+	email := firstString(body.Payload, "email", "email_address")
+	eventDate := firstString(body.Payload, "eventDate", "event_date")
+	key := idempotencyKey(r, scope, businessID, email, eventDate, source+"/"+triggerKey+"/"+pipelineKey)
+
+	h.idempotency.Serve(w, r, key, func(w http.ResponseWriter) {
+		// Execute pipeline
+		result, err := h.service.Run(ctx, req)
+		if err != nil {
+			writePipelineError(w, err)
+			return
+		}
+		writePipelineResult(w, result)
+	})
 }
 
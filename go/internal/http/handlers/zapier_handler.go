@@ -26,13 +26,15 @@ type ZapierHandler struct {
 	calendarService  *calendar.CalendarService
 	emailClient      *email.EmailServiceClient
 	gmailSender      *email.GmailSender
+	idempotency      *Idempotency
 	logger           *slog.Logger
 }
 
 // NewZapierHandler creates a new Zapier handler
-func NewZapierHandler(logger *slog.Logger) *ZapierHandler {
+func NewZapierHandler(logger *slog.Logger, idempotency *Idempotency) *ZapierHandler {
 	handler := &ZapierHandler{
-		logger: logger,
+		idempotency: idempotency,
+		logger:      logger,
 	}
 
 	// Initialize geocoding service
@@ -73,6 +75,23 @@ func NewZapierHandler(logger *slog.Logger) *ZapierHandler {
 	return handler
 }
 
+// zapierLeadPayload is the body of POST /api/zapier/process-lead
+type zapierLeadPayload struct {
+	// Zapier form fields (matching Apps Script)
+	FirstName        string `json:"first_name"`
+	LastName         string `json:"last_name"`
+	EmailAddress     string `json:"email_address"`
+	PhoneNumber      string `json:"phone_number"`
+	EventDate        string `json:"event_date"`
+	EventTime        string `json:"event_time"`
+	EventLocation    string `json:"event_location"`
+	HelpersRequested string `json:"helpers_requested"`
+	ForHowManyHours  string `json:"for_how_many_hours"`
+	Occasion         string `json:"occasion"`
+	GuestsExpected   string `json:"guests_expected"`
+	DryRun           bool   `json:"dryRun"`
+}
+
 // HandleProcessLead handles POST /api/zapier/process-lead
 // Matches the Apps Script processNewLeadFromZapier function
 func (h *ZapierHandler) HandleProcessLead(w http.ResponseWriter, r *http.Request) {
@@ -81,27 +100,26 @@ func (h *ZapierHandler) HandleProcessLead(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	var payload struct {
-		// Zapier form fields (matching Apps Script)
-		FirstName        string `json:"first_name"`
-		LastName         string `json:"last_name"`
-		EmailAddress     string `json:"email_address"`
-		PhoneNumber      string `json:"phone_number"`
-		EventDate        string `json:"event_date"`
-		EventTime        string `json:"event_time"`
-		EventLocation    string `json:"event_location"`
-		HelpersRequested string `json:"helpers_requested"`
-		ForHowManyHours  string `json:"for_how_many_hours"`
-		Occasion         string `json:"occasion"`
-		GuestsExpected   string `json:"guests_expected"`
-		DryRun           bool   `json:"dryRun"`
-	}
-
+	var payload zapierLeadPayload
 	if err := util.ReadJSON(r, &payload); err != nil {
 		util.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
 		return
 	}
 
+	// Zapier retries slow requests; repeats get the first response instead of
+	// creating another calendar event and sending another quote email
+	scope := "zapier-process-lead"
+	if payload.DryRun {
+		scope += "-dry-run"
+	}
+	key := idempotencyKey(r, scope, "", payload.EmailAddress, payload.EventDate, "zapier")
+	h.idempotency.Serve(w, r, key, func(w http.ResponseWriter) {
+		h.processLead(w, r, &payload)
+	})
+}
+
+// processLead runs the lead flow for a decoded Zapier payload
+func (h *ZapierHandler) processLead(w http.ResponseWriter, r *http.Request, payload *zapierLeadPayload) {
 	// Parse and validate input (matching Apps Script logic)
 	clientName := fmt.Sprintf("%s %s", strings.TrimSpace(payload.FirstName), strings.TrimSpace(payload.LastName))
 	if clientName == "" {
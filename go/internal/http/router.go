@@ -13,13 +13,20 @@ import (
 	"github.com/bizops360/go-api/internal/http/handlers"
 	"github.com/bizops360/go-api/internal/http/middleware"
 	"github.com/bizops360/go-api/internal/infra/email"
+	"github.com/bizops360/go-api/internal/infra/slack"
 	"github.com/bizops360/go-api/internal/infra/stripe"
+	"github.com/bizops360/go-api/internal/ports"
 )
 
 // Router sets up HTTP routes
 type Router struct {
 	formEventsHandler    *handlers.FormEventsHandler
 	triggersHandler      *handlers.TriggersHandler
+	mondayWebhookHandler *handlers.MondayWebhookHandler
+	jobsHandler          *handlers.JobsHandler
+	schedulesHandler     *handlers.SchedulesHandler
+	timersHandler        *handlers.TimersHandler
+	crmHandler           *handlers.CRMHandler
 	stripeHandler        *handlers.StripeHandler
 	stripeWebhookHandler *handlers.StripeWebhookHandler
 	estimateHandler      *handlers.EstimateHandler
@@ -36,14 +43,26 @@ type Router struct {
 	serverRestartHandler *handlers.ServerRestartHandler
 	settingsHandler      *handlers.SettingsHandler
 	emailAnalysisHandler *handlers.EmailAnalysisHandler
+	configHandler        *handlers.ConfigHandler
+	pipelinesHandler     *handlers.PipelinesHandler
 	logger               *slog.Logger
 	environment          string
 }
 
-// NewRouter creates a new router
+// NewRouter creates a new router. businessLoader serves the config admin
+// endpoints and is nil when the config is stored in a database.
 func NewRouter(
 	formEventsService *app.FormEventsService,
 	triggersService *app.TriggersService,
+	jobsRepo ports.JobsRepo,
+	jobsService *app.JobsService,
+	scheduler *app.Scheduler,
+	timersService *app.TimersService,
+	crmService *app.CRMService,
+	pipelineDocsService *app.PipelineDocsService,
+	idempotencyStore ports.IdempotencyStore,
+	idempotencyTTL time.Duration,
+	businesses ports.BusinessesRepo,
 	businessLoader *config.BusinessLoader,
 	logger *slog.Logger,
 	environment string,
@@ -52,7 +71,7 @@ func NewRouter(
 	emailClient := email.NewEmailServiceClient()
 	gmailSender, _ := email.NewGmailSender()
 
-	emailHandler := handlers.NewEmailHandlerWithBusinessLoader(logger, businessLoader)
+	emailHandler := handlers.NewEmailHandlerWithBusinesses(logger, businesses)
 	stripeHandler := handlers.NewStripeHandler(paymentsProvider)
 	stripeHandler.SetEmailHandler(emailHandler)
 	testHandler := handlers.NewTestHandler(logger)
@@ -66,16 +85,24 @@ func NewRouter(
 	// Initialize email analysis handler (may fail if credentials not set, that's ok)
 	emailAnalysisHandler, _ := handlers.NewEmailAnalysisHandler(logger)
 
+	// Deduplicate retried pipeline requests (form events, triggers, Zapier)
+	idempotency := handlers.NewIdempotency(idempotencyStore, idempotencyTTL)
+
 	return &Router{
-		formEventsHandler:    handlers.NewFormEventsHandler(formEventsService),
-		triggersHandler:      handlers.NewTriggersHandler(triggersService),
+		formEventsHandler:    handlers.NewFormEventsHandler(formEventsService, idempotency),
+		triggersHandler:      handlers.NewTriggersHandler(triggersService, idempotency),
+		mondayWebhookHandler: handlers.NewMondayWebhookHandler(businesses, triggersService, idempotency, logger),
+		jobsHandler:          handlers.NewJobsHandler(jobsRepo, jobsService),
+		schedulesHandler:     handlers.NewSchedulesHandler(scheduler),
+		timersHandler:        handlers.NewTimersHandler(timersService),
+		crmHandler:           handlers.NewCRMHandler(crmService),
 		stripeHandler:        stripeHandler,
-		stripeWebhookHandler: handlers.NewStripeWebhookHandler(paymentsProvider, emailClient, gmailSender, logger),
+		stripeWebhookHandler: handlers.NewStripeWebhookHandler(paymentsProvider, emailClient, gmailSender, businesses, slack.NewNotifiers(), logger),
 		estimateHandler:      handlers.NewEstimateHandler(paymentsProvider),
 		emailHandler:         emailHandler,
 		calendarHandler:      handlers.NewCalendarHandler(logger),
-		businessLeadHandler:  handlers.NewBusinessLeadHandler(businessLoader, logger),
-		zapierHandler:        handlers.NewZapierHandler(logger),
+		businessLeadHandler:  handlers.NewBusinessLeadHandler(businesses, logger),
+		zapierHandler:        handlers.NewZapierHandler(logger, idempotency),
 		healthHandler:        handlers.NewHealthHandler(),
 		commitsHandler:       handlers.NewCommitsHandler(),
 		rootHandler:          handlers.NewRootHandler(environment),
@@ -83,8 +110,10 @@ func NewRouter(
 		pdfHandler:           pdfHandler,
 		regenerateHandler:    regenerateHandler,
 		serverRestartHandler: handlers.NewServerRestartHandler(logger),
-		settingsHandler:      handlers.NewSettingsHandler(businessLoader, logger),
+		settingsHandler:      handlers.NewSettingsHandler(businesses, logger),
 		emailAnalysisHandler: emailAnalysisHandler,
+		configHandler:        handlers.NewConfigHandler(businessLoader),
+		pipelinesHandler:     handlers.NewPipelinesHandler(pipelineDocsService),
 		logger:               logger,
 		environment:          environment,
 	}
@@ -285,9 +314,29 @@ func (r *Router) Handler() http.Handler {
 		http.Redirect(w, r, "/docs/internal/", http.StatusMovedPermanently)
 	})
 
-	// API v1 routes (new pipeline-based) - no auth required
+	// API v1 routes (new pipeline-based) - no auth required for inbound events;
+	// the Monday.com webhook checks its own token
 	mux.Handle("/v1/form-events", r.formEventsHandler)
 	mux.Handle("/v1/triggers", r.triggersHandler)
+	mux.Handle("/v1/integrations/monday/{businessId}", r.mondayWebhookHandler)
+
+	// API v1 admin routes (require auth) - jobs hold lead details and can rerun side effects
+	mux.Handle("/v1/jobs", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.jobsHandler.HandleList)))
+	mux.Handle("/v1/jobs/{id}", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.jobsHandler.HandleGet)))
+	mux.Handle("/v1/jobs/{id}/resume", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.jobsHandler.HandleResume)))
+	mux.Handle("/v1/jobs/{id}/replay", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.jobsHandler.HandleReplay)))
+	mux.Handle("/v1/schedules", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.schedulesHandler.HandleUpcoming)))
+	mux.Handle("/v1/timers", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.timersHandler.HandleTimers)))
+	mux.Handle("/v1/timers/reschedule", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.timersHandler.HandleReschedule)))
+	mux.Handle("/v1/timers/{id}/cancel", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.timersHandler.HandleCancel)))
+	mux.Handle("/v1/crm/boards", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.crmHandler.HandleBoards)))
+	mux.Handle("/v1/crm/deals", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.crmHandler.HandleDeals)))
+	mux.Handle("/v1/crm/deals/{id}", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.crmHandler.HandleDeal)))
+	mux.Handle("/v1/pipelines", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.pipelinesHandler.HandleList)))
+	mux.Handle("/v1/pipelines/{key}", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.pipelinesHandler.HandleGet)))
+	mux.Handle("/v1/pipelines/{key}/graph", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.pipelinesHandler.HandleGraph)))
+	mux.Handle("/v1/admin/config", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.configHandler.HandleStatus)))
+	mux.Handle("/v1/admin/config/reload", middleware.APIKeyMiddleware(r.logger, http.HandlerFunc(r.configHandler.HandleReload)))
 
 	// Calendar endpoint - no auth required
 	mux.HandleFunc("/api/calendar/create", r.calendarHandler.HandleCreate)
@@ -1,17 +1,22 @@
 package http
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/bizops360/go-api/internal/app"
 	"github.com/bizops360/go-api/internal/config"
 	"github.com/bizops360/go-api/internal/domain"
 	"github.com/bizops360/go-api/internal/infra/db"
 	logger "github.com/bizops360/go-api/internal/infra/log"
+	"github.com/bizops360/go-api/internal/ports"
 )
 
 func TestRouter_AllEndpoints(t *testing.T) {
@@ -27,6 +32,7 @@ func TestRouter_AllEndpoints(t *testing.T) {
 	cfg := config.LoadConfig()
 	log := logger.NewLogger("debug")
 	businessLoader := config.NewBusinessLoader(cfg)
+	businesses, pipelines := config.NewYAMLBusinessesRepo(businessLoader), config.NewYAMLPipelinesRepo(businessLoader)
 	jobsRepo := db.NewMemoryJobsRepo()
 
 	actions := map[string]domain.Action{
@@ -34,10 +40,15 @@ func TestRouter_AllEndpoints(t *testing.T) {
 	}
 	pipelineRunner := domain.NewPipelineRunner(actions)
 
-	formEventsService := app.NewFormEventsService(businessLoader, pipelineRunner, jobsRepo)
-	triggersService := app.NewTriggersService(businessLoader, pipelineRunner, jobsRepo)
+	workerPool := app.NewWorkerPool(1, 10)
+	formEventsService := app.NewFormEventsService(businesses, pipelines, pipelineRunner, jobsRepo, workerPool)
+	triggersService := app.NewTriggersService(businesses, pipelines, pipelineRunner, jobsRepo, workerPool)
+	jobsService := app.NewJobsService(businesses, pipelines, pipelineRunner, jobsRepo, workerPool)
+	timersRepo := db.NewMemoryTimersRepo()
+	timersService := app.NewTimersService(businesses, timersRepo)
+	scheduler := app.NewScheduler(businesses, triggersService, timersRepo, db.NewMemoryLocker(), log, 0)
 
-	router := NewRouter(formEventsService, triggersService, businessLoader, log, "dev")
+	router := NewRouter(formEventsService, triggersService, jobsRepo, jobsService, scheduler, timersService, app.NewCRMService(businesses, db.NewMemoryCRMStore()), app.NewPipelineDocsService(pipelines, pipelineRunner), db.NewMemoryIdempotencyStore(), time.Hour, businesses, businessLoader, log, "dev")
 	handler := router.Handler()
 
 	tests := []struct {
@@ -57,6 +68,88 @@ func TestRouter_AllEndpoints(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			skipAuth:       true,
 		},
+		// Job status endpoints (require auth)
+		{
+			name:           "GET /v1/jobs/{id} unknown job",
+			method:         "GET",
+			path:           "/v1/jobs/req_unknown",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "GET /v1/jobs without businessId",
+			method:         "GET",
+			path:           "/v1/jobs",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "POST /v1/jobs/{id}/resume unknown job",
+			method:         "POST",
+			path:           "/v1/jobs/req_unknown/resume",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "POST /v1/jobs/{id}/replay unknown job",
+			method:         "POST",
+			path:           "/v1/jobs/req_unknown/replay",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			body:           `{"dryRun": true}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "GET /v1/jobs?businessId=",
+			method:         "GET",
+			path:           "/v1/jobs?businessId=stlpartyhelpers",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			expectedStatus: http.StatusOK,
+		},
+		// Schedule endpoints (require auth)
+		{
+			name:           "GET /v1/schedules unknown business",
+			method:         "GET",
+			path:           "/v1/schedules?businessId=unknown_business",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "GET /v1/schedules invalid limit",
+			method:         "GET",
+			path:           "/v1/schedules?limit=0",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		// Timer endpoints (require auth)
+		{
+			name:           "GET /v1/timers without leadId",
+			method:         "GET",
+			path:           "/v1/timers?businessId=stlpartyhelpers",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "GET /v1/timers",
+			method:         "GET",
+			path:           "/v1/timers?businessId=stlpartyhelpers&leadId=jane@example.com",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "POST /v1/timers/{id}/cancel unknown timer",
+			method:         "POST",
+			path:           "/v1/timers/tmr_unknown/cancel",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "POST /v1/timers/reschedule invalid date",
+			method:         "POST",
+			path:           "/v1/timers/reschedule",
+			headers:        map[string]string{"X-Api-Key": "test-api-key"},
+			body:           `{"businessId": "stlpartyhelpers", "leadId": "jane@example.com", "eventDate": "soon"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
 		// Health endpoints
 		{
 			name:           "GET /api/health",
@@ -145,6 +238,12 @@ func TestRouter_AllEndpoints(t *testing.T) {
 			body:           `{"eventDate":"2025-06-15","durationHours":4,"numHelpers":2}`,
 			expectedStatus: http.StatusUnauthorized,
 		},
+		{
+			name:           "GET /v1/jobs without API key",
+			method:         "GET",
+			path:           "/v1/jobs?businessId=stlpartyhelpers",
+			expectedStatus: http.StatusUnauthorized,
+		},
 		{
 			name:           "POST /api/estimate with wrong API key",
 			method:         "POST",
@@ -179,3 +278,293 @@ func TestRouter_AllEndpoints(t *testing.T) {
 	}
 }
 
+// blockingAction counts its runs and, when started is set, signals it and
+// waits for release before finishing
+type blockingAction struct {
+	calls   int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (a *blockingAction) Name() string {
+	return "send_quote_email"
+}
+
+func (a *blockingAction) Execute(ctx context.Context, pctx *domain.PipelineContext) domain.JobStep {
+	atomic.AddInt32(&a.calls, 1)
+	if a.started != nil {
+		a.started <- struct{}{}
+		<-a.release
+	}
+	return domain.JobStep{Name: a.Name(), Status: "ok"}
+}
+
+func newIdempotencyTestHandler(t *testing.T, action domain.Action) http.Handler {
+	t.Helper()
+	return newTestHandler(t, action, db.NewMemoryCRMStore(), map[string]string{
+		"businesses/stlpartyhelpers.yaml":  "id: stlpartyhelpers\n",
+		"pipelines/quote_and_deposit.yaml": "key: quote_and_deposit\nactions:\n  - name: send_quote_email\n",
+	})
+}
+
+// newTestHandler returns the router over config files, keyed by path
+// relative to the config dir, with action as the only registered action and
+// crmStore behind the built-in CRM
+func newTestHandler(t *testing.T, action domain.Action, crmStore ports.CRMStore, files map[string]string) http.Handler {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	log := logger.NewLogger("error")
+	businessLoader := config.NewBusinessLoader(&config.Config{ConfigDir: dir})
+	businesses, pipelines := config.NewYAMLBusinessesRepo(businessLoader), config.NewYAMLPipelinesRepo(businessLoader)
+	jobsRepo := db.NewMemoryJobsRepo()
+	pipelineRunner := domain.NewPipelineRunner(map[string]domain.Action{action.Name(): action})
+	workerPool := app.NewWorkerPool(1, 10)
+	formEventsService := app.NewFormEventsService(businesses, pipelines, pipelineRunner, jobsRepo, workerPool)
+	triggersService := app.NewTriggersService(businesses, pipelines, pipelineRunner, jobsRepo, workerPool)
+	jobsService := app.NewJobsService(businesses, pipelines, pipelineRunner, jobsRepo, workerPool)
+	timersRepo := db.NewMemoryTimersRepo()
+	timersService := app.NewTimersService(businesses, timersRepo)
+	scheduler := app.NewScheduler(businesses, triggersService, timersRepo, db.NewMemoryLocker(), log, 0)
+
+	router := NewRouter(formEventsService, triggersService, jobsRepo, jobsService, scheduler, timersService, app.NewCRMService(businesses, crmStore), app.NewPipelineDocsService(pipelines, pipelineRunner), db.NewMemoryIdempotencyStore(), time.Hour, businesses, businessLoader, log, "dev")
+	return router.Handler()
+}
+
+func postFormEvent(handler http.Handler, idempotencyKey, email string) *httptest.ResponseRecorder {
+	body := `{"businessId":"stlpartyhelpers","pipelineKey":"quote_and_deposit","fields":{"email":"` + email + `","eventDate":"2026-03-14"}}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/form-events", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestRouter_FormEventsIdempotency(t *testing.T) {
+	tests := []struct {
+		name         string
+		firstKey     string
+		firstEmail   string
+		secondKey    string
+		secondEmail  string
+		wantReplayed bool
+		wantCalls    int32
+	}{
+		{"same header key", "zap-123", "jane@example.com", "zap-123", "john@example.com", true, 1},
+		{"different header keys", "zap-123", "jane@example.com", "zap-456", "jane@example.com", false, 2},
+		{"derived from lead", "", "jane@example.com", "", "Jane@Example.com", true, 1},
+		{"different leads", "", "jane@example.com", "", "john@example.com", false, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := &blockingAction{}
+			handler := newIdempotencyTestHandler(t, action)
+
+			first := postFormEvent(handler, tt.firstKey, tt.firstEmail)
+			if first.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d. Response: %s", first.Code, first.Body.String())
+			}
+			second := postFormEvent(handler, tt.secondKey, tt.secondEmail)
+			if second.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d. Response: %s", second.Code, second.Body.String())
+			}
+
+			replayed := second.Header().Get("Idempotent-Replayed") == "true"
+			if replayed != tt.wantReplayed {
+				t.Errorf("expected replayed %v, got %v", tt.wantReplayed, replayed)
+			}
+			if tt.wantReplayed && second.Body.String() != first.Body.String() {
+				t.Errorf("expected the first response to be replayed, got %s", second.Body.String())
+			}
+			if calls := atomic.LoadInt32(&action.calls); calls != tt.wantCalls {
+				t.Errorf("expected %d pipeline runs, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRouter_FormEventsIdempotencyInFlight(t *testing.T) {
+	action := &blockingAction{started: make(chan struct{}), release: make(chan struct{})}
+	handler := newIdempotencyTestHandler(t, action)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- postFormEvent(handler, "zap-123", "jane@example.com")
+	}()
+	<-action.started
+
+	// A retry while the first request is still running is rejected
+	if w := postFormEvent(handler, "zap-123", "jane@example.com"); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d. Response: %s", w.Code, w.Body.String())
+	}
+
+	close(action.release)
+	if w := <-done; w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d. Response: %s", w.Code, w.Body.String())
+	}
+	if w := postFormEvent(handler, "zap-123", "jane@example.com"); w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("expected the completed response to be replayed, got status %d", w.Code)
+	}
+}
+
+const mondayTestBusiness = `id: stlpartyhelpers
+monday:
+  webhookSecretEnv: MONDAY_WEBHOOK_SECRET_TEST
+  boards:
+    deals: 234567890
+  triggers:
+    - board: deals
+      column: Status
+      value: Send Renewal
+      trigger: send_renewal_offer
+pipelines:
+  triggers:
+    send_renewal_offer: renewal
+`
+
+func postMondayWebhook(handler http.Handler, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestRouter_MondayWebhook(t *testing.T) {
+	t.Setenv("MONDAY_WEBHOOK_SECRET_TEST", "s3cret")
+	action := &blockingAction{started: make(chan struct{}), release: make(chan struct{})}
+	handler := newTestHandler(t, action, db.NewMemoryCRMStore(), map[string]string{
+		"businesses/stlpartyhelpers.yaml": mondayTestBusiness,
+		"businesses/acme.yaml":            "id: acme\n",
+		"pipelines/renewal.yaml":          "key: renewal\nactions:\n  - name: send_quote_email\n",
+	})
+	path := "/v1/integrations/monday/stlpartyhelpers?token=s3cret"
+	event := func(label, uuid string) string {
+		return `{"event":{"type":"update_column_value","boardId":234567890,"pulseId":42,"pulseName":"Jane - Birthday",` +
+			`"columnId":"status","columnTitle":"Status","value":{"label":{"text":"` + label + `"}},"triggerUuid":"` + uuid + `"}}`
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "challenge", path: path, body: `{"challenge":"abc123"}`, wantStatus: http.StatusOK, wantBody: `"challenge":"abc123"`},
+		{name: "wrong token", path: "/v1/integrations/monday/stlpartyhelpers?token=guess", body: `{"challenge":"abc123"}`, wantStatus: http.StatusUnauthorized},
+		{name: "no token", path: "/v1/integrations/monday/stlpartyhelpers", body: `{"challenge":"abc123"}`, wantStatus: http.StatusUnauthorized},
+		{name: "webhooks not configured", path: "/v1/integrations/monday/acme?token=s3cret", body: `{"challenge":"abc123"}`, wantStatus: http.StatusForbidden},
+		{name: "unknown business", path: "/v1/integrations/monday/unknown?token=s3cret", body: `{"challenge":"abc123"}`, wantStatus: http.StatusNotFound},
+		{name: "unmapped status", path: path, body: event("Booked", "uuid-1"), wantStatus: http.StatusOK, wantBody: `"ignored":true`},
+		{name: "invalid JSON", path: path, body: `{`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postMondayWebhook(handler, tt.path, tt.body)
+			if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("expected status %d with %s, got %d. Response: %s", tt.wantStatus, tt.wantBody, w.Code, w.Body.String())
+			}
+		})
+	}
+	if calls := atomic.LoadInt32(&action.calls); calls != 0 {
+		t.Fatalf("expected no pipeline runs yet, got %d", calls)
+	}
+
+	// The mapped status change queues the trigger's pipeline
+	w := postMondayWebhook(handler, path, event("Send Renewal", "uuid-2"))
+	if w.Code != http.StatusAccepted || !strings.Contains(w.Body.String(), `"pipelineKey":"renewal"`) {
+		t.Fatalf("expected the renewal pipeline to be queued, got %d. Response: %s", w.Code, w.Body.String())
+	}
+	<-action.started
+	close(action.release)
+
+	// Monday.com's retry of the same event is not run again
+	if w := postMondayWebhook(handler, path, event("Send Renewal", "uuid-2")); w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("expected the retry to be replayed, got status %d", w.Code)
+	}
+	if calls := atomic.LoadInt32(&action.calls); calls != 1 {
+		t.Errorf("expected 1 pipeline run, got %d", calls)
+	}
+}
+
+const crmTestBusiness = `id: stlpartyhelpers
+crm:
+  provider: builtin
+  boards:
+    deals:
+      id: 1
+      statusColumns:
+        - field: status
+          labels: [Lead, Booked, Lost]
+          default: Lead
+`
+
+func TestRouter_CRM(t *testing.T) {
+	t.Setenv("SERVICE_API_KEY", "test-api-key")
+	store := db.NewMemoryCRMStore()
+	for _, deal := range []*domain.CRMDeal{
+		{BusinessID: "stlpartyhelpers", BoardID: 1, Name: "Jane - Birthday", Fields: map[string]any{"status": "Lead", "email": "jane@example.com"}},
+		{BusinessID: "stlpartyhelpers", BoardID: 1, Name: "John - Wedding", Fields: map[string]any{"status": "Booked"}},
+	} {
+		if err := store.CreateDeal(context.Background(), deal); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handler := newTestHandler(t, &app.NormalizeInputAction{}, store, map[string]string{
+		"businesses/stlpartyhelpers.yaml": crmTestBusiness,
+		"businesses/acme.yaml":            "id: acme\n",
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "boards", method: "GET", path: "/v1/crm/boards?businessId=stlpartyhelpers", wantStatus: http.StatusOK, wantBody: `"labels":["Lead","Booked","Lost"]`},
+		{name: "list by status", method: "GET", path: "/v1/crm/deals?businessId=stlpartyhelpers&board=deals&status=booked", wantStatus: http.StatusOK, wantBody: `"name":"John - Wedding"`},
+		{name: "search", method: "GET", path: "/v1/crm/deals?businessId=stlpartyhelpers&q=jane@", wantStatus: http.StatusOK, wantBody: `"name":"Jane - Birthday"`},
+		{name: "list without businessId", method: "GET", path: "/v1/crm/deals", wantStatus: http.StatusBadRequest},
+		{name: "unknown status label", method: "GET", path: "/v1/crm/deals?businessId=stlpartyhelpers&status=Paid", wantStatus: http.StatusBadRequest},
+		{name: "invalid limit", method: "GET", path: "/v1/crm/deals?businessId=stlpartyhelpers&limit=0", wantStatus: http.StatusBadRequest},
+		{name: "business on monday", method: "GET", path: "/v1/crm/deals?businessId=acme", wantStatus: http.StatusConflict},
+		{name: "unknown business", method: "GET", path: "/v1/crm/deals?businessId=unknown", wantStatus: http.StatusNotFound},
+		{name: "get", method: "GET", path: "/v1/crm/deals/1?businessId=stlpartyhelpers", wantStatus: http.StatusOK, wantBody: `"name":"Jane - Birthday"`},
+		{name: "get unknown deal", method: "GET", path: "/v1/crm/deals/99?businessId=stlpartyhelpers", wantStatus: http.StatusNotFound},
+		{name: "get invalid id", method: "GET", path: "/v1/crm/deals/abc?businessId=stlpartyhelpers", wantStatus: http.StatusBadRequest},
+		{name: "update", method: "PATCH", path: "/v1/crm/deals/1", body: `{"businessId":"stlpartyhelpers","fields":{"status":"booked","phone":"314-555-0100"}}`, wantStatus: http.StatusOK, wantBody: `"status":"Booked"`},
+		{name: "update to unknown label", method: "PATCH", path: "/v1/crm/deals/1", body: `{"businessId":"stlpartyhelpers","fields":{"status":"Paid"}}`, wantStatus: http.StatusBadRequest},
+		{name: "update without fields", method: "PATCH", path: "/v1/crm/deals/1", body: `{"businessId":"stlpartyhelpers"}`, wantStatus: http.StatusBadRequest},
+		{name: "delete", method: "DELETE", path: "/v1/crm/deals/1?businessId=stlpartyhelpers", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Api-Key", "test-api-key")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("expected status %d with %s, got %d. Response: %s", tt.wantStatus, tt.wantBody, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// MemoryCRMStore is an in-memory implementation of CRMStore
+type MemoryCRMStore struct {
+	boards   map[string]*domain.CRMBoard
+	deals    map[int64]*domain.CRMDeal
+	contacts map[string]*domain.CRMContact
+	nextID   int64
+	mu       sync.RWMutex
+}
+
+// NewMemoryCRMStore creates a new in-memory CRM store
+func NewMemoryCRMStore() ports.CRMStore {
+	return &MemoryCRMStore{
+		boards:   make(map[string]*domain.CRMBoard),
+		deals:    make(map[int64]*domain.CRMDeal),
+		contacts: make(map[string]*domain.CRMContact),
+	}
+}
+
+// SaveBoard inserts or replaces a board
+func (s *MemoryCRMStore) SaveBoard(ctx context.Context, board *domain.CRMBoard) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	board.UpdatedAt = time.Now()
+	saved := *board
+	saved.StatusColumns = append([]domain.CRMStatusColumn(nil), board.StatusColumns...)
+	s.boards[fmt.Sprintf("%s/%d", board.BusinessID, board.ID)] = &saved
+	return nil
+}
+
+// ListBoards returns the boards of a business, by ID
+func (s *MemoryCRMStore) ListBoards(ctx context.Context, businessID string) ([]*domain.CRMBoard, error) {
+	s.mu.RLock()
+	boards := []*domain.CRMBoard{}
+	for _, board := range s.boards {
+		if board.BusinessID == businessID {
+			copied := *board
+			boards = append(boards, &copied)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(boards, func(i, j int) bool {
+		return boards[i].ID < boards[j].ID
+	})
+	return boards, nil
+}
+
+// CreateDeal inserts a deal and sets its ID
+func (s *MemoryCRMStore) CreateDeal(ctx context.Context, deal *domain.CRMDeal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	deal.ID = s.nextID
+	deal.CreatedAt = time.Now()
+	deal.UpdatedAt = deal.CreatedAt
+	s.deals[deal.ID] = deal.Snapshot()
+	return nil
+}
+
+// SaveDeal replaces an existing deal
+func (s *MemoryCRMStore) SaveDeal(ctx context.Context, deal *domain.CRMDeal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.deals[deal.ID]; !ok || existing.BusinessID != deal.BusinessID {
+		return fmt.Errorf("%w: %d", ports.ErrCRMDealNotFound, deal.ID)
+	}
+	deal.UpdatedAt = time.Now()
+	s.deals[deal.ID] = deal.Snapshot()
+	return nil
+}
+
+// GetDeal retrieves a deal of a business by ID
+func (s *MemoryCRMStore) GetDeal(ctx context.Context, businessID string, id int64) (*domain.CRMDeal, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	deal, ok := s.deals[id]
+	if !ok || deal.BusinessID != businessID {
+		return nil, fmt.Errorf("%w: %d", ports.ErrCRMDealNotFound, id)
+	}
+	return deal.Snapshot(), nil
+}
+
+// ListDeals returns the deals matching the query, most recently updated first
+func (s *MemoryCRMStore) ListDeals(ctx context.Context, query ports.DealQuery) ([]*domain.CRMDeal, error) {
+	s.mu.RLock()
+	deals := []*domain.CRMDeal{}
+	for _, deal := range s.deals {
+		if query.Matches(deal) {
+			deals = append(deals, deal.Snapshot())
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(deals, func(i, j int) bool {
+		if deals[i].UpdatedAt.Equal(deals[j].UpdatedAt) {
+			return deals[i].ID > deals[j].ID
+		}
+		return deals[i].UpdatedAt.After(deals[j].UpdatedAt)
+	})
+	if query.Limit > 0 && len(deals) > query.Limit {
+		deals = deals[:query.Limit]
+	}
+	return deals, nil
+}
+
+// SaveContact inserts or replaces a contact
+func (s *MemoryCRMStore) SaveContact(ctx context.Context, contact *domain.CRMContact) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if contact.CreatedAt.IsZero() {
+		contact.CreatedAt = time.Now()
+	}
+	contact.UpdatedAt = time.Now()
+	saved := *contact
+	s.contacts[contact.ID] = &saved
+	return nil
+}
+
+// GetContact retrieves a contact of a business by ID
+func (s *MemoryCRMStore) GetContact(ctx context.Context, businessID, id string) (*domain.CRMContact, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	contact, ok := s.contacts[id]
+	if !ok || contact.BusinessID != businessID {
+		return nil, fmt.Errorf("%w: %s", ports.ErrCRMContactNotFound, id)
+	}
+	copied := *contact
+	return &copied, nil
+}
@@ -0,0 +1,14 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+func TestMemoryCRMStore(t *testing.T) {
+	portstest.RunCRMStoreTests(t, func(t *testing.T) ports.CRMStore {
+		return NewMemoryCRMStore()
+	})
+}
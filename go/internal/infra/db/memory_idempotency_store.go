@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// MemoryIdempotencyStore is an in-memory implementation of IdempotencyStore for
+// single-instance deployments
+type MemoryIdempotencyStore struct {
+	entries map[string]idempotencyEntry
+	mu      sync.Mutex
+}
+
+// idempotencyEntry is a claimed key; response is nil while the request is in flight
+type idempotencyEntry struct {
+	response  *ports.IdempotentResponse
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore creates a new in-memory idempotency store
+func NewMemoryIdempotencyStore() ports.IdempotencyStore {
+	return &MemoryIdempotencyStore{
+		entries: make(map[string]idempotencyEntry),
+	}
+}
+
+// Begin claims key unless a request with it is in flight or has completed
+func (s *MemoryIdempotencyStore) Begin(ctx context.Context, key string, lease time.Duration) (*ports.IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		if entry.response == nil {
+			return nil, ports.ErrIdempotencyKeyInFlight
+		}
+		return copyIdempotentResponse(entry.response), nil
+	}
+
+	// Drop expired entries so the map does not grow without bound
+	for k, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+
+	s.entries[key] = idempotencyEntry{expiresAt: now.Add(lease)}
+	return nil, nil
+}
+
+// Complete stores the response of the request holding key for ttl
+func (s *MemoryIdempotencyStore) Complete(ctx context.Context, key string, response *ports.IdempotentResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = idempotencyEntry{response: copyIdempotentResponse(response), expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Release forgets an in-flight key
+func (s *MemoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[key]; ok && entry.response == nil {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+func copyIdempotentResponse(response *ports.IdempotentResponse) *ports.IdempotentResponse {
+	return &ports.IdempotentResponse{
+		StatusCode: response.StatusCode,
+		Body:       append([]byte(nil), response.Body...),
+	}
+}
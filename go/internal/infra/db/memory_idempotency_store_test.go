@@ -0,0 +1,14 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	portstest.RunIdempotencyStoreTests(t, func(t *testing.T) ports.IdempotencyStore {
+		return NewMemoryIdempotencyStore()
+	})
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -33,7 +34,8 @@ func (r *MemoryJobsRepo) Save(ctx context.Context, job *domain.Job) error {
 	}
 	job.UpdatedAt = time.Now()
 
-	r.jobs[job.ID] = job
+	// Store a copy so later changes by the caller don't leak into history
+	r.jobs[job.ID] = job.Snapshot()
 	return nil
 }
 
@@ -44,29 +46,78 @@ func (r *MemoryJobsRepo) GetByID(ctx context.Context, id string) (*domain.Job, e
 
 	job, exists := r.jobs[id]
 	if !exists {
-		return nil, fmt.Errorf("job not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", ports.ErrJobNotFound, id)
 	}
 
-	return job, nil
+	return job.Snapshot(), nil
 }
 
-// GetByBusinessID retrieves jobs for a business
+// CompareAndSetStatus changes a job's status from one value to another atomically
+func (r *MemoryJobsRepo) CompareAndSetStatus(ctx context.Context, id, from, to string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, exists := r.jobs[id]
+	if !exists {
+		return false, fmt.Errorf("%w: %s", ports.ErrJobNotFound, id)
+	}
+	if job.Status != from {
+		return false, nil
+	}
+	job.Status = to
+	job.UpdatedAt = time.Now()
+	return true, nil
+}
+
+// GetByBusinessID retrieves jobs for a business, newest first
 func (r *MemoryJobsRepo) GetByBusinessID(ctx context.Context, businessID string, limit int) ([]*domain.Job, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	page, err := r.List(ctx, ports.JobQuery{BusinessID: businessID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return page.Jobs, nil
+}
+
+// List retrieves jobs matching the query, newest first
+func (r *MemoryJobsRepo) List(ctx context.Context, query ports.JobQuery) (*ports.JobPage, error) {
+	var cursor *ports.JobCursor
+	if query.Cursor != "" {
+		var err error
+		cursor, err = ports.DecodeJobCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	r.mu.RLock()
 	var jobs []*domain.Job
 	for _, job := range r.jobs {
-		if job.BusinessID == businessID {
-			jobs = append(jobs, job)
+		if !query.Matches(job) {
+			continue
 		}
+		if cursor != nil && !cursor.After(job) {
+			continue
+		}
+		jobs = append(jobs, job.Snapshot())
 	}
+	r.mu.RUnlock()
+
+	// Newest first, ID as a tiebreaker so pagination is stable
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].CreatedAt.Equal(jobs[j].CreatedAt) {
+			return jobs[i].ID > jobs[j].ID
+		}
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
 
-	// Simple limit (in production, would sort by CreatedAt desc)
-	if limit > 0 && len(jobs) > limit {
-		jobs = jobs[:limit]
+	page := &ports.JobPage{Jobs: jobs}
+	if limit := query.PageLimit(); len(jobs) > limit {
+		page.Jobs = jobs[:limit]
+		page.NextCursor = ports.EncodeJobCursor(page.Jobs[limit-1])
+	}
+	if page.Jobs == nil {
+		page.Jobs = []*domain.Job{}
 	}
 
-	return jobs, nil
+	return page, nil
 }
-
@@ -0,0 +1,14 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+func TestMemoryJobsRepo(t *testing.T) {
+	portstest.RunJobsRepoTests(t, func(t *testing.T) ports.JobsRepo {
+		return NewMemoryJobsRepo()
+	})
+}
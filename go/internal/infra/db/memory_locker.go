@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// MemoryLocker is an in-memory implementation of Locker for single-instance deployments
+type MemoryLocker struct {
+	expires map[string]time.Time
+	mu      sync.Mutex
+}
+
+// NewMemoryLocker creates a new in-memory locker
+func NewMemoryLocker() ports.Locker {
+	return &MemoryLocker{
+		expires: make(map[string]time.Time),
+	}
+}
+
+// TryLock acquires the lock named key for ttl unless an unexpired holder has it
+func (l *MemoryLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if expiresAt, held := l.expires[key]; held && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	// Drop expired locks so the map does not grow without bound
+	for k, expiresAt := range l.expires {
+		if !now.Before(expiresAt) {
+			delete(l.expires, k)
+		}
+	}
+
+	l.expires[key] = now.Add(ttl)
+	return true, nil
+}
+
+// Unlock releases the lock named key
+func (l *MemoryLocker) Unlock(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.expires, key)
+	return nil
+}
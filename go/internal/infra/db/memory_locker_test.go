@@ -0,0 +1,14 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+func TestMemoryLocker(t *testing.T) {
+	portstest.RunLockerTests(t, func(t *testing.T) ports.Locker {
+		return NewMemoryLocker()
+	})
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// MemoryTimersRepo is an in-memory implementation of TimersRepo
+type MemoryTimersRepo struct {
+	timers map[string]*domain.Timer
+	mu     sync.RWMutex
+}
+
+// NewMemoryTimersRepo creates a new in-memory timers repository
+func NewMemoryTimersRepo() ports.TimersRepo {
+	return &MemoryTimersRepo{
+		timers: make(map[string]*domain.Timer),
+	}
+}
+
+// Save inserts or replaces a timer
+func (r *MemoryTimersRepo) Save(ctx context.Context, timer *domain.Timer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if timer.CreatedAt.IsZero() {
+		timer.CreatedAt = time.Now()
+	}
+	timer.UpdatedAt = time.Now()
+
+	r.timers[timer.ID] = timer.Snapshot()
+	return nil
+}
+
+// GetByID retrieves a timer by ID
+func (r *MemoryTimersRepo) GetByID(ctx context.Context, id string) (*domain.Timer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	timer, exists := r.timers[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ports.ErrTimerNotFound, id)
+	}
+	return timer.Snapshot(), nil
+}
+
+// ListByLead returns every timer of a lead, soonest first
+func (r *MemoryTimersRepo) ListByLead(ctx context.Context, businessID, leadID string) ([]*domain.Timer, error) {
+	return r.list(func(timer *domain.Timer) bool {
+		return timer.BusinessID == businessID && timer.LeadID == leadID
+	}, 0), nil
+}
+
+// ListDue returns up to limit scheduled timers whose FireAt is not after now, soonest first
+func (r *MemoryTimersRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Timer, error) {
+	return r.list(func(timer *domain.Timer) bool {
+		return timer.Status == domain.TimerStatusScheduled && !timer.FireAt.After(now)
+	}, limit), nil
+}
+
+// list returns copies of the matching timers ordered by FireAt, then ID
+func (r *MemoryTimersRepo) list(match func(*domain.Timer) bool, limit int) []*domain.Timer {
+	r.mu.RLock()
+	timers := []*domain.Timer{}
+	for _, timer := range r.timers {
+		if match(timer) {
+			timers = append(timers, timer.Snapshot())
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(timers, func(i, j int) bool {
+		if timers[i].FireAt.Equal(timers[j].FireAt) {
+			return timers[i].ID < timers[j].ID
+		}
+		return timers[i].FireAt.Before(timers[j].FireAt)
+	})
+	if limit > 0 && len(timers) > limit {
+		timers = timers[:limit]
+	}
+	return timers
+}
@@ -0,0 +1,14 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+func TestMemoryTimersRepo(t *testing.T) {
+	portstest.RunTimersRepoTests(t, func(t *testing.T) ports.TimersRepo {
+		return NewMemoryTimersRepo()
+	})
+}
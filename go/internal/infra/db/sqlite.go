@@ -0,0 +1,265 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	// Pure Go SQLite driver, so the binary still builds with CGO_ENABLED=0
+	_ "modernc.org/sqlite"
+)
+
+// migration is a forward-only schema change applied once, in version order
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// sqliteMigrations is the full schema history. Append new migrations with the
+// next version number; never edit one that has already shipped.
+var sqliteMigrations = []migration{
+	{
+		version: 1,
+		name:    "create_jobs",
+		sql: `
+CREATE TABLE jobs (
+	id           TEXT PRIMARY KEY,
+	business_id  TEXT NOT NULL,
+	pipeline_key TEXT NOT NULL,
+	status       TEXT NOT NULL,
+	input        TEXT NOT NULL DEFAULT '{}',
+	result       TEXT NOT NULL DEFAULT '{}',
+	created_at   INTEGER NOT NULL,
+	updated_at   INTEGER NOT NULL
+);
+CREATE INDEX idx_jobs_business_created ON jobs (business_id, created_at DESC, id DESC);
+
+CREATE TABLE job_steps (
+	job_id    TEXT NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	name      TEXT NOT NULL,
+	status    TEXT NOT NULL,
+	critical  INTEGER NOT NULL DEFAULT 0,
+	error     TEXT,
+	details   TEXT,
+	permanent INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (job_id, position)
+);
+`,
+	},
+	{
+		version: 2,
+		name:    "add_job_step_outputs_and_config",
+		sql: `
+ALTER TABLE job_steps ADD COLUMN outputs TEXT;
+ALTER TABLE job_steps ADD COLUMN config TEXT;
+`,
+	},
+	{
+		version: 3,
+		name:    "add_job_request_context",
+		sql: `
+ALTER TABLE jobs ADD COLUMN source TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN dry_run INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN options TEXT;
+ALTER TABLE jobs ADD COLUMN resource TEXT;
+ALTER TABLE jobs ADD COLUMN replay_of TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		version: 4,
+		name:    "create_locks",
+		sql: `
+CREATE TABLE locks (
+	key        TEXT PRIMARY KEY,
+	expires_at INTEGER NOT NULL
+);
+`,
+	},
+	{
+		version: 5,
+		name:    "create_timers",
+		sql: `
+CREATE TABLE timers (
+	id          TEXT PRIMARY KEY,
+	business_id TEXT NOT NULL,
+	lead_id     TEXT NOT NULL,
+	key         TEXT NOT NULL,
+	trigger_key TEXT NOT NULL,
+	event_date  TEXT NOT NULL,
+	offset_days INTEGER NOT NULL,
+	at          TEXT NOT NULL,
+	fire_at     INTEGER NOT NULL,
+	payload     TEXT,
+	status      TEXT NOT NULL,
+	job_id      TEXT NOT NULL DEFAULT '',
+	error       TEXT NOT NULL DEFAULT '',
+	created_at  INTEGER NOT NULL,
+	updated_at  INTEGER NOT NULL
+);
+CREATE INDEX idx_timers_lead ON timers (business_id, lead_id, fire_at);
+CREATE INDEX idx_timers_due ON timers (status, fire_at);
+`,
+	},
+	{
+		version: 6,
+		name:    "create_idempotency_keys",
+		sql: `
+CREATE TABLE idempotency_keys (
+	key         TEXT PRIMARY KEY,
+	completed   INTEGER NOT NULL DEFAULT 0,
+	status_code INTEGER NOT NULL DEFAULT 0,
+	body        BLOB,
+	expires_at  INTEGER NOT NULL
+);
+CREATE INDEX idx_idempotency_keys_expires ON idempotency_keys (expires_at);
+`,
+	},
+	{
+		version: 7,
+		name:    "add_job_step_sub_job",
+		sql: `
+ALTER TABLE job_steps ADD COLUMN sub_job TEXT;
+`,
+	},
+	{
+		version: 8,
+		name:    "add_job_step_timing",
+		sql: `
+ALTER TABLE job_steps ADD COLUMN started_at INTEGER;
+ALTER TABLE job_steps ADD COLUMN finished_at INTEGER;
+ALTER TABLE job_steps ADD COLUMN duration_ms INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_steps ADD COLUMN timeout_ms INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		version: 9,
+		name:    "add_job_step_side_effects",
+		sql: `
+ALTER TABLE job_steps ADD COLUMN side_effects TEXT;
+`,
+	},
+	{
+		version: 10,
+		name:    "create_crm",
+		sql: `
+CREATE TABLE crm_boards (
+	business_id    TEXT NOT NULL,
+	id             INTEGER NOT NULL,
+	name           TEXT NOT NULL,
+	status_columns TEXT,
+	updated_at     INTEGER NOT NULL,
+	PRIMARY KEY (business_id, id)
+);
+
+CREATE TABLE crm_deals (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	business_id TEXT NOT NULL,
+	board_id    INTEGER NOT NULL,
+	name        TEXT NOT NULL,
+	contact_id  TEXT NOT NULL DEFAULT '',
+	fields      TEXT NOT NULL DEFAULT '{}',
+	search_text TEXT NOT NULL DEFAULT '',
+	created_at  INTEGER NOT NULL,
+	updated_at  INTEGER NOT NULL
+);
+CREATE INDEX idx_crm_deals_business_updated ON crm_deals (business_id, board_id, updated_at DESC, id DESC);
+
+CREATE TABLE crm_contacts (
+	id          TEXT PRIMARY KEY,
+	business_id TEXT NOT NULL,
+	name        TEXT NOT NULL DEFAULT '',
+	email       TEXT NOT NULL DEFAULT '',
+	phone       TEXT NOT NULL DEFAULT '',
+	created_at  INTEGER NOT NULL,
+	updated_at  INTEGER NOT NULL
+);
+`,
+	},
+}
+
+// OpenSQLite opens (creating if needed) a SQLite database file and applies any
+// pending schema migrations
+func OpenSQLite(ctx context.Context, path string) (*sql.DB, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+	}
+
+	dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database %s: %w", path, err)
+	}
+
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY between
+	// the worker pool and HTTP handlers
+	db.SetMaxOpenConns(1)
+
+	if err := migrateSQLite(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// migrateSQLite applies migrations that have not been recorded in schema_migrations
+func migrateSQLite(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    INTEGER PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at INTEGER NOT NULL
+)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	applied := make(map[int]bool)
+	rows, err := db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		applied[version] = true
+	}
+	rows.Close()
+
+	for _, m := range sqliteMigrations {
+		if applied[m.version] {
+			continue
+		}
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to start migration %d: %w", m.version, err)
+		}
+		if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d (%s) failed: %w", m.version, m.name, err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+			m.version, m.name, time.Now().Unix(),
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %d: %w", m.version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %d: %w", m.version, err)
+		}
+	}
+
+	return nil
+}
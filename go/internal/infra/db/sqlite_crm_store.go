@@ -0,0 +1,268 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// SQLiteCRMStore stores the deals, contacts and boards of the built-in CRM in
+// a SQLite database opened with OpenSQLite
+type SQLiteCRMStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteCRMStore creates a SQLite-backed CRM store
+func NewSQLiteCRMStore(db *sql.DB) ports.CRMStore {
+	return &SQLiteCRMStore{db: db}
+}
+
+// SaveBoard inserts or replaces a board
+func (s *SQLiteCRMStore) SaveBoard(ctx context.Context, board *domain.CRMBoard) error {
+	board.UpdatedAt = time.Now()
+
+	statusColumns, err := json.Marshal(board.StatusColumns)
+	if err != nil {
+		return fmt.Errorf("failed to encode status columns of board %d: %w", board.ID, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+INSERT INTO crm_boards (business_id, id, name, status_columns, updated_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (business_id, id) DO UPDATE SET
+	name = excluded.name,
+	status_columns = excluded.status_columns,
+	updated_at = excluded.updated_at`,
+		board.BusinessID, board.ID, board.Name, string(statusColumns), board.UpdatedAt.UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save board %d: %w", board.ID, err)
+	}
+	return nil
+}
+
+// ListBoards returns the boards of a business, by ID
+func (s *SQLiteCRMStore) ListBoards(ctx context.Context, businessID string) ([]*domain.CRMBoard, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT business_id, id, name, status_columns, updated_at FROM crm_boards
+WHERE business_id = ?
+ORDER BY id`, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list boards: %w", err)
+	}
+	defer rows.Close()
+
+	boards := []*domain.CRMBoard{}
+	for rows.Next() {
+		var board domain.CRMBoard
+		var statusColumns sql.NullString
+		var updatedAt int64
+		if err := rows.Scan(&board.BusinessID, &board.ID, &board.Name, &statusColumns, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to list boards: %w", err)
+		}
+		if statusColumns.Valid {
+			if err := json.Unmarshal([]byte(statusColumns.String), &board.StatusColumns); err != nil {
+				return nil, fmt.Errorf("failed to decode status columns of board %d: %w", board.ID, err)
+			}
+		}
+		board.UpdatedAt = time.Unix(0, updatedAt).UTC()
+		boards = append(boards, &board)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list boards: %w", err)
+	}
+	return boards, nil
+}
+
+// CreateDeal inserts a deal and sets its ID
+func (s *SQLiteCRMStore) CreateDeal(ctx context.Context, deal *domain.CRMDeal) error {
+	deal.CreatedAt = time.Now()
+	deal.UpdatedAt = deal.CreatedAt
+
+	fields, err := encodeDealFields(deal)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(ctx, `
+INSERT INTO crm_deals (business_id, board_id, name, contact_id, fields, search_text, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		deal.BusinessID, deal.BoardID, deal.Name, deal.ContactID, fields, deal.SearchText(),
+		deal.CreatedAt.UnixNano(), deal.UpdatedAt.UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create deal %q: %w", deal.Name, err)
+	}
+	if deal.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("failed to create deal %q: %w", deal.Name, err)
+	}
+	return nil
+}
+
+// SaveDeal replaces an existing deal
+func (s *SQLiteCRMStore) SaveDeal(ctx context.Context, deal *domain.CRMDeal) error {
+	deal.UpdatedAt = time.Now()
+
+	fields, err := encodeDealFields(deal)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(ctx, `
+UPDATE crm_deals SET board_id = ?, name = ?, contact_id = ?, fields = ?, search_text = ?, created_at = ?, updated_at = ?
+WHERE id = ? AND business_id = ?`,
+		deal.BoardID, deal.Name, deal.ContactID, fields, deal.SearchText(),
+		deal.CreatedAt.UnixNano(), deal.UpdatedAt.UnixNano(),
+		deal.ID, deal.BusinessID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save deal %d: %w", deal.ID, err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: %d", ports.ErrCRMDealNotFound, deal.ID)
+	}
+	return nil
+}
+
+// GetDeal retrieves a deal of a business by ID
+func (s *SQLiteCRMStore) GetDeal(ctx context.Context, businessID string, id int64) (*domain.CRMDeal, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+dealColumns+` FROM crm_deals WHERE id = ? AND business_id = ?`, id, businessID)
+	deal, err := scanDeal(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ports.ErrCRMDealNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deal %d: %w", id, err)
+	}
+	return deal, nil
+}
+
+// ListDeals returns the deals matching the query, most recently updated first
+func (s *SQLiteCRMStore) ListDeals(ctx context.Context, query ports.DealQuery) ([]*domain.CRMDeal, error) {
+	where := []string{"business_id = ?"}
+	args := []any{query.BusinessID}
+	if query.BoardID != 0 {
+		where = append(where, "board_id = ?")
+		args = append(args, query.BoardID)
+	}
+	fields := make([]string, 0, len(query.Fields))
+	for field := range query.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		path := `$."` + field + `"`
+		where = append(where, "json_type(fields, ?) = 'text' AND json_extract(fields, ?) = ?")
+		args = append(args, path, path, query.Fields[field])
+	}
+	if query.Search != "" {
+		where = append(where, "instr(search_text, ?) > 0")
+		args = append(args, strings.ToLower(query.Search))
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = -1 // no limit
+	}
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+dealColumns+` FROM crm_deals
+WHERE `+strings.Join(where, " AND ")+`
+ORDER BY updated_at DESC, id DESC
+LIMIT ?`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deals: %w", err)
+	}
+	defer rows.Close()
+
+	deals := []*domain.CRMDeal{}
+	for rows.Next() {
+		deal, err := scanDeal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deals: %w", err)
+		}
+		deals = append(deals, deal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list deals: %w", err)
+	}
+	return deals, nil
+}
+
+// SaveContact inserts or replaces a contact
+func (s *SQLiteCRMStore) SaveContact(ctx context.Context, contact *domain.CRMContact) error {
+	if contact.CreatedAt.IsZero() {
+		contact.CreatedAt = time.Now()
+	}
+	contact.UpdatedAt = time.Now()
+
+	_, err := s.db.ExecContext(ctx, `
+INSERT INTO crm_contacts (id, business_id, name, email, phone, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+	business_id = excluded.business_id,
+	name = excluded.name,
+	email = excluded.email,
+	phone = excluded.phone,
+	created_at = excluded.created_at,
+	updated_at = excluded.updated_at`,
+		contact.ID, contact.BusinessID, contact.Name, contact.Email, contact.Phone,
+		contact.CreatedAt.UnixNano(), contact.UpdatedAt.UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save contact %s: %w", contact.ID, err)
+	}
+	return nil
+}
+
+// GetContact retrieves a contact of a business by ID
+func (s *SQLiteCRMStore) GetContact(ctx context.Context, businessID, id string) (*domain.CRMContact, error) {
+	var contact domain.CRMContact
+	var createdAt, updatedAt int64
+	err := s.db.QueryRowContext(ctx, `SELECT id, business_id, name, email, phone, created_at, updated_at FROM crm_contacts
+WHERE id = ? AND business_id = ?`, id, businessID).Scan(
+		&contact.ID, &contact.BusinessID, &contact.Name, &contact.Email, &contact.Phone, &createdAt, &updatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrCRMContactNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact %s: %w", id, err)
+	}
+	contact.CreatedAt = time.Unix(0, createdAt).UTC()
+	contact.UpdatedAt = time.Unix(0, updatedAt).UTC()
+	return &contact, nil
+}
+
+// dealColumns is the column list read by scanDeal
+const dealColumns = `id, business_id, board_id, name, contact_id, fields, created_at, updated_at`
+
+func scanDeal(row rowScanner) (*domain.CRMDeal, error) {
+	var deal domain.CRMDeal
+	var fields string
+	var createdAt, updatedAt int64
+	if err := row.Scan(&deal.ID, &deal.BusinessID, &deal.BoardID, &deal.Name, &deal.ContactID, &fields, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(fields), &deal.Fields); err != nil {
+		return nil, fmt.Errorf("failed to decode fields of deal %d: %w", deal.ID, err)
+	}
+	deal.CreatedAt = time.Unix(0, createdAt).UTC()
+	deal.UpdatedAt = time.Unix(0, updatedAt).UTC()
+	return &deal, nil
+}
+
+func encodeDealFields(deal *domain.CRMDeal) (string, error) {
+	if deal.Fields == nil {
+		return "{}", nil
+	}
+	fields, err := json.Marshal(deal.Fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode fields of deal %q: %w", deal.Name, err)
+	}
+	return string(fields), nil
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// SQLiteIdempotencyStore stores idempotency keys in a SQLite database opened with OpenSQLite
+type SQLiteIdempotencyStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteIdempotencyStore creates a SQLite-backed idempotency store
+func NewSQLiteIdempotencyStore(db *sql.DB) ports.IdempotencyStore {
+	return &SQLiteIdempotencyStore{db: db}
+}
+
+// Begin claims key unless a request with it is in flight or has completed
+func (s *SQLiteIdempotencyStore) Begin(ctx context.Context, key string, lease time.Duration) (*ports.IdempotentResponse, error) {
+	now := time.Now()
+	// Drop expired keys so the table does not grow without bound
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= ?`, now.UnixNano()); err != nil {
+		return nil, fmt.Errorf("failed to expire idempotency keys: %w", err)
+	}
+
+	// The upsert only takes over a key whose claim or response has expired
+	res, err := s.db.ExecContext(ctx, `
+INSERT INTO idempotency_keys (key, completed, status_code, body, expires_at) VALUES (?, 0, 0, NULL, ?)
+ON CONFLICT (key) DO UPDATE SET completed = 0, status_code = 0, body = NULL, expires_at = excluded.expires_at
+WHERE idempotency_keys.expires_at <= ?`,
+		key, now.Add(lease).UnixNano(), now.UnixNano(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim idempotency key %s: %w", key, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim idempotency key %s: %w", key, err)
+	}
+	if affected == 1 {
+		return nil, nil
+	}
+
+	var completed bool
+	response := &ports.IdempotentResponse{}
+	err = s.db.QueryRowContext(ctx,
+		`SELECT completed, status_code, body FROM idempotency_keys WHERE key = ?`, key,
+	).Scan(&completed, &response.StatusCode, &response.Body)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !completed) {
+		return nil, ports.ErrIdempotencyKeyInFlight
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read idempotency key %s: %w", key, err)
+	}
+	return response, nil
+}
+
+// Complete stores the response of the request holding key for ttl
+func (s *SQLiteIdempotencyStore) Complete(ctx context.Context, key string, response *ports.IdempotentResponse, ttl time.Duration) error {
+	_, err := s.db.ExecContext(ctx, `
+INSERT INTO idempotency_keys (key, completed, status_code, body, expires_at) VALUES (?, 1, ?, ?, ?)
+ON CONFLICT (key) DO UPDATE SET completed = 1, status_code = excluded.status_code, body = excluded.body, expires_at = excluded.expires_at`,
+		key, response.StatusCode, response.Body, time.Now().Add(ttl).UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store response for idempotency key %s: %w", key, err)
+	}
+	return nil
+}
+
+// Release forgets an in-flight key
+func (s *SQLiteIdempotencyStore) Release(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE key = ? AND completed = 0`, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,392 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// SQLiteJobsRepo stores pipeline jobs in a SQLite database opened with OpenSQLite
+type SQLiteJobsRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteJobsRepo creates a SQLite-backed jobs repository
+func NewSQLiteJobsRepo(db *sql.DB) ports.JobsRepo {
+	return &SQLiteJobsRepo{db: db}
+}
+
+// Save saves a job and replaces its steps
+func (r *SQLiteJobsRepo) Save(ctx context.Context, job *domain.Job) error {
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+	job.UpdatedAt = time.Now()
+
+	input, err := marshalJSONColumn(job.Input)
+	if err != nil {
+		return fmt.Errorf("failed to encode input of job %s: %w", job.ID, err)
+	}
+	result, err := marshalJSONColumn(job.Result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result of job %s: %w", job.ID, err)
+	}
+	var options, resource any
+	if job.Options != nil {
+		encoded, err := json.Marshal(job.Options)
+		if err != nil {
+			return fmt.Errorf("failed to encode options of job %s: %w", job.ID, err)
+		}
+		options = string(encoded)
+	}
+	if job.Resource != nil {
+		encoded, err := json.Marshal(job.Resource)
+		if err != nil {
+			return fmt.Errorf("failed to encode resource of job %s: %w", job.ID, err)
+		}
+		resource = string(encoded)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to save job %s: %w", job.ID, err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO jobs (id, business_id, pipeline_key, status, input, result, created_at, updated_at, source, dry_run, options, resource, replay_of)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+	business_id = excluded.business_id,
+	pipeline_key = excluded.pipeline_key,
+	status = excluded.status,
+	input = excluded.input,
+	result = excluded.result,
+	created_at = excluded.created_at,
+	updated_at = excluded.updated_at,
+	source = excluded.source,
+	dry_run = excluded.dry_run,
+	options = excluded.options,
+	resource = excluded.resource,
+	replay_of = excluded.replay_of`,
+		job.ID, job.BusinessID, job.PipelineKey, job.Status, input, result,
+		job.CreatedAt.UnixNano(), job.UpdatedAt.UnixNano(),
+		job.Source, job.DryRun, options, resource, job.ReplayOf,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save job %s: %w", job.ID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_steps WHERE job_id = ?`, job.ID); err != nil {
+		return fmt.Errorf("failed to save steps of job %s: %w", job.ID, err)
+	}
+	for i, step := range job.Steps {
+		var columns [3]any
+		for c, m := range []map[string]any{step.Details, step.Outputs, step.Config} {
+			if m == nil {
+				continue
+			}
+			encoded, err := json.Marshal(m)
+			if err != nil {
+				return fmt.Errorf("failed to encode step %s of job %s: %w", step.Name, job.ID, err)
+			}
+			columns[c] = string(encoded)
+		}
+		var subJob any
+		if step.SubJob != nil {
+			encoded, err := json.Marshal(step.SubJob)
+			if err != nil {
+				return fmt.Errorf("failed to encode step %s of job %s: %w", step.Name, job.ID, err)
+			}
+			subJob = string(encoded)
+		}
+		var sideEffects any
+		if len(step.SideEffects) > 0 {
+			encoded, err := json.Marshal(step.SideEffects)
+			if err != nil {
+				return fmt.Errorf("failed to encode step %s of job %s: %w", step.Name, job.ID, err)
+			}
+			sideEffects = string(encoded)
+		}
+		_, err := tx.ExecContext(ctx, `
+INSERT INTO job_steps (job_id, position, name, status, critical, error, details, permanent, outputs, config, sub_job,
+	started_at, finished_at, duration_ms, timeout_ms, side_effects)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			job.ID, i, step.Name, step.Status, step.Critical, step.Error, columns[0], step.Permanent, columns[1], columns[2], subJob,
+			unixNanoOrNull(step.StartedAt), unixNanoOrNull(step.FinishedAt), step.DurationMs, step.TimeoutMs, sideEffects,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save step %s of job %s: %w", step.Name, job.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// GetByID retrieves a job by ID
+func (r *SQLiteJobsRepo) GetByID(ctx context.Context, id string) (*domain.Job, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = ?`, id)
+	job, err := scanJob(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrJobNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", id, err)
+	}
+
+	if err := r.loadSteps(ctx, []*domain.Job{job}); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// CompareAndSetStatus changes a job's status from one value to another atomically
+func (r *SQLiteJobsRepo) CompareAndSetStatus(ctx context.Context, id, from, to string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `UPDATE jobs SET status = ?, updated_at = ? WHERE id = ? AND status = ?`,
+		to, time.Now().UnixNano(), id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update status of job %s: %w", id, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update status of job %s: %w", id, err)
+	}
+	if n == 1 {
+		return true, nil
+	}
+
+	// Tell a missing job apart from one in another status
+	var exists int
+	err = r.db.QueryRowContext(ctx, `SELECT 1 FROM jobs WHERE id = ?`, id).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("%w: %s", ports.ErrJobNotFound, id)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get job %s: %w", id, err)
+	}
+	return false, nil
+}
+
+// GetByBusinessID retrieves jobs for a business, newest first
+func (r *SQLiteJobsRepo) GetByBusinessID(ctx context.Context, businessID string, limit int) ([]*domain.Job, error) {
+	page, err := r.List(ctx, ports.JobQuery{BusinessID: businessID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return page.Jobs, nil
+}
+
+// List retrieves jobs matching the query, newest first
+func (r *SQLiteJobsRepo) List(ctx context.Context, query ports.JobQuery) (*ports.JobPage, error) {
+	var where []string
+	var args []any
+	if query.BusinessID != "" {
+		where = append(where, "business_id = ?")
+		args = append(args, query.BusinessID)
+	}
+	if query.Status != "" {
+		where = append(where, "status = ?")
+		args = append(args, query.Status)
+	}
+	if query.PipelineKey != "" {
+		where = append(where, "pipeline_key = ?")
+		args = append(args, query.PipelineKey)
+	}
+	if !query.CreatedAfter.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, query.CreatedAfter.UnixNano())
+	}
+	if !query.CreatedBefore.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, query.CreatedBefore.UnixNano())
+	}
+	if query.Cursor != "" {
+		cursor, err := ports.DecodeJobCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, cursor.CreatedAt.UnixNano(), cursor.CreatedAt.UnixNano(), cursor.ID)
+	}
+
+	stmt := `SELECT ` + jobColumns + ` FROM jobs`
+	if len(where) > 0 {
+		stmt += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	// Fetch one extra row to know whether there is a next page
+	limit := query.PageLimit()
+	stmt += ` ORDER BY created_at DESC, id DESC LIMIT ?`
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	jobs := []*domain.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to list jobs: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	rows.Close()
+
+	page := &ports.JobPage{Jobs: jobs}
+	if len(jobs) > limit {
+		page.Jobs = jobs[:limit]
+		page.NextCursor = ports.EncodeJobCursor(page.Jobs[limit-1])
+	}
+
+	if err := r.loadSteps(ctx, page.Jobs); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// jobColumns is the column list read by scanJob
+const jobColumns = `id, business_id, pipeline_key, status, input, result, created_at, updated_at, source, dry_run, options, resource, replay_of`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row rowScanner) (*domain.Job, error) {
+	var job domain.Job
+	var input, result string
+	var options, resource sql.NullString
+	var createdAt, updatedAt int64
+	if err := row.Scan(
+		&job.ID, &job.BusinessID, &job.PipelineKey, &job.Status, &input, &result, &createdAt, &updatedAt,
+		&job.Source, &job.DryRun, &options, &resource, &job.ReplayOf,
+	); err != nil {
+		return nil, err
+	}
+	if options.Valid {
+		if err := json.Unmarshal([]byte(options.String), &job.Options); err != nil {
+			return nil, fmt.Errorf("failed to decode options of job %s: %w", job.ID, err)
+		}
+	}
+	if resource.Valid {
+		if err := json.Unmarshal([]byte(resource.String), &job.Resource); err != nil {
+			return nil, fmt.Errorf("failed to decode resource of job %s: %w", job.ID, err)
+		}
+	}
+	if err := json.Unmarshal([]byte(input), &job.Input); err != nil {
+		return nil, fmt.Errorf("failed to decode input of job %s: %w", job.ID, err)
+	}
+	if err := json.Unmarshal([]byte(result), &job.Result); err != nil {
+		return nil, fmt.Errorf("failed to decode result of job %s: %w", job.ID, err)
+	}
+	job.CreatedAt = time.Unix(0, createdAt).UTC()
+	job.UpdatedAt = time.Unix(0, updatedAt).UTC()
+	job.Steps = []domain.JobStep{}
+	return &job, nil
+}
+
+// loadSteps fills in the steps of the given jobs with a single query
+func (r *SQLiteJobsRepo) loadSteps(ctx context.Context, jobs []*domain.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.Job, len(jobs))
+	placeholders := make([]string, len(jobs))
+	args := make([]any, len(jobs))
+	for i, job := range jobs {
+		byID[job.ID] = job
+		placeholders[i] = "?"
+		args[i] = job.ID
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+SELECT job_id, name, status, critical, error, details, permanent, outputs, config, sub_job,
+	started_at, finished_at, duration_ms, timeout_ms, side_effects
+FROM job_steps
+WHERE job_id IN (`+strings.Join(placeholders, ", ")+`)
+ORDER BY job_id, position`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load job steps: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jobID string
+		var step domain.JobStep
+		var stepErr, details, outputs, config, subJob, sideEffects sql.NullString
+		var startedAt, finishedAt sql.NullInt64
+		if err := rows.Scan(&jobID, &step.Name, &step.Status, &step.Critical, &stepErr, &details, &step.Permanent, &outputs, &config, &subJob,
+			&startedAt, &finishedAt, &step.DurationMs, &step.TimeoutMs, &sideEffects); err != nil {
+			return fmt.Errorf("failed to load job steps: %w", err)
+		}
+		if startedAt.Valid {
+			step.StartedAt = time.Unix(0, startedAt.Int64).UTC()
+		}
+		if finishedAt.Valid {
+			step.FinishedAt = time.Unix(0, finishedAt.Int64).UTC()
+		}
+		if stepErr.Valid {
+			step.Error = &stepErr.String
+		}
+		for _, column := range []struct {
+			value sql.NullString
+			dest  *map[string]any
+		}{{details, &step.Details}, {outputs, &step.Outputs}, {config, &step.Config}} {
+			if !column.value.Valid {
+				continue
+			}
+			if err := json.Unmarshal([]byte(column.value.String), column.dest); err != nil {
+				return fmt.Errorf("failed to decode step %s of job %s: %w", step.Name, jobID, err)
+			}
+		}
+		if subJob.Valid {
+			if err := json.Unmarshal([]byte(subJob.String), &step.SubJob); err != nil {
+				return fmt.Errorf("failed to decode step %s of job %s: %w", step.Name, jobID, err)
+			}
+		}
+		if sideEffects.Valid {
+			if err := json.Unmarshal([]byte(sideEffects.String), &step.SideEffects); err != nil {
+				return fmt.Errorf("failed to decode step %s of job %s: %w", step.Name, jobID, err)
+			}
+		}
+		byID[jobID].Steps = append(byID[jobID].Steps, step)
+	}
+	return rows.Err()
+}
+
+// unixNanoOrNull encodes a time for a nullable INTEGER column, storing the
+// zero time as NULL
+func unixNanoOrNull(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UnixNano()
+}
+
+// marshalJSONColumn encodes a map for a JSON text column, storing nil as {}
+func marshalJSONColumn(m map[string]any) (string, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	encoded, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+func TestSQLiteJobsRepo(t *testing.T) {
+	portstest.RunJobsRepoTests(t, func(t *testing.T) ports.JobsRepo {
+		db, err := OpenSQLite(context.Background(), filepath.Join(t.TempDir(), "bizops.db"))
+		if err != nil {
+			t.Fatalf("OpenSQLite failed: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return NewSQLiteJobsRepo(db)
+	})
+}
+
+func TestOpenSQLite_Reopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data", "bizops.db")
+
+	db, err := OpenSQLite(ctx, path)
+	if err != nil {
+		t.Fatalf("OpenSQLite failed: %v", err)
+	}
+	if err := NewSQLiteJobsRepo(db).Save(ctx, &domain.Job{ID: "job-1", BusinessID: "stlpartyhelpers", Status: "completed"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	db.Close()
+
+	// Reopening must not re-run migrations and must keep the data
+	db, err = OpenSQLite(ctx, path)
+	if err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	defer db.Close()
+
+	var migrations int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations`).Scan(&migrations); err != nil {
+		t.Fatalf("failed to count migrations: %v", err)
+	}
+	if migrations != len(sqliteMigrations) {
+		t.Errorf("expected %d recorded migrations, got %d", len(sqliteMigrations), migrations)
+	}
+
+	job, err := NewSQLiteJobsRepo(db).GetByID(ctx, "job-1")
+	if err != nil {
+		t.Fatalf("expected job to survive reopen: %v", err)
+	}
+	if job.Status != "completed" {
+		t.Errorf("expected completed job, got %s", job.Status)
+	}
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// SQLiteLocker stores locks in a SQLite database opened with OpenSQLite
+type SQLiteLocker struct {
+	db *sql.DB
+}
+
+// NewSQLiteLocker creates a SQLite-backed locker
+func NewSQLiteLocker(db *sql.DB) ports.Locker {
+	return &SQLiteLocker{db: db}
+}
+
+// TryLock acquires the lock named key for ttl unless an unexpired holder has it
+func (l *SQLiteLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	// The upsert only takes over a lock whose holder has expired
+	res, err := l.db.ExecContext(ctx, `
+INSERT INTO locks (key, expires_at) VALUES (?, ?)
+ON CONFLICT (key) DO UPDATE SET expires_at = excluded.expires_at
+WHERE locks.expires_at <= ?`,
+		key, now.Add(ttl).UnixNano(), now.UnixNano(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	return affected == 1, nil
+}
+
+// Unlock releases the lock named key
+func (l *SQLiteLocker) Unlock(ctx context.Context, key string) error {
+	if _, err := l.db.ExecContext(ctx, `DELETE FROM locks WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// SQLiteTimersRepo stores event-relative timers in a SQLite database opened with OpenSQLite
+type SQLiteTimersRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteTimersRepo creates a SQLite-backed timers repository
+func NewSQLiteTimersRepo(db *sql.DB) ports.TimersRepo {
+	return &SQLiteTimersRepo{db: db}
+}
+
+// Save inserts or replaces a timer
+func (r *SQLiteTimersRepo) Save(ctx context.Context, timer *domain.Timer) error {
+	if timer.CreatedAt.IsZero() {
+		timer.CreatedAt = time.Now()
+	}
+	timer.UpdatedAt = time.Now()
+
+	var payload any
+	if timer.Payload != nil {
+		encoded, err := json.Marshal(timer.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode payload of timer %s: %w", timer.ID, err)
+		}
+		payload = string(encoded)
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+INSERT INTO timers (id, business_id, lead_id, key, trigger_key, event_date, offset_days, at, fire_at, payload, status, job_id, error, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+	business_id = excluded.business_id,
+	lead_id = excluded.lead_id,
+	key = excluded.key,
+	trigger_key = excluded.trigger_key,
+	event_date = excluded.event_date,
+	offset_days = excluded.offset_days,
+	at = excluded.at,
+	fire_at = excluded.fire_at,
+	payload = excluded.payload,
+	status = excluded.status,
+	job_id = excluded.job_id,
+	error = excluded.error,
+	created_at = excluded.created_at,
+	updated_at = excluded.updated_at`,
+		timer.ID, timer.BusinessID, timer.LeadID, timer.Key, timer.TriggerKey, timer.EventDate, timer.OffsetDays, timer.At,
+		timer.FireAt.UnixNano(), payload, timer.Status, timer.JobID, timer.Error,
+		timer.CreatedAt.UnixNano(), timer.UpdatedAt.UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save timer %s: %w", timer.ID, err)
+	}
+	return nil
+}
+
+// GetByID retrieves a timer by ID
+func (r *SQLiteTimersRepo) GetByID(ctx context.Context, id string) (*domain.Timer, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+timerColumns+` FROM timers WHERE id = ?`, id)
+	timer, err := scanTimer(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrTimerNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timer %s: %w", id, err)
+	}
+	return timer, nil
+}
+
+// ListByLead returns every timer of a lead, soonest first
+func (r *SQLiteTimersRepo) ListByLead(ctx context.Context, businessID, leadID string) ([]*domain.Timer, error) {
+	return r.query(ctx, `SELECT `+timerColumns+` FROM timers
+WHERE business_id = ? AND lead_id = ?
+ORDER BY fire_at, id`, businessID, leadID)
+}
+
+// ListDue returns up to limit scheduled timers whose FireAt is not after now, soonest first
+func (r *SQLiteTimersRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Timer, error) {
+	if limit <= 0 {
+		limit = -1 // no limit
+	}
+	return r.query(ctx, `SELECT `+timerColumns+` FROM timers
+WHERE status = ? AND fire_at <= ?
+ORDER BY fire_at, id
+LIMIT ?`, domain.TimerStatusScheduled, now.UnixNano(), limit)
+}
+
+func (r *SQLiteTimersRepo) query(ctx context.Context, stmt string, args ...any) ([]*domain.Timer, error) {
+	rows, err := r.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list timers: %w", err)
+	}
+	defer rows.Close()
+
+	timers := []*domain.Timer{}
+	for rows.Next() {
+		timer, err := scanTimer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list timers: %w", err)
+		}
+		timers = append(timers, timer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list timers: %w", err)
+	}
+	return timers, nil
+}
+
+// timerColumns is the column list read by scanTimer
+const timerColumns = `id, business_id, lead_id, key, trigger_key, event_date, offset_days, at, fire_at, payload, status, job_id, error, created_at, updated_at`
+
+func scanTimer(row rowScanner) (*domain.Timer, error) {
+	var timer domain.Timer
+	var payload sql.NullString
+	var fireAt, createdAt, updatedAt int64
+	if err := row.Scan(
+		&timer.ID, &timer.BusinessID, &timer.LeadID, &timer.Key, &timer.TriggerKey, &timer.EventDate, &timer.OffsetDays, &timer.At,
+		&fireAt, &payload, &timer.Status, &timer.JobID, &timer.Error, &createdAt, &updatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if payload.Valid {
+		if err := json.Unmarshal([]byte(payload.String), &timer.Payload); err != nil {
+			return nil, fmt.Errorf("failed to decode payload of timer %s: %w", timer.ID, err)
+		}
+	}
+	timer.FireAt = time.Unix(0, fireAt).UTC()
+	timer.CreatedAt = time.Unix(0, createdAt).UTC()
+	timer.UpdatedAt = time.Unix(0, updatedAt).UTC()
+	return &timer, nil
+}
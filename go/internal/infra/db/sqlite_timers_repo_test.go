@@ -0,0 +1,21 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+func TestSQLiteTimersRepo(t *testing.T) {
+	portstest.RunTimersRepoTests(t, func(t *testing.T) ports.TimersRepo {
+		db, err := OpenSQLite(context.Background(), filepath.Join(t.TempDir(), "bizops.db"))
+		if err != nil {
+			t.Fatalf("OpenSQLite failed: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return NewSQLiteTimersRepo(db)
+	})
+}
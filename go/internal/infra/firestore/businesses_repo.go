@@ -0,0 +1,133 @@
+package firestore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// businessesCollection is the Firestore collection holding business configs
+const businessesCollection = "businesses"
+
+// FirestoreBusinessesRepo stores business configurations in Firestore, one
+// document per business keyed by business ID
+type FirestoreBusinessesRepo struct {
+	client     *Client
+	collection string
+}
+
+// NewFirestoreBusinessesRepo creates a Firestore-backed businesses repository
+func NewFirestoreBusinessesRepo(client *Client) ports.BusinessesRepo {
+	return &FirestoreBusinessesRepo{
+		client:     client,
+		collection: businessesCollection,
+	}
+}
+
+// configDocument is the Firestore representation of a business or pipeline
+// config. Config holds the JSON form of the domain type, so documents can be
+// edited in the console with the field names of the API.
+type configDocument struct {
+	Config    map[string]any `firestore:"config"`
+	UpdatedAt time.Time      `firestore:"updatedAt"`
+}
+
+func newConfigDocument(value any) (*configDocument, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]any
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return &configDocument{Config: config, UpdatedAt: time.Now()}, nil
+}
+
+// decode converts the document's config into value
+func (d *configDocument) decode(value any) error {
+	data, err := json.Marshal(d.Config)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
+
+func (r *FirestoreBusinessesRepo) businesses() *firestore.CollectionRef {
+	return r.client.GetClient().Collection(r.collection)
+}
+
+// GetByID retrieves a business configuration by ID
+func (r *FirestoreBusinessesRepo) GetByID(ctx context.Context, id string) (*domain.BusinessConfig, error) {
+	snap, err := r.businesses().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %s", ports.ErrBusinessNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get business %s: %w", id, err)
+	}
+	return decodeBusiness(snap)
+}
+
+// GetAll returns every business configuration, sorted by ID
+func (r *FirestoreBusinessesRepo) GetAll(ctx context.Context) ([]*domain.BusinessConfig, error) {
+	iter := r.businesses().OrderBy(firestore.DocumentID, firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	businesses := []*domain.BusinessConfig{}
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list businesses: %w", err)
+		}
+		business, err := decodeBusiness(snap)
+		if err != nil {
+			return nil, err
+		}
+		businesses = append(businesses, business)
+	}
+	return businesses, nil
+}
+
+// Save inserts or replaces a business configuration
+func (r *FirestoreBusinessesRepo) Save(ctx context.Context, business *domain.BusinessConfig) error {
+	if business.ID == "" {
+		return errors.New("business ID is required")
+	}
+	doc, err := newConfigDocument(business)
+	if err != nil {
+		return fmt.Errorf("failed to encode business %s: %w", business.ID, err)
+	}
+	if _, err := r.businesses().Doc(business.ID).Set(ctx, doc); err != nil {
+		return fmt.Errorf("failed to save business %s: %w", business.ID, err)
+	}
+	return nil
+}
+
+func decodeBusiness(snap *firestore.DocumentSnapshot) (*domain.BusinessConfig, error) {
+	var doc configDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode business %s: %w", snap.Ref.ID, err)
+	}
+	var business domain.BusinessConfig
+	if err := doc.decode(&business); err != nil {
+		return nil, fmt.Errorf("failed to decode business %s: %w", snap.Ref.ID, err)
+	}
+	if business.ID == "" {
+		business.ID = snap.Ref.ID
+	}
+	return &business, nil
+}
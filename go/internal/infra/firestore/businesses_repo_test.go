@@ -0,0 +1,68 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+// TestFirestoreBusinessesRepo runs the BusinessesRepo conformance suite against the Firestore
+// emulator or, without one, the in-process fake; see newTestClient.
+func TestFirestoreBusinessesRepo(t *testing.T) {
+	client := newTestClient(t)
+	portstest.RunBusinessesRepoTests(t, func(t *testing.T) ports.BusinessesRepo {
+		// Each test gets its own collection so results don't mix
+		return &FirestoreBusinessesRepo{
+			client:     client,
+			collection: testCollection("businesses"),
+		}
+	})
+}
+
+// TestFirestoreBusinessesRepo_Validation checks that stored businesses are
+// validated against the stored pipelines as they are with
+// CONFIG_STORE=firestore
+func TestFirestoreBusinessesRepo_Validation(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	pipelines := &FirestorePipelinesRepo{client: client, collection: testCollection("pipelines")}
+	stored := &FirestoreBusinessesRepo{client: client, collection: testCollection("businesses")}
+	repo := config.NewValidatingBusinessesRepo(stored, pipelines)
+
+	if err := pipelines.Save(ctx, &domain.PipelineDefinition{Key: "intake", Actions: []domain.ActionDefinition{{Name: "normalize_input"}}}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	valid := &domain.BusinessConfig{ID: "acme", Pipelines: domain.BusinessPipelineConfig{DefaultForm: "intake"}}
+	if err := repo.Save(ctx, valid); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	invalid := &domain.BusinessConfig{ID: "zenith", Pipelines: domain.BusinessPipelineConfig{DefaultForm: "missing"}}
+	var validationErr *domain.ValidationError
+	if err := repo.Save(ctx, invalid); !errors.As(err, &validationErr) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+	if _, err := stored.GetByID(ctx, "zenith"); !errors.Is(err, ports.ErrBusinessNotFound) {
+		t.Errorf("expected the invalid business not to be saved, got %v", err)
+	}
+
+	// A business edited in the console is validated when it is loaded
+	if err := stored.Save(ctx, invalid); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "zenith"); !errors.As(err, &validationErr) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+	businesses, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(businesses) != 1 || businesses[0].ID != "acme" {
+		t.Errorf("expected only the valid business, got %+v", businesses)
+	}
+}
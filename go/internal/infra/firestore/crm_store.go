@@ -0,0 +1,330 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// Firestore collections holding the built-in CRM
+const (
+	crmBoardsCollection   = "crm_boards"
+	crmDealsCollection    = "crm_deals"
+	crmContactsCollection = "crm_contacts"
+	// crmCountersCollection holds the counter deal IDs are taken from
+	crmCountersCollection = "crm_counters"
+)
+
+// FirestoreCRMStore stores the deals, contacts and boards of the built-in CRM
+// in Firestore, one document each. Deal IDs come from a counter document
+// incremented in the transaction that creates the deal.
+//
+// ListBoards needs a composite index on (businessId ASC, id ASC). ListDeals
+// filters on businessId, boardId and the queried fields and orders by
+// updatedAt and id, so it needs indexes such as (businessId ASC, boardId ASC,
+// updatedAt DESC, id DESC); Firestore reports the exact index to create the
+// first time a query needs it. Searches are matched after the query.
+type FirestoreCRMStore struct {
+	client *Client
+	// prefix is prepended to the collection names
+	prefix string
+}
+
+// NewFirestoreCRMStore creates a Firestore-backed CRM store
+func NewFirestoreCRMStore(client *Client) ports.CRMStore {
+	return &FirestoreCRMStore{client: client}
+}
+
+// boardDocument is the Firestore representation of domain.CRMBoard
+type boardDocument struct {
+	BusinessID    string                 `firestore:"businessId"`
+	ID            int64                  `firestore:"id"`
+	Name          string                 `firestore:"name"`
+	StatusColumns []statusColumnDocument `firestore:"statusColumns"`
+	UpdatedAt     time.Time              `firestore:"updatedAt"`
+}
+
+// statusColumnDocument is the Firestore representation of domain.CRMStatusColumn
+type statusColumnDocument struct {
+	Field   string   `firestore:"field"`
+	Labels  []string `firestore:"labels"`
+	Default string   `firestore:"default,omitempty"`
+}
+
+// dealDocument is the Firestore representation of domain.CRMDeal
+type dealDocument struct {
+	ID         int64          `firestore:"id"`
+	BusinessID string         `firestore:"businessId"`
+	BoardID    int64          `firestore:"boardId"`
+	Name       string         `firestore:"name"`
+	ContactID  string         `firestore:"contactId,omitempty"`
+	Fields     map[string]any `firestore:"fields"`
+	CreatedAt  time.Time      `firestore:"createdAt"`
+	UpdatedAt  time.Time      `firestore:"updatedAt"`
+}
+
+// contactDocument is the Firestore representation of domain.CRMContact
+type contactDocument struct {
+	ID         string    `firestore:"id"`
+	BusinessID string    `firestore:"businessId"`
+	Name       string    `firestore:"name,omitempty"`
+	Email      string    `firestore:"email,omitempty"`
+	Phone      string    `firestore:"phone,omitempty"`
+	CreatedAt  time.Time `firestore:"createdAt"`
+	UpdatedAt  time.Time `firestore:"updatedAt"`
+}
+
+func (s *FirestoreCRMStore) collection(name string) *firestore.CollectionRef {
+	return s.client.GetClient().Collection(s.prefix + name)
+}
+
+// SaveBoard inserts or replaces a board
+func (s *FirestoreCRMStore) SaveBoard(ctx context.Context, board *domain.CRMBoard) error {
+	board.UpdatedAt = time.Now().Truncate(time.Microsecond)
+
+	doc := boardDocument{
+		BusinessID: board.BusinessID,
+		ID:         board.ID,
+		Name:       board.Name,
+		UpdatedAt:  board.UpdatedAt,
+	}
+	for _, column := range board.StatusColumns {
+		doc.StatusColumns = append(doc.StatusColumns, statusColumnDocument(column))
+	}
+	ref := s.collection(crmBoardsCollection).Doc(fmt.Sprintf("%s_%d", board.BusinessID, board.ID))
+	if _, err := ref.Set(ctx, &doc); err != nil {
+		return fmt.Errorf("failed to save board %d: %w", board.ID, err)
+	}
+	return nil
+}
+
+// ListBoards returns the boards of a business, by ID
+func (s *FirestoreCRMStore) ListBoards(ctx context.Context, businessID string) ([]*domain.CRMBoard, error) {
+	iter := s.collection(crmBoardsCollection).
+		Where("businessId", "==", businessID).
+		OrderBy("id", firestore.Asc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	boards := []*domain.CRMBoard{}
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list boards: %w", err)
+		}
+
+		var doc boardDocument
+		if err := snap.DataTo(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode board %s: %w", snap.Ref.ID, err)
+		}
+		board := &domain.CRMBoard{
+			BusinessID: doc.BusinessID,
+			ID:         doc.ID,
+			Name:       doc.Name,
+			UpdatedAt:  doc.UpdatedAt.UTC(),
+		}
+		for _, column := range doc.StatusColumns {
+			board.StatusColumns = append(board.StatusColumns, domain.CRMStatusColumn(column))
+		}
+		boards = append(boards, board)
+	}
+	return boards, nil
+}
+
+// CreateDeal inserts a deal and sets its ID
+func (s *FirestoreCRMStore) CreateDeal(ctx context.Context, deal *domain.CRMDeal) error {
+	// Firestore timestamps have microsecond precision
+	deal.CreatedAt = time.Now().Truncate(time.Microsecond)
+	deal.UpdatedAt = deal.CreatedAt
+
+	counter := s.collection(crmCountersCollection).Doc("deals")
+	var id int64
+	err := s.client.GetClient().RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		id = 1
+		snap, err := tx.Get(counter)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			last, err := snap.DataAt("last")
+			if err != nil {
+				return err
+			}
+			lastID, ok := last.(int64)
+			if !ok {
+				return fmt.Errorf("invalid deal counter %v", last)
+			}
+			id = lastID + 1
+		}
+
+		doc := dealDocument(*deal)
+		doc.ID = id
+		if err := tx.Set(counter, map[string]any{"last": id}); err != nil {
+			return err
+		}
+		return tx.Create(s.collection(crmDealsCollection).Doc(strconv.FormatInt(id, 10)), &doc)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create deal %q: %w", deal.Name, err)
+	}
+	deal.ID = id
+	return nil
+}
+
+// SaveDeal replaces an existing deal
+func (s *FirestoreCRMStore) SaveDeal(ctx context.Context, deal *domain.CRMDeal) error {
+	deal.CreatedAt = deal.CreatedAt.Truncate(time.Microsecond)
+	deal.UpdatedAt = time.Now().Truncate(time.Microsecond)
+
+	ref := s.collection(crmDealsCollection).Doc(strconv.FormatInt(deal.ID, 10))
+	err := s.client.GetClient().RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("%w: %d", ports.ErrCRMDealNotFound, deal.ID)
+		}
+		if err != nil {
+			return err
+		}
+		if businessID, _ := snap.DataAt("businessId"); businessID != deal.BusinessID {
+			return fmt.Errorf("%w: %d", ports.ErrCRMDealNotFound, deal.ID)
+		}
+		doc := dealDocument(*deal)
+		return tx.Set(ref, &doc)
+	})
+	if errors.Is(err, ports.ErrCRMDealNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save deal %d: %w", deal.ID, err)
+	}
+	return nil
+}
+
+// GetDeal retrieves a deal of a business by ID
+func (s *FirestoreCRMStore) GetDeal(ctx context.Context, businessID string, id int64) (*domain.CRMDeal, error) {
+	snap, err := s.collection(crmDealsCollection).Doc(strconv.FormatInt(id, 10)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %d", ports.ErrCRMDealNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get deal %d: %w", id, err)
+	}
+
+	var doc dealDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode deal %d: %w", id, err)
+	}
+	if doc.BusinessID != businessID {
+		return nil, fmt.Errorf("%w: %d", ports.ErrCRMDealNotFound, id)
+	}
+	return doc.toDeal(), nil
+}
+
+// ListDeals returns the deals matching the query, most recently updated first
+func (s *FirestoreCRMStore) ListDeals(ctx context.Context, query ports.DealQuery) ([]*domain.CRMDeal, error) {
+	q := s.collection(crmDealsCollection).Where("businessId", "==", query.BusinessID)
+	if query.BoardID != 0 {
+		q = q.Where("boardId", "==", query.BoardID)
+	}
+	fields := make([]string, 0, len(query.Fields))
+	for field := range query.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		q = q.WherePath(firestore.FieldPath{"fields", field}, "==", query.Fields[field])
+	}
+	q = q.OrderBy("updatedAt", firestore.Desc).OrderBy("id", firestore.Desc)
+	// Firestore cannot match substrings, so searches read every deal the
+	// filters leave
+	if query.Limit > 0 && query.Search == "" {
+		q = q.Limit(query.Limit)
+	}
+
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	deals := []*domain.CRMDeal{}
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deals: %w", err)
+		}
+
+		var doc dealDocument
+		if err := snap.DataTo(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode deal %s: %w", snap.Ref.ID, err)
+		}
+		deal := doc.toDeal()
+		if !query.Matches(deal) {
+			continue
+		}
+		deals = append(deals, deal)
+		if query.Limit > 0 && len(deals) == query.Limit {
+			break
+		}
+	}
+	return deals, nil
+}
+
+// SaveContact inserts or replaces a contact
+func (s *FirestoreCRMStore) SaveContact(ctx context.Context, contact *domain.CRMContact) error {
+	if contact.CreatedAt.IsZero() {
+		contact.CreatedAt = time.Now()
+	}
+	contact.CreatedAt = contact.CreatedAt.Truncate(time.Microsecond)
+	contact.UpdatedAt = time.Now().Truncate(time.Microsecond)
+
+	doc := contactDocument(*contact)
+	if _, err := s.collection(crmContactsCollection).Doc(contact.ID).Set(ctx, &doc); err != nil {
+		return fmt.Errorf("failed to save contact %s: %w", contact.ID, err)
+	}
+	return nil
+}
+
+// GetContact retrieves a contact of a business by ID
+func (s *FirestoreCRMStore) GetContact(ctx context.Context, businessID, id string) (*domain.CRMContact, error) {
+	snap, err := s.collection(crmContactsCollection).Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %s", ports.ErrCRMContactNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get contact %s: %w", id, err)
+	}
+
+	var doc contactDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode contact %s: %w", id, err)
+	}
+	if doc.BusinessID != businessID {
+		return nil, fmt.Errorf("%w: %s", ports.ErrCRMContactNotFound, id)
+	}
+	contact := domain.CRMContact(doc)
+	contact.CreatedAt = doc.CreatedAt.UTC()
+	contact.UpdatedAt = doc.UpdatedAt.UTC()
+	return &contact, nil
+}
+
+func (d *dealDocument) toDeal() *domain.CRMDeal {
+	deal := domain.CRMDeal(*d)
+	deal.CreatedAt = d.CreatedAt.UTC()
+	deal.UpdatedAt = d.UpdatedAt.UTC()
+	return &deal
+}
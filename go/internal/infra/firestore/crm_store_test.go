@@ -0,0 +1,21 @@
+package firestore
+
+import (
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+// TestFirestoreCRMStore runs the CRMStore conformance suite against the
+// Firestore emulator or, without one, the in-process fake; see newTestClient.
+func TestFirestoreCRMStore(t *testing.T) {
+	client := newTestClient(t)
+	portstest.RunCRMStoreTests(t, func(t *testing.T) ports.CRMStore {
+		// Each test gets its own collections so results don't mix
+		return &FirestoreCRMStore{
+			client: client,
+			prefix: testCollection("crm") + "_",
+		}
+	})
+}
@@ -0,0 +1,584 @@
+package firestore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testRun and testCollections make collection names unique, so tests against
+// a shared emulator do not see each other's documents
+var (
+	testRun         = time.Now().UnixNano()
+	testCollections atomic.Int64
+)
+
+// testCollection returns a collection name no other test uses
+func testCollection(name string) string {
+	return fmt.Sprintf("%s_test_%d_%d", name, testRun, testCollections.Add(1))
+}
+
+// newTestClient returns a client for the Firestore emulator when
+// FIRESTORE_EMULATOR_HOST is set (start it with `gcloud emulators firestore
+// start`), and for an in-process fake of the Firestore API otherwise, so the
+// conformance suites always run.
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+		t.Setenv("FIRESTORE_EMULATOR_HOST", startFakeFirestore(t))
+	}
+
+	client, err := NewClient(context.Background(), "bizops360-test")
+	if err != nil {
+		t.Fatalf("failed to create Firestore client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// startFakeFirestore serves a fakeFirestore on a local port and returns its address
+func startFakeFirestore(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterFirestoreServer(srv, newFakeFirestore())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+// fakeFirestore implements the parts of the Firestore API the repositories
+// use: document reads and writes, read-write transactions and structured
+// queries with field filters, ordering, cursors and limits.
+//
+// Transactions are optimistic: a commit is aborted when a document the
+// transaction read has changed since, and the client library retries it.
+type fakeFirestore struct {
+	pb.UnimplementedFirestoreServer
+
+	mu   sync.Mutex
+	docs map[string]*pb.Document
+	// versions counts the writes to each document name, including deletes
+	versions map[string]int64
+	// txReads holds the document versions each open transaction has read
+	txReads map[string]map[string]int64
+	nextTx  int64
+	clock   time.Time
+}
+
+func newFakeFirestore() *fakeFirestore {
+	return &fakeFirestore{
+		docs:     make(map[string]*pb.Document),
+		versions: make(map[string]int64),
+		txReads:  make(map[string]map[string]int64),
+	}
+}
+
+// now returns a strictly increasing timestamp; callers hold f.mu
+func (f *fakeFirestore) now() *timestamppb.Timestamp {
+	now := time.Now()
+	if !now.After(f.clock) {
+		now = f.clock.Add(time.Microsecond)
+	}
+	f.clock = now
+	return timestamppb.New(now)
+}
+
+// recordRead notes the version of a document read inside a transaction; callers hold f.mu
+func (f *fakeFirestore) recordRead(tx []byte, name string) error {
+	if tx == nil {
+		return nil
+	}
+	reads, ok := f.txReads[string(tx)]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown transaction %q", tx)
+	}
+	reads[name] = f.versions[name]
+	return nil
+}
+
+func (f *fakeFirestore) BeginTransaction(ctx context.Context, req *pb.BeginTransactionRequest) (*pb.BeginTransactionResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextTx++
+	tx := fmt.Sprintf("tx-%d", f.nextTx)
+	f.txReads[tx] = make(map[string]int64)
+	return &pb.BeginTransactionResponse{Transaction: []byte(tx)}, nil
+}
+
+func (f *fakeFirestore) Rollback(ctx context.Context, req *pb.RollbackRequest) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.txReads, string(req.Transaction))
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeFirestore) BatchGetDocuments(req *pb.BatchGetDocumentsRequest, stream pb.Firestore_BatchGetDocumentsServer) error {
+	f.mu.Lock()
+	readTime := f.now()
+	responses := make([]*pb.BatchGetDocumentsResponse, 0, len(req.Documents))
+	for _, name := range req.Documents {
+		if err := f.recordRead(req.GetTransaction(), name); err != nil {
+			f.mu.Unlock()
+			return err
+		}
+		res := &pb.BatchGetDocumentsResponse{ReadTime: readTime}
+		if doc, ok := f.docs[name]; ok {
+			res.Result = &pb.BatchGetDocumentsResponse_Found{Found: proto.Clone(doc).(*pb.Document)}
+		} else {
+			res.Result = &pb.BatchGetDocumentsResponse_Missing{Missing: name}
+		}
+		responses = append(responses, res)
+	}
+	f.mu.Unlock()
+
+	for _, res := range responses {
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeFirestore) Commit(ctx context.Context, req *pb.CommitRequest) (*pb.CommitResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if req.Transaction != nil {
+		reads, ok := f.txReads[string(req.Transaction)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown transaction %q", req.Transaction)
+		}
+		delete(f.txReads, string(req.Transaction))
+		for name, version := range reads {
+			if f.versions[name] != version {
+				return nil, status.Errorf(codes.Aborted, "document %s changed during the transaction", name)
+			}
+		}
+	}
+
+	// Check every precondition before applying any write, so a commit is all or nothing
+	for _, w := range req.Writes {
+		if len(w.UpdateTransforms) > 0 || w.UpdateMask != nil || w.GetTransform() != nil {
+			return nil, status.Error(codes.Unimplemented, "fake firestore only supports whole-document writes")
+		}
+		name := w.GetDelete()
+		if doc := w.GetUpdate(); doc != nil {
+			name = doc.Name
+		}
+		if pre := w.CurrentDocument; pre != nil {
+			_, exists := f.docs[name]
+			if want, ok := pre.ConditionType.(*pb.Precondition_Exists); ok && want.Exists != exists {
+				if exists {
+					return nil, status.Errorf(codes.AlreadyExists, "document %s already exists", name)
+				}
+				return nil, status.Errorf(codes.NotFound, "document %s not found", name)
+			}
+			if _, ok := pre.ConditionType.(*pb.Precondition_UpdateTime); ok {
+				return nil, status.Error(codes.Unimplemented, "fake firestore does not support update time preconditions")
+			}
+		}
+	}
+
+	commitTime := f.now()
+	results := make([]*pb.WriteResult, len(req.Writes))
+	for i, w := range req.Writes {
+		if name := w.GetDelete(); name != "" {
+			delete(f.docs, name)
+			f.versions[name]++
+		} else {
+			doc := proto.Clone(w.GetUpdate()).(*pb.Document)
+			doc.CreateTime = commitTime
+			if existing, ok := f.docs[doc.Name]; ok {
+				doc.CreateTime = existing.CreateTime
+			}
+			doc.UpdateTime = commitTime
+			f.docs[doc.Name] = doc
+			f.versions[doc.Name]++
+		}
+		results[i] = &pb.WriteResult{UpdateTime: commitTime}
+	}
+	return &pb.CommitResponse{WriteResults: results, CommitTime: commitTime}, nil
+}
+
+func (f *fakeFirestore) RunQuery(req *pb.RunQueryRequest, stream pb.Firestore_RunQueryServer) error {
+	query := req.GetStructuredQuery()
+	if query == nil {
+		return status.Error(codes.InvalidArgument, "structured query required")
+	}
+	if len(query.From) != 1 || query.From[0].AllDescendants {
+		return status.Error(codes.Unimplemented, "fake firestore only queries a single collection")
+	}
+	if query.Offset != 0 || query.EndAt != nil || query.Select != nil || query.FindNearest != nil {
+		return status.Error(codes.Unimplemented, "fake firestore does not support offset, end cursors, projections or vector search")
+	}
+	prefix := req.Parent + "/" + query.From[0].CollectionId + "/"
+
+	orders := query.OrderBy
+	if n := len(orders); n == 0 || orders[n-1].Field.FieldPath != "__name__" {
+		// Like Firestore, break ties by document name
+		direction := pb.StructuredQuery_ASCENDING
+		if n > 0 {
+			direction = orders[n-1].Direction
+		}
+		orders = append(orders, &pb.StructuredQuery_Order{
+			Field:     &pb.StructuredQuery_FieldReference{FieldPath: "__name__"},
+			Direction: direction,
+		})
+	}
+
+	f.mu.Lock()
+	readTime := f.now()
+	var docs []*pb.Document
+	for name, doc := range f.docs {
+		if !strings.HasPrefix(name, prefix) || strings.Contains(name[len(prefix):], "/") {
+			continue
+		}
+		match, err := matchesFilter(doc, query.Where)
+		if err != nil {
+			f.mu.Unlock()
+			return err
+		}
+		// Documents without an ordered field are left out, as in Firestore
+		for _, order := range orders {
+			if _, ok := fieldValue(doc, order.Field.FieldPath); !ok {
+				match = false
+			}
+		}
+		if match {
+			docs = append(docs, proto.Clone(doc).(*pb.Document))
+		}
+	}
+	f.mu.Unlock()
+
+	sort.Slice(docs, func(i, j int) bool {
+		return compareByOrders(docs[i], docs[j], orders) < 0
+	})
+	if start := query.StartAt; start != nil {
+		kept := docs[:0]
+		for _, doc := range docs {
+			c := compareToCursor(doc, start.Values, orders)
+			if c > 0 || (c == 0 && start.Before) {
+				kept = append(kept, doc)
+			}
+		}
+		docs = kept
+	}
+	if query.Limit != nil && int(query.Limit.Value) < len(docs) {
+		docs = docs[:query.Limit.Value]
+	}
+
+	if tx := req.GetTransaction(); tx != nil {
+		f.mu.Lock()
+		for _, doc := range docs {
+			if err := f.recordRead(tx, doc.Name); err != nil {
+				f.mu.Unlock()
+				return err
+			}
+		}
+		f.mu.Unlock()
+	}
+
+	for _, doc := range docs {
+		if err := stream.Send(&pb.RunQueryResponse{Document: doc, ReadTime: readTime}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchesFilter reports whether a document passes a query filter
+func matchesFilter(doc *pb.Document, filter *pb.StructuredQuery_Filter) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+	switch ft := filter.FilterType.(type) {
+	case *pb.StructuredQuery_Filter_CompositeFilter:
+		if ft.CompositeFilter.Op != pb.StructuredQuery_CompositeFilter_AND {
+			return false, status.Error(codes.Unimplemented, "fake firestore only supports AND filters")
+		}
+		for _, sub := range ft.CompositeFilter.Filters {
+			match, err := matchesFilter(doc, sub)
+			if err != nil || !match {
+				return false, err
+			}
+		}
+		return true, nil
+	case *pb.StructuredQuery_Filter_FieldFilter:
+		return matchesFieldFilter(doc, ft.FieldFilter)
+	default:
+		return false, status.Errorf(codes.Unimplemented, "fake firestore does not support filter %T", ft)
+	}
+}
+
+func matchesFieldFilter(doc *pb.Document, filter *pb.StructuredQuery_FieldFilter) (bool, error) {
+	value, ok := fieldValue(doc, filter.Field.FieldPath)
+	if !ok {
+		return false, nil
+	}
+	// Range filters only match values of the same type, as in Firestore
+	sameType := typeOrder(value) == typeOrder(filter.Value)
+	c := compareValues(value, filter.Value)
+	switch filter.Op {
+	case pb.StructuredQuery_FieldFilter_EQUAL:
+		return c == 0, nil
+	case pb.StructuredQuery_FieldFilter_NOT_EQUAL:
+		return c != 0, nil
+	case pb.StructuredQuery_FieldFilter_LESS_THAN:
+		return sameType && c < 0, nil
+	case pb.StructuredQuery_FieldFilter_LESS_THAN_OR_EQUAL:
+		return sameType && c <= 0, nil
+	case pb.StructuredQuery_FieldFilter_GREATER_THAN:
+		return sameType && c > 0, nil
+	case pb.StructuredQuery_FieldFilter_GREATER_THAN_OR_EQUAL:
+		return sameType && c >= 0, nil
+	default:
+		return false, status.Errorf(codes.Unimplemented, "fake firestore does not support operator %s", filter.Op)
+	}
+}
+
+// fieldValue looks up a field path, or __name__ for the document name
+func fieldValue(doc *pb.Document, path string) (*pb.Value, bool) {
+	if path == "__name__" {
+		return &pb.Value{ValueType: &pb.Value_ReferenceValue{ReferenceValue: doc.Name}}, true
+	}
+	fields := doc.Fields
+	segments := parseFieldPath(path)
+	for i, segment := range segments {
+		value, ok := fields[segment]
+		if !ok {
+			return nil, false
+		}
+		if i == len(segments)-1 {
+			return value, true
+		}
+		m := value.GetMapValue()
+		if m == nil {
+			return nil, false
+		}
+		fields = m.Fields
+	}
+	return nil, false
+}
+
+// parseFieldPath splits a field path such as a.`b.c` into its segments
+func parseFieldPath(path string) []string {
+	var segments []string
+	var current strings.Builder
+	quoted, escaped := false, false
+	for _, r := range path {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '`':
+			quoted = !quoted
+		case !quoted && r == '.':
+			segments = append(segments, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(segments, current.String())
+}
+
+// compareByOrders compares two documents by the query's order-by clauses
+func compareByOrders(a, b *pb.Document, orders []*pb.StructuredQuery_Order) int {
+	for _, order := range orders {
+		av, _ := fieldValue(a, order.Field.FieldPath)
+		bv, _ := fieldValue(b, order.Field.FieldPath)
+		if c := directed(compareValues(av, bv), order.Direction); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareToCursor compares a document with a cursor position; cursors may
+// name fewer values than there are orders
+func compareToCursor(doc *pb.Document, cursor []*pb.Value, orders []*pb.StructuredQuery_Order) int {
+	for i, value := range cursor {
+		if i >= len(orders) {
+			break
+		}
+		v, _ := fieldValue(doc, orders[i].Field.FieldPath)
+		if c := directed(compareValues(v, value), orders[i].Direction); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func directed(c int, direction pb.StructuredQuery_Direction) int {
+	if direction == pb.StructuredQuery_DESCENDING {
+		return -c
+	}
+	return c
+}
+
+// typeOrder ranks value types the way Firestore orders mixed types
+func typeOrder(v *pb.Value) int {
+	switch v.GetValueType().(type) {
+	case *pb.Value_NullValue:
+		return 0
+	case *pb.Value_BooleanValue:
+		return 1
+	case *pb.Value_IntegerValue, *pb.Value_DoubleValue:
+		return 2
+	case *pb.Value_TimestampValue:
+		return 3
+	case *pb.Value_StringValue:
+		return 4
+	case *pb.Value_BytesValue:
+		return 5
+	case *pb.Value_ReferenceValue:
+		return 6
+	case *pb.Value_GeoPointValue:
+		return 7
+	case *pb.Value_ArrayValue:
+		return 8
+	default:
+		return 9
+	}
+}
+
+// compareValues orders two Firestore values
+func compareValues(a, b *pb.Value) int {
+	if ta, tb := typeOrder(a), typeOrder(b); ta != tb {
+		return compareInts(int64(ta), int64(tb))
+	}
+	switch av := a.GetValueType().(type) {
+	case *pb.Value_BooleanValue:
+		x, y := 0, 0
+		if av.BooleanValue {
+			x = 1
+		}
+		if b.GetBooleanValue() {
+			y = 1
+		}
+		return compareInts(int64(x), int64(y))
+	case *pb.Value_IntegerValue, *pb.Value_DoubleValue:
+		_, aInt := av.(*pb.Value_IntegerValue)
+		_, bInt := b.GetValueType().(*pb.Value_IntegerValue)
+		if aInt && bInt {
+			return compareInts(a.GetIntegerValue(), b.GetIntegerValue())
+		}
+		return compareFloats(number(a), number(b))
+	case *pb.Value_TimestampValue:
+		return av.TimestampValue.AsTime().Compare(b.GetTimestampValue().AsTime())
+	case *pb.Value_StringValue:
+		return strings.Compare(av.StringValue, b.GetStringValue())
+	case *pb.Value_BytesValue:
+		return bytes.Compare(av.BytesValue, b.GetBytesValue())
+	case *pb.Value_ReferenceValue:
+		return compareReferences(av.ReferenceValue, b.GetReferenceValue())
+	case *pb.Value_GeoPointValue:
+		if c := compareFloats(av.GeoPointValue.Latitude, b.GetGeoPointValue().Latitude); c != 0 {
+			return c
+		}
+		return compareFloats(av.GeoPointValue.Longitude, b.GetGeoPointValue().Longitude)
+	case *pb.Value_ArrayValue:
+		x, y := av.ArrayValue.GetValues(), b.GetArrayValue().GetValues()
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if c := compareValues(x[i], y[i]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(int64(len(x)), int64(len(y)))
+	case *pb.Value_MapValue:
+		return compareMaps(av.MapValue.GetFields(), b.GetMapValue().GetFields())
+	default:
+		return 0
+	}
+}
+
+func compareMaps(a, b map[string]*pb.Value) int {
+	keys := func(m map[string]*pb.Value) []string {
+		ks := make([]string, 0, len(m))
+		for k := range m {
+			ks = append(ks, k)
+		}
+		sort.Strings(ks)
+		return ks
+	}
+	ak, bk := keys(a), keys(b)
+	for i := 0; i < len(ak) && i < len(bk); i++ {
+		if c := strings.Compare(ak[i], bk[i]); c != 0 {
+			return c
+		}
+		if c := compareValues(a[ak[i]], b[bk[i]]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(int64(len(ak)), int64(len(bk)))
+}
+
+// compareReferences orders document names segment by segment
+func compareReferences(a, b string) int {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := strings.Compare(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(int64(len(as)), int64(len(bs)))
+}
+
+func number(v *pb.Value) float64 {
+	if i, ok := v.GetValueType().(*pb.Value_IntegerValue); ok {
+		return float64(i.IntegerValue)
+	}
+	return v.GetDoubleValue()
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareFloats orders NaN before every other number, as Firestore does
+func compareFloats(a, b float64) int {
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return -1
+	case math.IsNaN(b):
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
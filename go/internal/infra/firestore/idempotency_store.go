@@ -0,0 +1,123 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// idempotencyCollection is the Firestore collection holding idempotency keys
+const idempotencyCollection = "idempotency_keys"
+
+// FirestoreIdempotencyStore stores idempotency keys in Firestore, one document
+// per key, so that every instance of the API shares them. Configure a TTL
+// policy on expiresAt to have Firestore delete expired keys.
+type FirestoreIdempotencyStore struct {
+	client     *Client
+	collection string
+}
+
+// NewFirestoreIdempotencyStore creates a Firestore-backed idempotency store
+func NewFirestoreIdempotencyStore(client *Client) ports.IdempotencyStore {
+	return &FirestoreIdempotencyStore{
+		client:     client,
+		collection: idempotencyCollection,
+	}
+}
+
+// idempotencyDocument is the Firestore representation of a claimed key
+type idempotencyDocument struct {
+	Completed  bool      `firestore:"completed"`
+	StatusCode int       `firestore:"statusCode"`
+	Body       []byte    `firestore:"body"`
+	ExpiresAt  time.Time `firestore:"expiresAt"`
+}
+
+func (s *FirestoreIdempotencyStore) doc(key string) *firestore.DocumentRef {
+	return s.client.GetClient().Collection(s.collection).Doc(key)
+}
+
+// Begin claims key unless a request with it is in flight or has completed
+func (s *FirestoreIdempotencyStore) Begin(ctx context.Context, key string, lease time.Duration) (*ports.IdempotentResponse, error) {
+	ref := s.doc(key)
+
+	var response *ports.IdempotentResponse
+	err := s.client.GetClient().RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		response = nil
+		now := time.Now()
+
+		snap, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var doc idempotencyDocument
+			if err := snap.DataTo(&doc); err != nil {
+				return err
+			}
+			if now.Before(doc.ExpiresAt) {
+				if !doc.Completed {
+					return ports.ErrIdempotencyKeyInFlight
+				}
+				response = &ports.IdempotentResponse{StatusCode: doc.StatusCode, Body: doc.Body}
+				return nil
+			}
+		}
+
+		return tx.Set(ref, idempotencyDocument{ExpiresAt: now.Add(lease)})
+	})
+	if errors.Is(err, ports.ErrIdempotencyKeyInFlight) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim idempotency key %s: %w", key, err)
+	}
+	return response, nil
+}
+
+// Complete stores the response of the request holding key for ttl
+func (s *FirestoreIdempotencyStore) Complete(ctx context.Context, key string, response *ports.IdempotentResponse, ttl time.Duration) error {
+	_, err := s.doc(key).Set(ctx, idempotencyDocument{
+		Completed:  true,
+		StatusCode: response.StatusCode,
+		Body:       response.Body,
+		ExpiresAt:  time.Now().Add(ttl),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store response for idempotency key %s: %w", key, err)
+	}
+	return nil
+}
+
+// Release forgets an in-flight key
+func (s *FirestoreIdempotencyStore) Release(ctx context.Context, key string) error {
+	ref := s.doc(key)
+	err := s.client.GetClient().RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var doc idempotencyDocument
+		if err := snap.DataTo(&doc); err != nil {
+			return err
+		}
+		if doc.Completed {
+			return nil
+		}
+		return tx.Delete(ref)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package firestore
+
+import (
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+// TestFirestoreIdempotencyStore runs the IdempotencyStore conformance suite against the Firestore
+// emulator or, without one, the in-process fake; see newTestClient.
+func TestFirestoreIdempotencyStore(t *testing.T) {
+	client := newTestClient(t)
+	portstest.RunIdempotencyStoreTests(t, func(t *testing.T) ports.IdempotencyStore {
+		// Each test gets its own collection so results don't mix
+		return &FirestoreIdempotencyStore{
+			client:     client,
+			collection: testCollection("idempotency_keys"),
+		}
+	})
+}
@@ -0,0 +1,331 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// jobsCollection is the Firestore collection holding pipeline jobs
+const jobsCollection = "jobs"
+
+// FirestoreJobsRepo stores pipeline jobs in Firestore, one document per job with
+// its steps embedded.
+//
+// List queries filter on businessId, status, pipelineKey and createdAt and order by
+// createdAt and document ID, so they need composite indexes such as
+// (businessId ASC, status ASC, createdAt DESC, __name__ DESC). Firestore reports the
+// exact index to create the first time a query needs it.
+type FirestoreJobsRepo struct {
+	client     *Client
+	collection string
+}
+
+// NewFirestoreJobsRepo creates a Firestore-backed jobs repository
+func NewFirestoreJobsRepo(client *Client) ports.JobsRepo {
+	return &FirestoreJobsRepo{
+		client:     client,
+		collection: jobsCollection,
+	}
+}
+
+// jobDocument is the Firestore representation of domain.Job
+type jobDocument struct {
+	ID          string            `firestore:"id"`
+	BusinessID  string            `firestore:"businessId"`
+	PipelineKey string            `firestore:"pipelineKey"`
+	Status      string            `firestore:"status"`
+	Steps       []stepDocument    `firestore:"steps"`
+	Input       map[string]any    `firestore:"input"`
+	Result      map[string]any    `firestore:"result"`
+	CreatedAt   time.Time         `firestore:"createdAt"`
+	UpdatedAt   time.Time         `firestore:"updatedAt"`
+	Source      string            `firestore:"source,omitempty"`
+	DryRun      bool              `firestore:"dryRun,omitempty"`
+	Options     map[string]any    `firestore:"options,omitempty"`
+	Resource    *resourceDocument `firestore:"resource,omitempty"`
+	ReplayOf    string            `firestore:"replayOf,omitempty"`
+}
+
+// resourceDocument is the Firestore representation of domain.ResourceContext
+type resourceDocument struct {
+	Type    string         `firestore:"type"`
+	BoardID *int64         `firestore:"boardId,omitempty"`
+	ItemID  *int64         `firestore:"itemId,omitempty"`
+	Data    map[string]any `firestore:"data,omitempty"`
+}
+
+// stepDocument is the Firestore representation of domain.JobStep
+type stepDocument struct {
+	Name        string               `firestore:"name"`
+	Status      string               `firestore:"status"`
+	Critical    bool                 `firestore:"critical"`
+	Error       *string              `firestore:"error"`
+	Details     map[string]any       `firestore:"details"`
+	Permanent   bool                 `firestore:"permanent"`
+	Outputs     map[string]any       `firestore:"outputs,omitempty"`
+	Config      map[string]any       `firestore:"config,omitempty"`
+	SubJob      *jobDocument         `firestore:"subJob,omitempty"`
+	StartedAt   time.Time            `firestore:"startedAt,omitempty"`
+	FinishedAt  time.Time            `firestore:"finishedAt,omitempty"`
+	DurationMs  int64                `firestore:"durationMs"`
+	TimeoutMs   int64                `firestore:"timeoutMs,omitempty"`
+	SideEffects []sideEffectDocument `firestore:"sideEffects,omitempty"`
+}
+
+// sideEffectDocument is the Firestore representation of domain.SideEffect
+type sideEffectDocument struct {
+	Kind    string         `firestore:"kind"`
+	Summary string         `firestore:"summary"`
+	Details map[string]any `firestore:"details,omitempty"`
+}
+
+func toJobDocument(job *domain.Job) *jobDocument {
+	var resource *resourceDocument
+	if job.Resource != nil {
+		resource = &resourceDocument{
+			Type:    job.Resource.Type,
+			BoardID: job.Resource.BoardID,
+			ItemID:  job.Resource.ItemID,
+			Data:    job.Resource.Data,
+		}
+	}
+	steps := make([]stepDocument, len(job.Steps))
+	for i, step := range job.Steps {
+		steps[i] = stepDocument{
+			Name:       step.Name,
+			Status:     step.Status,
+			Critical:   step.Critical,
+			Error:      step.Error,
+			Details:    step.Details,
+			Permanent:  step.Permanent,
+			Outputs:    step.Outputs,
+			Config:     step.Config,
+			StartedAt:  step.StartedAt,
+			FinishedAt: step.FinishedAt,
+			DurationMs: step.DurationMs,
+			TimeoutMs:  step.TimeoutMs,
+		}
+		if step.SubJob != nil {
+			steps[i].SubJob = toJobDocument(step.SubJob)
+		}
+		for _, effect := range step.SideEffects {
+			steps[i].SideEffects = append(steps[i].SideEffects, sideEffectDocument(effect))
+		}
+	}
+	return &jobDocument{
+		ID:          job.ID,
+		BusinessID:  job.BusinessID,
+		PipelineKey: job.PipelineKey,
+		Status:      job.Status,
+		Steps:       steps,
+		Input:       job.Input,
+		Result:      job.Result,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		Source:      job.Source,
+		DryRun:      job.DryRun,
+		Options:     job.Options,
+		Resource:    resource,
+		ReplayOf:    job.ReplayOf,
+	}
+}
+
+func (d *jobDocument) toJob() *domain.Job {
+	var resource *domain.ResourceContext
+	if d.Resource != nil {
+		resource = &domain.ResourceContext{
+			Type:    d.Resource.Type,
+			BoardID: d.Resource.BoardID,
+			ItemID:  d.Resource.ItemID,
+			Data:    d.Resource.Data,
+		}
+	}
+	steps := make([]domain.JobStep, len(d.Steps))
+	for i, step := range d.Steps {
+		steps[i] = domain.JobStep{
+			Name:       step.Name,
+			Status:     step.Status,
+			Critical:   step.Critical,
+			Error:      step.Error,
+			Details:    step.Details,
+			Permanent:  step.Permanent,
+			Outputs:    step.Outputs,
+			Config:     step.Config,
+			StartedAt:  step.StartedAt,
+			FinishedAt: step.FinishedAt,
+			DurationMs: step.DurationMs,
+			TimeoutMs:  step.TimeoutMs,
+		}
+		if step.SubJob != nil {
+			steps[i].SubJob = step.SubJob.toJob()
+		}
+		for _, effect := range step.SideEffects {
+			steps[i].SideEffects = append(steps[i].SideEffects, domain.SideEffect(effect))
+		}
+	}
+	return &domain.Job{
+		ID:          d.ID,
+		BusinessID:  d.BusinessID,
+		PipelineKey: d.PipelineKey,
+		Status:      d.Status,
+		Steps:       steps,
+		Input:       d.Input,
+		Result:      d.Result,
+		CreatedAt:   d.CreatedAt.UTC(),
+		UpdatedAt:   d.UpdatedAt.UTC(),
+		Source:      d.Source,
+		DryRun:      d.DryRun,
+		Options:     d.Options,
+		Resource:    resource,
+		ReplayOf:    d.ReplayOf,
+	}
+}
+
+func (r *FirestoreJobsRepo) jobs() *firestore.CollectionRef {
+	return r.client.GetClient().Collection(r.collection)
+}
+
+// Save saves a job, replacing any previous version
+func (r *FirestoreJobsRepo) Save(ctx context.Context, job *domain.Job) error {
+	// Firestore timestamps have microsecond precision
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+	job.CreatedAt = job.CreatedAt.Truncate(time.Microsecond)
+	job.UpdatedAt = time.Now().Truncate(time.Microsecond)
+
+	if _, err := r.jobs().Doc(job.ID).Set(ctx, toJobDocument(job)); err != nil {
+		return fmt.Errorf("failed to save job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// GetByID retrieves a job by ID
+func (r *FirestoreJobsRepo) GetByID(ctx context.Context, id string) (*domain.Job, error) {
+	snap, err := r.jobs().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %s", ports.ErrJobNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get job %s: %w", id, err)
+	}
+
+	var doc jobDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode job %s: %w", id, err)
+	}
+	return doc.toJob(), nil
+}
+
+// CompareAndSetStatus changes a job's status from one value to another in a transaction
+func (r *FirestoreJobsRepo) CompareAndSetStatus(ctx context.Context, id, from, to string) (bool, error) {
+	ref := r.jobs().Doc(id)
+
+	swapped := false
+	err := r.client.GetClient().RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		swapped = false
+
+		snap, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		var doc jobDocument
+		if err := snap.DataTo(&doc); err != nil {
+			return err
+		}
+		if doc.Status != from {
+			return nil
+		}
+
+		doc.Status = to
+		doc.UpdatedAt = time.Now().Truncate(time.Microsecond)
+		swapped = true
+		return tx.Set(ref, &doc)
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return false, fmt.Errorf("%w: %s", ports.ErrJobNotFound, id)
+		}
+		return false, fmt.Errorf("failed to update status of job %s: %w", id, err)
+	}
+	return swapped, nil
+}
+
+// GetByBusinessID retrieves jobs for a business, newest first
+func (r *FirestoreJobsRepo) GetByBusinessID(ctx context.Context, businessID string, limit int) ([]*domain.Job, error) {
+	page, err := r.List(ctx, ports.JobQuery{BusinessID: businessID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return page.Jobs, nil
+}
+
+// List retrieves jobs matching the query, newest first
+func (r *FirestoreJobsRepo) List(ctx context.Context, query ports.JobQuery) (*ports.JobPage, error) {
+	q := r.jobs().Query
+	if query.BusinessID != "" {
+		q = q.Where("businessId", "==", query.BusinessID)
+	}
+	if query.Status != "" {
+		q = q.Where("status", "==", query.Status)
+	}
+	if query.PipelineKey != "" {
+		q = q.Where("pipelineKey", "==", query.PipelineKey)
+	}
+	if !query.CreatedAfter.IsZero() {
+		q = q.Where("createdAt", ">=", query.CreatedAfter)
+	}
+	if !query.CreatedBefore.IsZero() {
+		q = q.Where("createdAt", "<", query.CreatedBefore)
+	}
+	q = q.OrderBy("createdAt", firestore.Desc).OrderBy(firestore.DocumentID, firestore.Desc)
+
+	if query.Cursor != "" {
+		cursor, err := ports.DecodeJobCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		q = q.StartAfter(cursor.CreatedAt, cursor.ID)
+	}
+
+	// Fetch one extra document to know whether there is a next page
+	limit := query.PageLimit()
+	q = q.Limit(limit + 1)
+
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	jobs := []*domain.Job{}
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list jobs: %w", err)
+		}
+
+		var doc jobDocument
+		if err := snap.DataTo(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode job %s: %w", snap.Ref.ID, err)
+		}
+		jobs = append(jobs, doc.toJob())
+	}
+
+	page := &ports.JobPage{Jobs: jobs}
+	if len(jobs) > limit {
+		page.Jobs = jobs[:limit]
+		page.NextCursor = ports.EncodeJobCursor(page.Jobs[limit-1])
+	}
+	return page, nil
+}
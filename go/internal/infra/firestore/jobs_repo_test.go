@@ -0,0 +1,21 @@
+package firestore
+
+import (
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+// TestFirestoreJobsRepo runs the JobsRepo conformance suite against the Firestore
+// emulator or, without one, the in-process fake; see newTestClient.
+func TestFirestoreJobsRepo(t *testing.T) {
+	client := newTestClient(t)
+	portstest.RunJobsRepoTests(t, func(t *testing.T) ports.JobsRepo {
+		// Each test gets its own collection so results don't mix
+		return &FirestoreJobsRepo{
+			client:     client,
+			collection: testCollection("jobs"),
+		}
+	})
+}
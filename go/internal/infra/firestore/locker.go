@@ -0,0 +1,76 @@
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// locksCollection is the Firestore collection holding locks
+const locksCollection = "locks"
+
+// FirestoreLocker stores locks in Firestore, one document per lock key, so that
+// every instance of the API shares them
+type FirestoreLocker struct {
+	client     *Client
+	collection string
+}
+
+// NewFirestoreLocker creates a Firestore-backed locker
+func NewFirestoreLocker(client *Client) ports.Locker {
+	return &FirestoreLocker{
+		client:     client,
+		collection: locksCollection,
+	}
+}
+
+// lockDocument is the Firestore representation of a held lock
+type lockDocument struct {
+	ExpiresAt time.Time `firestore:"expiresAt"`
+}
+
+// TryLock acquires the lock named key for ttl unless an unexpired holder has it
+func (l *FirestoreLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ref := l.client.GetClient().Collection(l.collection).Doc(key)
+
+	acquired := false
+	err := l.client.GetClient().RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		acquired = false
+		now := time.Now()
+
+		snap, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var doc lockDocument
+			if err := snap.DataTo(&doc); err != nil {
+				return err
+			}
+			if now.Before(doc.ExpiresAt) {
+				return nil
+			}
+		}
+
+		acquired = true
+		return tx.Set(ref, lockDocument{ExpiresAt: now.Add(ttl)})
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	return acquired, nil
+}
+
+// Unlock releases the lock named key
+func (l *FirestoreLocker) Unlock(ctx context.Context, key string) error {
+	if _, err := l.client.GetClient().Collection(l.collection).Doc(key).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", key, err)
+	}
+	return nil
+}
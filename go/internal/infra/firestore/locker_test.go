@@ -0,0 +1,21 @@
+package firestore
+
+import (
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+// TestFirestoreLocker runs the Locker conformance suite against the Firestore
+// emulator or, without one, the in-process fake; see newTestClient.
+func TestFirestoreLocker(t *testing.T) {
+	client := newTestClient(t)
+	portstest.RunLockerTests(t, func(t *testing.T) ports.Locker {
+		// Each test gets its own collection so results don't mix
+		return &FirestoreLocker{
+			client:     client,
+			collection: testCollection("locks"),
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// pipelinesCollection is the Firestore collection holding pipeline definitions
+const pipelinesCollection = "pipelines"
+
+// FirestorePipelinesRepo stores pipeline definitions in Firestore, one
+// document per pipeline keyed by pipeline key. Definitions are stored with
+// their includes expanded, as config import writes them.
+type FirestorePipelinesRepo struct {
+	client     *Client
+	collection string
+}
+
+// NewFirestorePipelinesRepo creates a Firestore-backed pipelines repository
+func NewFirestorePipelinesRepo(client *Client) ports.PipelinesRepo {
+	return &FirestorePipelinesRepo{
+		client:     client,
+		collection: pipelinesCollection,
+	}
+}
+
+func (r *FirestorePipelinesRepo) pipelines() *firestore.CollectionRef {
+	return r.client.GetClient().Collection(r.collection)
+}
+
+// GetByKey retrieves a pipeline definition by key
+func (r *FirestorePipelinesRepo) GetByKey(ctx context.Context, key string) (*domain.PipelineDefinition, error) {
+	snap, err := r.pipelines().Doc(key).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %s", ports.ErrPipelineNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to get pipeline %s: %w", key, err)
+	}
+	return decodePipeline(snap)
+}
+
+// GetAll returns every pipeline definition, sorted by key
+func (r *FirestorePipelinesRepo) GetAll(ctx context.Context) ([]*domain.PipelineDefinition, error) {
+	iter := r.pipelines().OrderBy(firestore.DocumentID, firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	pipelines := []*domain.PipelineDefinition{}
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pipelines: %w", err)
+		}
+		pipeline, err := decodePipeline(snap)
+		if err != nil {
+			return nil, err
+		}
+		pipelines = append(pipelines, pipeline)
+	}
+	return pipelines, nil
+}
+
+// Save inserts or replaces a pipeline definition
+func (r *FirestorePipelinesRepo) Save(ctx context.Context, pipeline *domain.PipelineDefinition) error {
+	if pipeline.Key == "" {
+		return errors.New("pipeline key is required")
+	}
+	doc, err := newConfigDocument(pipeline)
+	if err != nil {
+		return fmt.Errorf("failed to encode pipeline %s: %w", pipeline.Key, err)
+	}
+	if _, err := r.pipelines().Doc(pipeline.Key).Set(ctx, doc); err != nil {
+		return fmt.Errorf("failed to save pipeline %s: %w", pipeline.Key, err)
+	}
+	return nil
+}
+
+func decodePipeline(snap *firestore.DocumentSnapshot) (*domain.PipelineDefinition, error) {
+	var doc configDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode pipeline %s: %w", snap.Ref.ID, err)
+	}
+	var pipeline domain.PipelineDefinition
+	if err := doc.decode(&pipeline); err != nil {
+		return nil, fmt.Errorf("failed to decode pipeline %s: %w", snap.Ref.ID, err)
+	}
+	if pipeline.Key == "" {
+		pipeline.Key = snap.Ref.ID
+	}
+	return &pipeline, nil
+}
@@ -0,0 +1,113 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/config"
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+// TestFirestorePipelinesRepo runs the PipelinesRepo conformance suite against the Firestore
+// emulator or, without one, the in-process fake; see newTestClient.
+func TestFirestorePipelinesRepo(t *testing.T) {
+	client := newTestClient(t)
+	portstest.RunPipelinesRepoTests(t, func(t *testing.T) ports.PipelinesRepo {
+		// Each test gets its own collection so results don't mix
+		return &FirestorePipelinesRepo{
+			client:     client,
+			collection: testCollection("pipelines"),
+		}
+	})
+}
+
+// TestFirestorePipelinesRepo_Validation checks that stored pipelines are
+// validated as they are with CONFIG_STORE=firestore
+func TestFirestorePipelinesRepo_Validation(t *testing.T) {
+	ctx := context.Background()
+	stored := &FirestorePipelinesRepo{client: newTestClient(t), collection: testCollection("pipelines")}
+	repo := config.NewValidatingPipelinesRepo(stored)
+	repo.SetPipelineValidator(domain.NewPipelineRunner(map[string]domain.Action{"normalize_input": nil}))
+
+	invalid := &domain.PipelineDefinition{Key: "invalid", Actions: []domain.ActionDefinition{{Name: "unknown_action"}}}
+	var validationErr *domain.ValidationError
+	if err := repo.Save(ctx, invalid); !errors.As(err, &validationErr) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+	if _, err := stored.GetByKey(ctx, "invalid"); !errors.Is(err, ports.ErrPipelineNotFound) {
+		t.Errorf("expected the invalid pipeline not to be saved, got %v", err)
+	}
+
+	// A pipeline edited in the console is validated when it is loaded
+	valid := &domain.PipelineDefinition{Key: "intake", Actions: []domain.ActionDefinition{{Name: "normalize_input"}}}
+	for _, pipeline := range []*domain.PipelineDefinition{valid, invalid} {
+		if err := stored.Save(ctx, pipeline); err != nil {
+			t.Fatalf("Save(%s) failed: %v", pipeline.Key, err)
+		}
+	}
+	if _, err := repo.GetByKey(ctx, "invalid"); !errors.As(err, &validationErr) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+	pipelines, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(pipelines) != 1 || pipelines[0].Key != "intake" {
+		t.Errorf("expected only the valid pipeline, got %+v", pipelines)
+	}
+}
+
+// TestFirestorePipelinesRepo_Cycles checks that call_pipeline cycles are
+// rejected when pipelines are saved and loaded, not only when they run
+func TestFirestorePipelinesRepo_Cycles(t *testing.T) {
+	ctx := context.Background()
+	stored := &FirestorePipelinesRepo{client: newTestClient(t), collection: testCollection("pipelines")}
+	repo := config.NewValidatingPipelinesRepo(stored)
+	repo.SetPipelineValidator(domain.NewPipelineRunner(map[string]domain.Action{"normalize_input": nil}))
+
+	calling := func(key, called string) *domain.PipelineDefinition {
+		return &domain.PipelineDefinition{Key: key, Actions: []domain.ActionDefinition{
+			{Name: "normalize_input"},
+			{Name: domain.CallPipelineActionName, Config: map[string]any{"pipelineKey": called}},
+		}}
+	}
+	notify := &domain.PipelineDefinition{Key: "notify", Actions: []domain.ActionDefinition{{Name: "normalize_input"}}}
+	for _, pipeline := range []*domain.PipelineDefinition{notify, calling("intake", "notify")} {
+		if err := repo.Save(ctx, pipeline); err != nil {
+			t.Fatalf("Save(%s) failed: %v", pipeline.Key, err)
+		}
+	}
+
+	// Called pipelines must exist
+	if err := repo.Save(ctx, calling("broken", "missing")); err == nil || errors.Is(err, ports.ErrPipelineNotFound) {
+		t.Errorf("expected an error other than ErrPipelineNotFound, got %v", err)
+	}
+
+	// Closing a cycle is rejected
+	if err := repo.Save(ctx, calling("notify", "intake")); !errors.Is(err, config.ErrPipelineCycle) {
+		t.Errorf("expected ErrPipelineCycle, got %v", err)
+	}
+	if err := repo.Save(ctx, calling("loop", "loop")); !errors.Is(err, config.ErrPipelineCycle) {
+		t.Errorf("expected ErrPipelineCycle for a pipeline calling itself, got %v", err)
+	}
+
+	// A cycle made in the console is caught when the pipelines are loaded
+	if err := stored.Save(ctx, calling("notify", "intake")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	for _, key := range []string{"intake", "notify"} {
+		if _, err := repo.GetByKey(ctx, key); !errors.Is(err, config.ErrPipelineCycle) {
+			t.Errorf("GetByKey(%s): expected ErrPipelineCycle, got %v", key, err)
+		}
+	}
+	pipelines, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(pipelines) != 0 {
+		t.Errorf("expected the pipelines in the cycle to be left out, got %+v", pipelines)
+	}
+}
@@ -0,0 +1,147 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// timersCollection is the Firestore collection holding event-relative timers
+const timersCollection = "timers"
+
+// FirestoreTimersRepo stores event-relative timers in Firestore, one document per
+// timer.
+//
+// ListByLead needs a composite index on (businessId ASC, leadId ASC, fireAt ASC)
+// and ListDue one on (status ASC, fireAt ASC).
+type FirestoreTimersRepo struct {
+	client     *Client
+	collection string
+}
+
+// NewFirestoreTimersRepo creates a Firestore-backed timers repository
+func NewFirestoreTimersRepo(client *Client) ports.TimersRepo {
+	return &FirestoreTimersRepo{
+		client:     client,
+		collection: timersCollection,
+	}
+}
+
+// timerDocument is the Firestore representation of domain.Timer
+type timerDocument struct {
+	ID         string         `firestore:"id"`
+	BusinessID string         `firestore:"businessId"`
+	LeadID     string         `firestore:"leadId"`
+	Key        string         `firestore:"key"`
+	TriggerKey string         `firestore:"triggerKey"`
+	EventDate  string         `firestore:"eventDate"`
+	OffsetDays int            `firestore:"offsetDays"`
+	At         string         `firestore:"at"`
+	FireAt     time.Time      `firestore:"fireAt"`
+	Payload    map[string]any `firestore:"payload,omitempty"`
+	Status     string         `firestore:"status"`
+	JobID      string         `firestore:"jobId,omitempty"`
+	Error      string         `firestore:"error,omitempty"`
+	CreatedAt  time.Time      `firestore:"createdAt"`
+	UpdatedAt  time.Time      `firestore:"updatedAt"`
+}
+
+func (r *FirestoreTimersRepo) timers() *firestore.CollectionRef {
+	return r.client.GetClient().Collection(r.collection)
+}
+
+// Save inserts or replaces a timer
+func (r *FirestoreTimersRepo) Save(ctx context.Context, timer *domain.Timer) error {
+	// Firestore timestamps have microsecond precision
+	if timer.CreatedAt.IsZero() {
+		timer.CreatedAt = time.Now()
+	}
+	timer.CreatedAt = timer.CreatedAt.Truncate(time.Microsecond)
+	timer.UpdatedAt = time.Now().Truncate(time.Microsecond)
+
+	doc := timerDocument(*timer)
+	if _, err := r.timers().Doc(timer.ID).Set(ctx, &doc); err != nil {
+		return fmt.Errorf("failed to save timer %s: %w", timer.ID, err)
+	}
+	return nil
+}
+
+// GetByID retrieves a timer by ID
+func (r *FirestoreTimersRepo) GetByID(ctx context.Context, id string) (*domain.Timer, error) {
+	snap, err := r.timers().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %s", ports.ErrTimerNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get timer %s: %w", id, err)
+	}
+
+	var doc timerDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode timer %s: %w", id, err)
+	}
+	return doc.toTimer(), nil
+}
+
+// ListByLead returns every timer of a lead, soonest first
+func (r *FirestoreTimersRepo) ListByLead(ctx context.Context, businessID, leadID string) ([]*domain.Timer, error) {
+	q := r.timers().
+		Where("businessId", "==", businessID).
+		Where("leadId", "==", leadID).
+		OrderBy("fireAt", firestore.Asc).
+		OrderBy(firestore.DocumentID, firestore.Asc)
+	return r.query(ctx, q)
+}
+
+// ListDue returns up to limit scheduled timers whose FireAt is not after now, soonest first
+func (r *FirestoreTimersRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Timer, error) {
+	q := r.timers().
+		Where("status", "==", domain.TimerStatusScheduled).
+		Where("fireAt", "<=", now).
+		OrderBy("fireAt", firestore.Asc).
+		OrderBy(firestore.DocumentID, firestore.Asc)
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	return r.query(ctx, q)
+}
+
+func (r *FirestoreTimersRepo) query(ctx context.Context, q firestore.Query) ([]*domain.Timer, error) {
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	timers := []*domain.Timer{}
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list timers: %w", err)
+		}
+
+		var doc timerDocument
+		if err := snap.DataTo(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode timer %s: %w", snap.Ref.ID, err)
+		}
+		timers = append(timers, doc.toTimer())
+	}
+	return timers, nil
+}
+
+func (d *timerDocument) toTimer() *domain.Timer {
+	timer := domain.Timer(*d)
+	timer.FireAt = d.FireAt.UTC()
+	timer.CreatedAt = d.CreatedAt.UTC()
+	timer.UpdatedAt = d.UpdatedAt.UTC()
+	return &timer
+}
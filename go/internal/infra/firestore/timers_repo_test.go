@@ -0,0 +1,21 @@
+package firestore
+
+import (
+	"testing"
+
+	"github.com/bizops360/go-api/internal/ports"
+	"github.com/bizops360/go-api/internal/ports/portstest"
+)
+
+// TestFirestoreTimersRepo runs the TimersRepo conformance suite against the Firestore
+// emulator or, without one, the in-process fake; see newTestClient.
+func TestFirestoreTimersRepo(t *testing.T) {
+	client := newTestClient(t)
+	portstest.RunTimersRepoTests(t, func(t *testing.T) ports.TimersRepo {
+		// Each test gets its own collection so results don't mix
+		return &FirestoreTimersRepo{
+			client:     client,
+			collection: testCollection("timers"),
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// SlogLogger adapts a *slog.Logger to ports.Logger
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger wraps logger as a ports.Logger
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{logger: logger}
+}
+
+func (l *SlogLogger) Debug(msg string, fields ...ports.Field) {
+	l.log(slog.LevelDebug, msg, fields)
+}
+
+func (l *SlogLogger) Info(msg string, fields ...ports.Field) {
+	l.log(slog.LevelInfo, msg, fields)
+}
+
+func (l *SlogLogger) Warn(msg string, fields ...ports.Field) {
+	l.log(slog.LevelWarn, msg, fields)
+}
+
+func (l *SlogLogger) Error(msg string, fields ...ports.Field) {
+	l.log(slog.LevelError, msg, fields)
+}
+
+// With returns a logger that adds fields to every entry
+func (l *SlogLogger) With(fields ...ports.Field) ports.Logger {
+	return &SlogLogger{logger: l.logger.With(attrs(fields)...)}
+}
+
+func (l *SlogLogger) log(level slog.Level, msg string, fields []ports.Field) {
+	l.logger.Log(context.Background(), level, msg, attrs(fields)...)
+}
+
+func attrs(fields []ports.Field) []any {
+	args := make([]any, len(fields))
+	for i, f := range fields {
+		args[i] = slog.Any(f.Key, f.Value)
+	}
+	return args
+}
@@ -0,0 +1,137 @@
+package monday
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+const (
+	// DefaultAPIURL is the Monday.com GraphQL endpoint
+	DefaultAPIURL = "https://api.monday.com/v2"
+	// apiVersion pins the schema the queries are written against
+	apiVersion = "2024-10"
+)
+
+// rateLimitCodes are the error codes Monday.com answers with when a request
+// is over a rate or complexity limit; such requests succeed when retried
+var rateLimitCodes = []string{
+	"ComplexityException",
+	"COMPLEXITY_BUDGET_EXHAUSTED",
+	"RATE_LIMIT_EXCEEDED",
+	"IP_RATE_LIMIT_EXCEEDED",
+	"maxConcurrencyExceeded",
+}
+
+// Client sends GraphQL requests to the Monday.com API
+type Client struct {
+	apiURL string
+	token  string
+	client *http.Client
+}
+
+// NewClient creates a client for the API at apiURL authenticated with token
+func NewClient(apiURL, token string, client *http.Client) *Client {
+	return &Client{apiURL: apiURL, token: token, client: client}
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLError struct {
+	Message    string `json:"message"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
+}
+
+type graphQLResponse struct {
+	Data         json.RawMessage `json:"data"`
+	Errors       []graphQLError  `json:"errors"`
+	ErrorCode    string          `json:"error_code"`
+	ErrorMessage string          `json:"error_message"`
+}
+
+// Do runs query with variables and decodes its data into out. Rate limited
+// requests and server errors return retryable errors; any other failure
+// returns the same error on every attempt and is permanent.
+func (c *Client) Do(ctx context.Context, query string, variables map[string]any, out any) error {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return domain.Permanent(fmt.Errorf("failed to encode monday request: %w", err))
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return domain.Permanent(fmt.Errorf("invalid monday API URL: %w", err))
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", c.token)
+	httpReq.Header.Set("API-Version", apiVersion)
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to call monday: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read monday response: %w", err)
+	}
+
+	var result graphQLResponse
+	decodeErr := json.Unmarshal(respBody, &result)
+	if decodeErr == nil {
+		if err := result.err(); err != nil {
+			return err
+		}
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("monday returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	case resp.StatusCode != http.StatusOK:
+		return domain.Permanent(fmt.Errorf("monday returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody))))
+	case decodeErr != nil:
+		return fmt.Errorf("failed to decode monday response: %w", decodeErr)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("failed to decode monday response: %w", err)
+	}
+	return nil
+}
+
+// err returns the error a response reports, if any
+func (r *graphQLResponse) err() error {
+	var messages, codes []string
+	if r.ErrorMessage != "" || r.ErrorCode != "" {
+		messages = append(messages, r.ErrorMessage)
+		codes = append(codes, r.ErrorCode)
+	}
+	for _, e := range r.Errors {
+		messages = append(messages, e.Message)
+		codes = append(codes, e.Extensions.Code)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	err := fmt.Errorf("monday error: %s", strings.Join(messages, "; "))
+	for _, code := range codes {
+		if slices.Contains(rateLimitCodes, code) {
+			return fmt.Errorf("monday rate limited (%s): %s", code, strings.Join(messages, "; "))
+		}
+	}
+	return domain.Permanent(err)
+}
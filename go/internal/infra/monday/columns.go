@@ -0,0 +1,130 @@
+package monday
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+// itemNameColumn is the column holding an item's name; it may be written
+// without a mapping
+const itemNameColumn = "name"
+
+// columnValues maps deal fields to the column_values argument of a mutation,
+// a JSON object keyed by column ID, using the board's column mapping
+func columnValues(boardID int64, columns map[string]domain.MondayColumn, fields map[string]any) (string, error) {
+	values := make(map[string]any, len(fields))
+	for field, value := range fields {
+		column, ok := columns[field]
+		if !ok {
+			if field != itemNameColumn {
+				return "", domain.Permanent(fmt.Errorf("no column is mapped for field %s on board %d (see monday.columns)", field, boardID))
+			}
+			column = domain.MondayColumn{ID: itemNameColumn}
+		}
+		formatted, err := columnValue(column, value)
+		if err != nil {
+			return "", domain.Permanent(fmt.Errorf("field %s: %w", field, err))
+		}
+		values[column.ID] = formatted
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", domain.Permanent(fmt.Errorf("failed to encode column values: %w", err))
+	}
+	return string(data), nil
+}
+
+// columnValue formats value in the shape column's type expects. A nil value
+// clears the column.
+func columnValue(column domain.MondayColumn, value any) (any, error) {
+	if value == nil {
+		switch column.Type {
+		case "", domain.MondayColumnText, domain.MondayColumnNumbers:
+			return "", nil
+		default:
+			return map[string]any{}, nil
+		}
+	}
+
+	if column.Type == domain.MondayColumnDropdown {
+		if list, ok := value.([]any); ok {
+			labels := make([]string, 0, len(list))
+			for _, item := range list {
+				label, err := scalarText(item)
+				if err != nil {
+					return nil, err
+				}
+				labels = append(labels, label)
+			}
+			return map[string]any{"labels": labels}, nil
+		}
+	}
+
+	s, err := scalarText(value)
+	if err != nil {
+		return nil, err
+	}
+	switch column.Type {
+	case "", domain.MondayColumnText, domain.MondayColumnPhone:
+		return s, nil
+	case domain.MondayColumnLongText:
+		return map[string]any{"text": s}, nil
+	case domain.MondayColumnNumbers:
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("%q is not a number", s)
+		}
+		return s, nil
+	case domain.MondayColumnStatus:
+		return map[string]any{"label": s}, nil
+	case domain.MondayColumnDropdown:
+		return map[string]any{"labels": []string{s}}, nil
+	case domain.MondayColumnDate:
+		return dateValue(s)
+	case domain.MondayColumnEmail:
+		return map[string]any{"email": s, "text": s}, nil
+	case domain.MondayColumnLink:
+		return map[string]any{"url": s, "text": s}, nil
+	case domain.MondayColumnCheckbox:
+		checked, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false", s)
+		}
+		if !checked {
+			return map[string]any{}, nil
+		}
+		return map[string]any{"checked": "true"}, nil
+	default:
+		return nil, fmt.Errorf("unknown column type %s", column.Type)
+	}
+}
+
+// dateValue formats a YYYY-MM-DD date, or an RFC 3339 time in UTC
+func dateValue(s string) (any, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return map[string]any{"date": t.Format("2006-01-02")}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		t = t.UTC()
+		return map[string]any{"date": t.Format("2006-01-02"), "time": t.Format("15:04:05")}, nil
+	}
+	return nil, fmt.Errorf("%q is not a YYYY-MM-DD date", s)
+}
+
+// scalarText returns a string, number or boolean as text
+func scalarText(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int, int64, bool:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v", value)
+	}
+}
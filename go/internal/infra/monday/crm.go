@@ -0,0 +1,156 @@
+package monday
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+const createItemMutation = `mutation ($board: ID!, $name: String!, $values: JSON) {
+  create_item(board_id: $board, item_name: $name, column_values: $values) { id }
+}`
+
+const changeColumnValuesMutation = `mutation ($board: ID!, $item: ID!, $values: JSON!) {
+  change_multiple_column_values(board_id: $board, item_id: $item, column_values: $values) { id }
+}`
+
+const itemQuery = `query ($items: [ID!]) {
+  items(ids: $items) { id name board { id } column_values { id text } }
+}`
+
+// CRM keeps deals as items of Monday.com boards. Deal fields are written to
+// the columns the business maps them to per board.
+type CRM struct {
+	client *Client
+	// columns holds each board's column mapping by board ID
+	columns map[int64]map[string]domain.MondayColumn
+}
+
+// NewCRM creates a CRM over the boards and column mappings of config
+func NewCRM(client *Client, config domain.MondayConfig) *CRM {
+	columns := make(map[int64]map[string]domain.MondayColumn, len(config.Columns))
+	for board, mapping := range config.Columns {
+		if boardID, ok := config.Boards[board]; ok {
+			columns[boardID] = mapping
+		}
+	}
+	return &CRM{client: client, columns: columns}
+}
+
+// CreateDeal creates an item on req.BoardID
+func (c *CRM) CreateDeal(ctx context.Context, req *ports.CreateDealRequest) (*ports.CreateDealResult, error) {
+	values, err := columnValues(req.BoardID, c.columns[req.BoardID], req.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		CreateItem struct {
+			ID string `json:"id"`
+		} `json:"create_item"`
+	}
+	err = c.client.Do(ctx, createItemMutation, map[string]any{
+		"board":  strconv.FormatInt(req.BoardID, 10),
+		"name":   req.Name,
+		"values": values,
+	}, &data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create monday item on board %d: %w", req.BoardID, err)
+	}
+
+	itemID, err := strconv.ParseInt(data.CreateItem.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("monday returned invalid item id %q", data.CreateItem.ID)
+	}
+	return &ports.CreateDealResult{ItemID: itemID, Success: true}, nil
+}
+
+// UpdateDeal writes req.Fields to the columns of an item
+func (c *CRM) UpdateDeal(ctx context.Context, req *ports.UpdateDealRequest) (*ports.UpdateDealResult, error) {
+	values, err := columnValues(req.BoardID, c.columns[req.BoardID], req.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.client.Do(ctx, changeColumnValuesMutation, map[string]any{
+		"board":  strconv.FormatInt(req.BoardID, 10),
+		"item":   strconv.FormatInt(req.ItemID, 10),
+		"values": values,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update monday item %d: %w", req.ItemID, err)
+	}
+	return &ports.UpdateDealResult{Success: true}, nil
+}
+
+// GetDeal returns an item with its column text keyed by the mapped field
+// name, or by column ID for columns without a mapping. An item on another
+// board is not found.
+func (c *CRM) GetDeal(ctx context.Context, boardID int64, itemID int64) (*ports.Deal, error) {
+	var data struct {
+		Items []struct {
+			ID    string `json:"id"`
+			Name  string `json:"name"`
+			Board struct {
+				ID string `json:"id"`
+			} `json:"board"`
+			ColumnValues []struct {
+				ID   string `json:"id"`
+				Text string `json:"text"`
+			} `json:"column_values"`
+		} `json:"items"`
+	}
+	err := c.client.Do(ctx, itemQuery, map[string]any{"items": []string{strconv.FormatInt(itemID, 10)}}, &data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monday item %d: %w", itemID, err)
+	}
+	if len(data.Items) == 0 || data.Items[0].Board.ID != strconv.FormatInt(boardID, 10) {
+		return nil, fmt.Errorf("monday item %d on board %d: %w", itemID, boardID, fs.ErrNotExist)
+	}
+
+	fieldNames := make(map[string]string, len(c.columns[boardID]))
+	for field, column := range c.columns[boardID] {
+		fieldNames[column.ID] = field
+	}
+	item := data.Items[0]
+	fields := make(map[string]any, len(item.ColumnValues))
+	for _, value := range item.ColumnValues {
+		name, ok := fieldNames[value.ID]
+		if !ok {
+			name = value.ID
+		}
+		fields[name] = value.Text
+	}
+	return &ports.Deal{ItemID: itemID, BoardID: boardID, Name: item.Name, Fields: fields}, nil
+}
+
+// CRMs connects each business to Monday.com with its own API token
+type CRMs struct {
+	apiURL string
+	client *http.Client
+}
+
+// NewCRMs creates the per-business Monday.com CRMs
+func NewCRMs() *CRMs {
+	return &CRMs{apiURL: DefaultAPIURL, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// ForBusiness returns a CRM authenticated with the token held by the
+// environment variable the business monday config names
+func (c *CRMs) ForBusiness(business *domain.BusinessConfig) (ports.CRM, error) {
+	if business.Monday.APITokenEnv == "" {
+		return nil, fmt.Errorf("monday.apiTokenEnv is not set for business %s", business.ID)
+	}
+	token := os.Getenv(business.Monday.APITokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("monday token environment variable %s is not set", business.Monday.APITokenEnv)
+	}
+	return NewCRM(NewClient(c.apiURL, token, c.client), business.Monday), nil
+}
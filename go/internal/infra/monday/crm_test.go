@@ -0,0 +1,283 @@
+package monday
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// fakeItem is an item of the fake Monday.com API
+type fakeItem struct {
+	board  string
+	name   string
+	values map[string]any
+}
+
+// fakeMonday is a GraphQL server answering the queries the CRM sends from
+// its items. When fail is set, every request gets it as the response.
+type fakeMonday struct {
+	t      *testing.T
+	items  map[string]*fakeItem
+	nextID int
+	fail   func(w http.ResponseWriter)
+}
+
+func (f *fakeMonday) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "test-token" || r.Header.Get("API-Version") == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors":[{"message":"Not Authenticated"}]}`))
+		return
+	}
+	if f.fail != nil {
+		f.fail(w)
+		return
+	}
+
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		f.t.Errorf("failed to decode request: %v", err)
+	}
+	values := map[string]any{}
+	if raw, ok := req.Variables["values"].(string); ok {
+		if err := json.Unmarshal([]byte(raw), &values); err != nil {
+			f.t.Errorf("column_values is not a JSON object: %v", err)
+		}
+	}
+
+	var data any
+	switch {
+	case strings.Contains(req.Query, "create_item"):
+		f.nextID++
+		id := strconv.Itoa(f.nextID)
+		f.items[id] = &fakeItem{board: req.Variables["board"].(string), name: req.Variables["name"].(string), values: values}
+		data = map[string]any{"create_item": map[string]any{"id": id}}
+	case strings.Contains(req.Query, "change_multiple_column_values"):
+		item, ok := f.items[req.Variables["item"].(string)]
+		if !ok {
+			w.Write([]byte(`{"errors":[{"message":"Item not found in board","extensions":{"code":"InvalidItemIdException"}}]}`))
+			return
+		}
+		for k, v := range values {
+			item.values[k] = v
+		}
+		data = map[string]any{"change_multiple_column_values": map[string]any{"id": req.Variables["item"]}}
+	case strings.Contains(req.Query, "items(ids"):
+		items := []any{}
+		for _, id := range req.Variables["items"].([]any) {
+			item, ok := f.items[id.(string)]
+			if !ok {
+				continue
+			}
+			var columns []any
+			for column, value := range item.values {
+				text := value
+				if m, ok := value.(map[string]any); ok {
+					text = m["label"]
+				}
+				columns = append(columns, map[string]any{"id": column, "text": text})
+			}
+			items = append(items, map[string]any{"id": id, "name": item.name, "board": map[string]any{"id": item.board}, "column_values": columns})
+		}
+		data = map[string]any{"items": items}
+	default:
+		f.t.Errorf("unexpected query: %s", req.Query)
+	}
+	json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+var testMondayConfig = domain.MondayConfig{
+	Boards: map[string]int64{"deals": 234567890},
+	Columns: map[string]map[string]domain.MondayColumn{
+		"deals": {
+			"email":     {ID: "email", Type: domain.MondayColumnEmail},
+			"eventDate": {ID: "date4", Type: domain.MondayColumnDate},
+			"status":    {ID: "status", Type: domain.MondayColumnStatus},
+			"helpers":   {ID: "numbers", Type: domain.MondayColumnNumbers},
+			"notes":     {ID: "text1"},
+		},
+	},
+}
+
+func newTestCRM(t *testing.T) (*CRM, *fakeMonday) {
+	fake := &fakeMonday{t: t, items: map[string]*fakeItem{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return NewCRM(NewClient(server.URL, "test-token", server.Client()), testMondayConfig), fake
+}
+
+func TestCRM_Deals(t *testing.T) {
+	crm, fake := newTestCRM(t)
+	ctx := context.Background()
+
+	created, err := crm.CreateDeal(ctx, &ports.CreateDealRequest{
+		BoardID: 234567890,
+		Name:    "Jane - Birthday",
+		Fields:  map[string]any{"email": "jane@example.com", "eventDate": "2027-06-12", "status": "Quote Sent", "helpers": float64(3)},
+	})
+	if err != nil {
+		t.Fatalf("CreateDeal failed: %v", err)
+	}
+
+	item := fake.items[strconv.FormatInt(created.ItemID, 10)]
+	if item == nil || item.board != "234567890" || item.name != "Jane - Birthday" {
+		t.Fatalf("expected the item on the deals board, got %+v", item)
+	}
+	want := map[string]string{
+		"email":   `{"email":"jane@example.com","text":"jane@example.com"}`,
+		"date4":   `{"date":"2027-06-12"}`,
+		"status":  `{"label":"Quote Sent"}`,
+		"numbers": `"3"`,
+	}
+	for column, value := range want {
+		got, _ := json.Marshal(item.values[column])
+		if string(got) != value {
+			t.Errorf("expected column %s to be %s, got %s", column, value, got)
+		}
+	}
+
+	if _, err := crm.UpdateDeal(ctx, &ports.UpdateDealRequest{BoardID: 234567890, ItemID: created.ItemID, Fields: map[string]any{"status": "Deposit Paid"}}); err != nil {
+		t.Fatalf("UpdateDeal failed: %v", err)
+	}
+
+	deal, err := crm.GetDeal(ctx, 234567890, created.ItemID)
+	if err != nil {
+		t.Fatalf("GetDeal failed: %v", err)
+	}
+	if deal.Name != "Jane - Birthday" || deal.Fields["status"] != "Deposit Paid" {
+		t.Errorf("expected the updated deal with fields by name, got %+v", deal)
+	}
+
+	if _, err := crm.GetDeal(ctx, 111, created.ItemID); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected an item on another board to be not found, got %v", err)
+	}
+	if _, err := crm.GetDeal(ctx, 234567890, 999); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a missing item to be not found, got %v", err)
+	}
+}
+
+func TestCRM_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		fields        map[string]any
+		fail          func(w http.ResponseWriter)
+		wantErr       string
+		wantPermanent bool
+	}{
+		{name: "unmapped field", fields: map[string]any{"budget": "500"}, wantErr: "no column is mapped for field budget", wantPermanent: true},
+		{name: "invalid date", fields: map[string]any{"eventDate": "June 12"}, wantErr: "field eventDate", wantPermanent: true},
+		{name: "invalid number", fields: map[string]any{"helpers": "three"}, wantErr: "field helpers", wantPermanent: true},
+		{
+			name: "graphql error",
+			fail: func(w http.ResponseWriter) {
+				w.Write([]byte(`{"errors":[{"message":"Column not found","extensions":{"code":"InvalidColumnIdException"}}]}`))
+			},
+			wantErr:       "Column not found",
+			wantPermanent: true,
+		},
+		{
+			name: "complexity budget exhausted",
+			fail: func(w http.ResponseWriter) {
+				w.Write([]byte(`{"error_code":"ComplexityException","error_message":"Complexity budget exhausted"}`))
+			},
+			wantErr: "rate limited",
+		},
+		{
+			name: "too many requests",
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte("slow down"))
+			},
+			wantErr: "monday returned 429",
+		},
+		{
+			name: "server error",
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			wantErr: "monday returned 502",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crm, fake := newTestCRM(t)
+			fake.fail = tt.fail
+			fields := tt.fields
+			if fields == nil {
+				fields = map[string]any{"notes": "hello"}
+			}
+
+			_, err := crm.CreateDeal(context.Background(), &ports.CreateDealRequest{BoardID: 234567890, Name: "Jane", Fields: fields})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+			var permanent *domain.PermanentError
+			if errors.As(err, &permanent) != tt.wantPermanent {
+				t.Errorf("expected permanent %v, got %v", tt.wantPermanent, err)
+			}
+		})
+	}
+}
+
+func TestColumnValue(t *testing.T) {
+	tests := []struct {
+		column string
+		value  any
+		want   string
+	}{
+		{column: domain.MondayColumnText, value: "hello", want: `"hello"`},
+		{column: domain.MondayColumnLongText, value: "a long note", want: `{"text":"a long note"}`},
+		{column: domain.MondayColumnNumbers, value: 615.5, want: `"615.5"`},
+		{column: domain.MondayColumnDropdown, value: []any{"Bartender", "Server"}, want: `{"labels":["Bartender","Server"]}`},
+		{column: domain.MondayColumnDate, value: "2027-06-12T23:30:00-05:00", want: `{"date":"2027-06-13","time":"04:30:00"}`},
+		{column: domain.MondayColumnPhone, value: "+13145550100", want: `"+13145550100"`},
+		{column: domain.MondayColumnLink, value: "https://invoice.stripe.com/i/1", want: `{"text":"https://invoice.stripe.com/i/1","url":"https://invoice.stripe.com/i/1"}`},
+		{column: domain.MondayColumnCheckbox, value: true, want: `{"checked":"true"}`},
+		{column: domain.MondayColumnCheckbox, value: "false", want: `{}`},
+		{column: domain.MondayColumnStatus, value: nil, want: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+			value, err := columnValue(domain.MondayColumn{ID: "c", Type: tt.column}, tt.value)
+			if err != nil {
+				t.Fatalf("columnValue failed: %v", err)
+			}
+			if got, _ := json.Marshal(value); string(got) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCRMs_ForBusiness(t *testing.T) {
+	t.Setenv("MONDAY_API_TOKEN_TEST", "token")
+
+	tests := []struct {
+		name    string
+		monday  domain.MondayConfig
+		wantErr bool
+	}{
+		{name: "configured", monday: domain.MondayConfig{APITokenEnv: "MONDAY_API_TOKEN_TEST"}},
+		{name: "no env var named", wantErr: true},
+		{name: "env var unset", monday: domain.MondayConfig{APITokenEnv: "MONDAY_API_TOKEN_UNSET"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCRMs().ForBusiness(&domain.BusinessConfig{ID: "acme", Monday: tt.monday})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
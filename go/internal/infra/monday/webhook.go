@@ -0,0 +1,149 @@
+package monday
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrUnverifiedWebhook is returned for a webhook request that carries neither
+// a valid signature nor the webhook token
+var ErrUnverifiedWebhook = errors.New("monday webhook could not be verified")
+
+// WebhookRequest is the body of a Monday.com webhook request: a challenge
+// when the webhook is created, an event afterwards
+type WebhookRequest struct {
+	Challenge string        `json:"challenge"`
+	Event     *WebhookEvent `json:"event"`
+}
+
+// WebhookEvent is a change on a board, e.g. a column value update
+type WebhookEvent struct {
+	Type          string          `json:"type"`
+	UserID        int64           `json:"userId"`
+	BoardID       int64           `json:"boardId"`
+	ItemID        int64           `json:"pulseId"`
+	ItemName      string          `json:"pulseName"`
+	ColumnID      string          `json:"columnId"`
+	ColumnType    string          `json:"columnType"`
+	ColumnTitle   string          `json:"columnTitle"`
+	Value         json.RawMessage `json:"value"`
+	PreviousValue json.RawMessage `json:"previousValue"`
+	TriggerUUID   string          `json:"triggerUuid"`
+}
+
+// IsColumnChange reports whether the event is a column value update
+func (e *WebhookEvent) IsColumnChange() bool {
+	return e.ColumnID != "" && (e.Type == "update_column_value" || e.Type == "change_column_value" || e.Type == "change_status_column_value")
+}
+
+// Label returns the text of the new column value: the label of a status
+// column, or the text of other columns
+func (e *WebhookEvent) Label() string {
+	return valueText(e.Value)
+}
+
+// PreviousLabel returns the text of the previous column value
+func (e *WebhookEvent) PreviousLabel() string {
+	return valueText(e.PreviousValue)
+}
+
+func valueText(raw json.RawMessage) string {
+	var value struct {
+		Label *struct {
+			Text string `json:"text"`
+		} `json:"label"`
+		Text  string `json:"text"`
+		Value any    `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+		return ""
+	}
+	switch {
+	case value.Label != nil:
+		return value.Label.Text
+	case value.Text != "":
+		return value.Text
+	case value.Value != nil:
+		return fmt.Sprint(value.Value)
+	}
+	return ""
+}
+
+// VerifyWebhook checks that r comes from Monday.com. Webhooks of apps carry
+// a JWT signed with the app signing secret in the Authorization header;
+// board webhooks, which cannot be signed, carry the secret as the token query
+// parameter of the webhook URL.
+func VerifyWebhook(r *http.Request, secret string, now time.Time) error {
+	if secret == "" {
+		return ErrUnverifiedWebhook
+	}
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		if err := verifyJWT(strings.TrimPrefix(authorization, "Bearer "), secret, now); err != nil {
+			return fmt.Errorf("%w: %v", ErrUnverifiedWebhook, err)
+		}
+		return nil
+	}
+	if token := r.URL.Query().Get("token"); token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1 {
+		return nil
+	}
+	return ErrUnverifiedWebhook
+}
+
+// verifyJWT checks the HS256 signature and expiry of token
+func verifyJWT(token, secret string, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return fmt.Errorf("malformed token header: %w", err)
+	}
+	if header.Alg != "HS256" {
+		return fmt.Errorf("unsupported token algorithm %s", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("malformed token signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return errors.New("invalid token signature")
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return fmt.Errorf("malformed token claims: %w", err)
+	}
+	if claims.Exp != 0 && now.Unix() >= claims.Exp {
+		return errors.New("token expired")
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
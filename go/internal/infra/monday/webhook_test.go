@@ -0,0 +1,93 @@
+package monday
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// signJWT returns an HS256 token for claims signed with secret
+func signJWT(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	now := time.Date(2027, time.May, 1, 12, 0, 0, 0, time.UTC)
+	valid := signJWT(t, "signing-secret", map[string]any{"accountId": 1, "exp": now.Add(time.Minute).Unix()})
+
+	tests := []struct {
+		name          string
+		target        string
+		authorization string
+		secret        string
+		wantErr       bool
+	}{
+		{name: "signed", authorization: valid, secret: "signing-secret"},
+		{name: "signed with bearer prefix", authorization: "Bearer " + valid, secret: "signing-secret"},
+		{name: "wrong signing secret", authorization: valid, secret: "other-secret", wantErr: true},
+		{name: "expired", authorization: signJWT(t, "signing-secret", map[string]any{"exp": now.Add(-time.Minute).Unix()}), secret: "signing-secret", wantErr: true},
+		{name: "malformed token", authorization: "not-a-jwt", secret: "signing-secret", wantErr: true},
+		{name: "token in the URL", target: "/?token=signing-secret", secret: "signing-secret"},
+		{name: "wrong token in the URL", target: "/?token=guess", secret: "signing-secret", wantErr: true},
+		{name: "unsigned", secret: "signing-secret", wantErr: true},
+		{name: "no secret configured", target: "/?token=", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target
+			if target == "" {
+				target = "/"
+			}
+			r := httptest.NewRequest("POST", target, nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			err := VerifyWebhook(r, tt.secret, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrUnverifiedWebhook) {
+				t.Errorf("expected ErrUnverifiedWebhook, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookEvent_Label(t *testing.T) {
+	body := `{"event":{"type":"update_column_value","boardId":234567890,"pulseId":42,"pulseName":"Jane - Birthday",
+		"columnId":"status","columnType":"color","columnTitle":"Status",
+		"value":{"label":{"index":1,"text":"Send Renewal"},"post_id":null},
+		"previousValue":{"label":{"index":0,"text":"Booked"}},"triggerUuid":"abc"}}`
+
+	var req WebhookRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatal(err)
+	}
+	event := req.Event
+	if !event.IsColumnChange() || event.BoardID != 234567890 || event.ItemID != 42 {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+	if event.Label() != "Send Renewal" || event.PreviousLabel() != "Booked" {
+		t.Errorf("expected the status labels, got %q and %q", event.Label(), event.PreviousLabel())
+	}
+
+	text := WebhookEvent{Value: json.RawMessage(`{"value":"Renewal due"}`)}
+	if text.Label() != "Renewal due" {
+		t.Errorf("expected a text column's value, got %q", text.Label())
+	}
+}
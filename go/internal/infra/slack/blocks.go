@@ -0,0 +1,94 @@
+package slack
+
+import (
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// message is the body of an incoming webhook request
+type message struct {
+	Channel     string       `json:"channel,omitempty"`
+	Text        string       `json:"text"`
+	Blocks      []block      `json:"blocks,omitempty"`
+	Attachments []attachment `json:"attachments,omitempty"`
+}
+
+// attachment carries the blocks of a message with a color bar
+type attachment struct {
+	Color  string  `json:"color"`
+	Blocks []block `json:"blocks"`
+}
+
+type block struct {
+	Type   string  `json:"type"`
+	Text   *text   `json:"text,omitempty"`
+	Fields []*text `json:"fields,omitempty"`
+}
+
+type text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// renderMessage renders a notification as Block Kit: the title as a header,
+// the message as a section and the fields as two-column sections. Fields that
+// are not Short get a section of their own. With a color, the blocks are
+// wrapped in an attachment showing it. Text is the plain fallback shown in
+// notifications.
+func renderMessage(req *ports.NotificationRequest) message {
+	var blocks []block
+	if req.Title != "" {
+		blocks = append(blocks, block{Type: "header", Text: &text{Type: "plain_text", Text: truncate(req.Title, maxHeaderLength)}})
+	}
+	if req.Message != "" {
+		blocks = append(blocks, block{Type: "section", Text: mrkdwn(truncate(req.Message, maxSectionLength))})
+	}
+
+	var short []*text
+	flush := func() {
+		if len(short) > 0 {
+			blocks = append(blocks, block{Type: "section", Fields: short})
+			short = nil
+		}
+	}
+	for _, field := range req.Fields {
+		content := mrkdwn(truncate("*"+field.Title+"*\n"+field.Value, maxFieldLength))
+		if !field.Short {
+			flush()
+			blocks = append(blocks, block{Type: "section", Text: content})
+			continue
+		}
+		short = append(short, content)
+		if len(short) == maxSectionFields {
+			flush()
+		}
+	}
+	flush()
+
+	fallback := req.Message
+	if req.Title != "" && req.Message != "" {
+		fallback = req.Title + ": " + req.Message
+	} else if req.Title != "" {
+		fallback = req.Title
+	}
+
+	msg := message{Channel: req.Channel, Text: fallback}
+	if req.Color != "" {
+		msg.Attachments = []attachment{{Color: req.Color, Blocks: blocks}}
+	} else {
+		msg.Blocks = blocks
+	}
+	return msg
+}
+
+func mrkdwn(s string) *text {
+	return &text{Type: "mrkdwn", Text: s}
+}
+
+// truncate shortens s to at most max runes, marking the cut with an ellipsis
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,142 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// Rate limit handling: a webhook answering 429 is retried after its
+// Retry-After delay, up to maxAttempts in total, unless the delay is longer
+// than maxRetryWait
+const (
+	maxAttempts       = 3
+	maxRetryWait      = 30 * time.Second
+	defaultRetryAfter = time.Second
+)
+
+// Block Kit limits; longer text is truncated
+const (
+	maxHeaderLength  = 150
+	maxSectionLength = 3000
+	maxFieldLength   = 2000
+	maxSectionFields = 10
+)
+
+// Notifier posts notifications to a Slack incoming webhook as Block Kit
+// messages
+type Notifier struct {
+	webhookURL string
+	client     *http.Client
+	wait       func(ctx context.Context, d time.Duration) error
+}
+
+// NewNotifier creates a notifier for an incoming webhook URL
+func NewNotifier(webhookURL string, client *http.Client) *Notifier {
+	return &Notifier{webhookURL: webhookURL, client: client, wait: sleep}
+}
+
+// SendNotification posts req to the webhook. The channel only takes effect
+// for webhooks that may post to other channels; others post to the channel
+// they were created for.
+func (n *Notifier) SendNotification(ctx context.Context, req *ports.NotificationRequest) (*ports.NotificationResult, error) {
+	body, err := json.Marshal(renderMessage(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode slack message: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		retryAfter, err := n.post(ctx, body)
+		if err == nil {
+			return &ports.NotificationResult{Success: true}, nil
+		}
+		if retryAfter == 0 || attempt == maxAttempts || retryAfter > maxRetryWait {
+			return nil, err
+		}
+		if err := n.wait(ctx, retryAfter); err != nil {
+			return nil, fmt.Errorf("slack rate limited, retry aborted: %w", err)
+		}
+	}
+}
+
+// post sends one request. A rate-limited request returns the delay to retry
+// after with its error.
+func (n *Notifier) post(ctx context.Context, body []byte) (time.Duration, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, domain.Permanent(fmt.Errorf("invalid slack webhook: %w", err))
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post to slack: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return 0, nil
+	case resp.StatusCode == http.StatusTooManyRequests:
+		retryAfter := defaultRetryAfter
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return retryAfter, fmt.Errorf("slack returned 429, retry after %s", retryAfter)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		// invalid_payload, channel_not_found, no_service and the like fail
+		// the same way on every attempt
+		return 0, domain.Permanent(fmt.Errorf("slack returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody))))
+	default:
+		return 0, fmt.Errorf("slack returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Notifiers routes notifications to each business's webhook
+type Notifiers struct {
+	client *http.Client
+}
+
+// NewNotifiers creates the per-business Slack notifiers
+func NewNotifiers() *Notifiers {
+	return &Notifiers{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// ForBusiness returns a notifier for the webhook URL held by the environment
+// variable the business slack config names
+func (n *Notifiers) ForBusiness(business *domain.BusinessConfig) (ports.Notifier, error) {
+	if !business.Slack.Enabled {
+		return nil, fmt.Errorf("slack is not enabled for business %s", business.ID)
+	}
+	if business.Slack.WebhookEnv == "" {
+		return nil, fmt.Errorf("slack.webhookEnv is not set for business %s", business.ID)
+	}
+	webhookURL := os.Getenv(business.Slack.WebhookEnv)
+	if webhookURL == "" {
+		return nil, fmt.Errorf("slack webhook environment variable %s is not set", business.Slack.WebhookEnv)
+	}
+	return NewNotifier(webhookURL, n.client), nil
+}
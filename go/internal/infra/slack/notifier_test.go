@@ -0,0 +1,188 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// newTestNotifier returns a notifier for server that records the delays it
+// waits instead of sleeping
+func newTestNotifier(server *httptest.Server, waits *[]time.Duration) *Notifier {
+	notifier := NewNotifier(server.URL, server.Client())
+	notifier.wait = func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return ctx.Err()
+	}
+	return notifier
+}
+
+func TestNotifier_SendNotification(t *testing.T) {
+	var received message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected a JSON request, got %q", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	result, err := newTestNotifier(server, &waits).SendNotification(context.Background(), &ports.NotificationRequest{
+		Channel: "#payments",
+		Title:   "Deposit paid",
+		Message: "Jane paid the deposit",
+		Color:   "good",
+		Fields: []ports.NotificationField{
+			{Title: "Amount", Value: "$135.00", Short: true},
+			{Title: "Invoice", Value: "in_123", Short: true},
+			{Title: "Notes", Value: "Birthday party"},
+		},
+	})
+	if err != nil || !result.Success {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if received.Channel != "#payments" || received.Text != "Deposit paid: Jane paid the deposit" {
+		t.Errorf("unexpected channel or fallback text: %+v", received)
+	}
+	if len(received.Attachments) != 1 || received.Attachments[0].Color != "good" {
+		t.Fatalf("expected one colored attachment, got %+v", received.Attachments)
+	}
+	blocks := received.Attachments[0].Blocks
+	var types []string
+	for _, b := range blocks {
+		types = append(types, b.Type)
+	}
+	if strings.Join(types, ",") != "header,section,section,section" {
+		t.Fatalf("unexpected blocks: %v", types)
+	}
+	if len(blocks[2].Fields) != 2 || blocks[2].Fields[0].Text != "*Amount*\n$135.00" {
+		t.Errorf("expected the short fields side by side, got %+v", blocks[2])
+	}
+	if blocks[3].Text == nil || blocks[3].Text.Text != "*Notes*\nBirthday party" {
+		t.Errorf("expected the long field in its own section, got %+v", blocks[3])
+	}
+}
+
+func TestNotifier_RateLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		limited    int32
+		retryAfter string
+		wantCalls  int32
+		wantWaits  []time.Duration
+		wantErr    bool
+	}{
+		{name: "retries after the delay", limited: 1, retryAfter: "2", wantCalls: 2, wantWaits: []time.Duration{2 * time.Second}},
+		{name: "default delay", limited: 2, wantCalls: 3, wantWaits: []time.Duration{time.Second, time.Second}},
+		{name: "gives up after the last attempt", limited: 5, retryAfter: "1", wantCalls: 3, wantWaits: []time.Duration{time.Second, time.Second}, wantErr: true},
+		{name: "delay too long", limited: 1, retryAfter: "120", wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= tt.limited {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.Write([]byte("ok"))
+			}))
+			defer server.Close()
+
+			var waits []time.Duration
+			_, err := newTestNotifier(server, &waits).SendNotification(context.Background(), &ports.NotificationRequest{Message: "hello"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !strings.Contains(err.Error(), "429") {
+				t.Errorf("expected the error to name the 429, got %v", err)
+			}
+			if calls.Load() != tt.wantCalls || len(waits) != len(tt.wantWaits) {
+				t.Fatalf("expected %d call(s) and waits %v, got %d and %v", tt.wantCalls, tt.wantWaits, calls.Load(), waits)
+			}
+			for i := range waits {
+				if waits[i] != tt.wantWaits[i] {
+					t.Errorf("expected waits %v, got %v", tt.wantWaits, waits)
+				}
+			}
+		})
+	}
+}
+
+func TestNotifier_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		wantPermanent bool
+	}{
+		{name: "invalid payload", status: http.StatusBadRequest, body: "invalid_payload", wantPermanent: true},
+		{name: "webhook revoked", status: http.StatusNotFound, body: "no_service", wantPermanent: true},
+		{name: "server error", status: http.StatusInternalServerError, body: "oops"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			var waits []time.Duration
+			_, err := newTestNotifier(server, &waits).SendNotification(context.Background(), &ports.NotificationRequest{Message: "hello"})
+			if err == nil || !strings.Contains(err.Error(), tt.body) {
+				t.Fatalf("expected an error naming %q, got %v", tt.body, err)
+			}
+			var permanent *domain.PermanentError
+			if errors.As(err, &permanent) != tt.wantPermanent {
+				t.Errorf("expected permanent %v, got %v", tt.wantPermanent, err)
+			}
+		})
+	}
+}
+
+func TestNotifiers_ForBusiness(t *testing.T) {
+	t.Setenv("SLACK_WEBHOOK_TEST", "https://hooks.slack.com/services/T/B/X")
+
+	tests := []struct {
+		name    string
+		slack   domain.SlackConfig
+		wantErr bool
+	}{
+		{name: "configured", slack: domain.SlackConfig{Enabled: true, WebhookEnv: "SLACK_WEBHOOK_TEST"}},
+		{name: "disabled", slack: domain.SlackConfig{WebhookEnv: "SLACK_WEBHOOK_TEST"}, wantErr: true},
+		{name: "no env var named", slack: domain.SlackConfig{Enabled: true}, wantErr: true},
+		{name: "env var unset", slack: domain.SlackConfig{Enabled: true, WebhookEnv: "SLACK_WEBHOOK_UNSET"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier, err := NewNotifiers().ForBusiness(&domain.BusinessConfig{ID: "acme", Slack: tt.slack})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && notifier.(*Notifier).webhookURL != "https://hooks.slack.com/services/T/B/X" {
+				t.Errorf("expected the webhook from the environment, got %+v", notifier)
+			}
+		})
+	}
+}
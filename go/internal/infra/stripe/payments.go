@@ -297,6 +297,30 @@ func (s *StripePayments) SendInvoice(ctx context.Context, invoiceID string, useT
 	return nil
 }
 
+// VoidInvoice voids a finalized invoice so the customer can no longer pay it
+func (s *StripePayments) VoidInvoice(ctx context.Context, invoiceID string, useTest bool) error {
+	apiKey, err := s.getAPIKey("", useTest)
+	if err != nil {
+		return err
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", "https://api.stripe.com/v1/invoices/"+invoiceID+"/void", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("stripe API error: %s", string(body))
+	}
+
+	return nil
+}
+
 // getOrCreateCustomer gets or creates a Stripe customer
 // If customer exists, updates the name if it's different
 func (s *StripePayments) getOrCreateCustomer(ctx context.Context, apiKey, email, name string) (string, error) {
@@ -2,12 +2,21 @@ package ports
 
 import (
 	"context"
+	"fmt"
+	"io/fs"
+
 	"github.com/bizops360/go-api/internal/domain"
 )
 
+// ErrBusinessNotFound is returned when a business does not exist. It wraps
+// fs.ErrNotExist, so callers written against the YAML files still match it.
+var ErrBusinessNotFound = fmt.Errorf("business not found: %w", fs.ErrNotExist)
+
 // BusinessesRepo defines the interface for business configuration storage
 type BusinessesRepo interface {
 	GetByID(ctx context.Context, id string) (*domain.BusinessConfig, error)
+	// GetAll returns every business, sorted by ID
 	GetAll(ctx context.Context) ([]*domain.BusinessConfig, error)
+	// Save inserts or replaces a business configuration
+	Save(ctx context.Context, business *domain.BusinessConfig) error
 }
-
@@ -1,14 +1,23 @@
 package ports
 
-import "context"
+import (
+	"context"
 
-// CRM defines the interface for CRM operations (Monday.com)
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+// CRM defines the interface for CRM operations (Monday.com or the built-in CRM)
 type CRM interface {
 	CreateDeal(ctx context.Context, req *CreateDealRequest) (*CreateDealResult, error)
 	UpdateDeal(ctx context.Context, req *UpdateDealRequest) (*UpdateDealResult, error)
 	GetDeal(ctx context.Context, boardID int64, itemID int64) (*Deal, error)
 }
 
+// BusinessCRMs returns the CRM configured for a business
+type BusinessCRMs interface {
+	ForBusiness(business *domain.BusinessConfig) (CRM, error)
+}
+
 // CreateDealRequest contains data to create a deal
 type CreateDealRequest struct {
 	BoardID int64
@@ -0,0 +1,67 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+// ErrCRMDealNotFound is returned when a deal of the built-in CRM does not
+// exist. It wraps fs.ErrNotExist, as CRM.GetDeal errors for missing deals do.
+var ErrCRMDealNotFound = fmt.Errorf("deal not found: %w", fs.ErrNotExist)
+
+// ErrCRMContactNotFound is returned when a contact of the built-in CRM does
+// not exist
+var ErrCRMContactNotFound = fmt.Errorf("contact not found: %w", fs.ErrNotExist)
+
+// CRMStore defines the interface for the deals, contacts and boards of the
+// built-in CRM
+type CRMStore interface {
+	// SaveBoard inserts or replaces a board
+	SaveBoard(ctx context.Context, board *domain.CRMBoard) error
+	// ListBoards returns the boards of a business, by ID
+	ListBoards(ctx context.Context, businessID string) ([]*domain.CRMBoard, error)
+	// CreateDeal inserts a deal and sets its ID, unique across businesses
+	CreateDeal(ctx context.Context, deal *domain.CRMDeal) error
+	// SaveDeal replaces an existing deal
+	SaveDeal(ctx context.Context, deal *domain.CRMDeal) error
+	GetDeal(ctx context.Context, businessID string, id int64) (*domain.CRMDeal, error)
+	// ListDeals returns the deals matching the query, most recently updated
+	// first
+	ListDeals(ctx context.Context, query DealQuery) ([]*domain.CRMDeal, error)
+	// SaveContact inserts or replaces a contact
+	SaveContact(ctx context.Context, contact *domain.CRMContact) error
+	GetContact(ctx context.Context, businessID, id string) (*domain.CRMContact, error)
+}
+
+// DealQuery filters the deals of a business
+type DealQuery struct {
+	BusinessID string
+	BoardID    int64 // optional
+	// Fields keeps deals whose string fields have exactly these values,
+	// e.g. {"status": "Booked"}
+	Fields map[string]string
+	// Search keeps deals whose name or field values contain it,
+	// case-insensitively
+	Search string
+	Limit  int // optional
+}
+
+// Matches reports whether a deal satisfies the query filters
+func (q DealQuery) Matches(deal *domain.CRMDeal) bool {
+	if deal.BusinessID != q.BusinessID {
+		return false
+	}
+	if q.BoardID != 0 && deal.BoardID != q.BoardID {
+		return false
+	}
+	for field, want := range q.Fields {
+		if got, ok := deal.Fields[field].(string); !ok || got != want {
+			return false
+		}
+	}
+	return q.Search == "" || strings.Contains(deal.SearchText(), strings.ToLower(q.Search))
+}
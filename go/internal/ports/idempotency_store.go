@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrIdempotencyKeyInFlight is returned by Begin while another request holding
+// the same key has not completed
+var ErrIdempotencyKeyInFlight = errors.New("a request with this idempotency key is still in progress")
+
+// IdempotentResponse is the stored response of a completed request
+type IdempotentResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// IdempotencyStore remembers the responses of requests by idempotency key so
+// that retried requests are answered without running again
+type IdempotencyStore interface {
+	// Begin claims key for a new request. It returns the stored response when
+	// a request with the key has completed, nil when the caller now holds the
+	// key, or ErrIdempotencyKeyInFlight. A claim that is never completed or
+	// released lapses after lease.
+	Begin(ctx context.Context, key string, lease time.Duration) (*IdempotentResponse, error)
+	// Complete stores the response of the request holding key for ttl
+	Complete(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error
+	// Release gives up a claimed key without storing a response, so the
+	// request can be retried
+	Release(ctx context.Context, key string) error
+}
@@ -2,13 +2,117 @@ package ports
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/bizops360/go-api/internal/domain"
 )
 
+// ErrJobNotFound is returned when a job does not exist
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// DefaultJobQueryLimit is the page size used when JobQuery.Limit is not set
+const DefaultJobQueryLimit = 50
+
 // JobsRepo defines the interface for job storage
 type JobsRepo interface {
 	Save(ctx context.Context, job *domain.Job) error
 	GetByID(ctx context.Context, id string) (*domain.Job, error)
 	GetByBusinessID(ctx context.Context, businessID string, limit int) ([]*domain.Job, error)
+	// List returns jobs matching the query, newest first
+	List(ctx context.Context, query JobQuery) (*JobPage, error)
+	// CompareAndSetStatus changes a job's status to to only if it is currently
+	// from, as one atomic step. It reports false when the status did not match,
+	// so only one caller can claim a job.
+	CompareAndSetStatus(ctx context.Context, id, from, to string) (bool, error)
+}
+
+// JobQuery filters and paginates job history
+type JobQuery struct {
+	BusinessID    string
+	Status        string    // optional
+	PipelineKey   string    // optional
+	CreatedAfter  time.Time // optional, inclusive
+	CreatedBefore time.Time // optional, exclusive
+	Limit         int       // page size, DefaultJobQueryLimit when <= 0
+	Cursor        string    // optional, NextCursor from the previous page
+}
+
+// JobPage is one page of job history
+type JobPage struct {
+	Jobs       []*domain.Job `json:"jobs"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+// PageLimit returns the effective page size
+func (q JobQuery) PageLimit() int {
+	if q.Limit <= 0 {
+		return DefaultJobQueryLimit
+	}
+	return q.Limit
 }
 
+// Matches reports whether a job satisfies the query filters (not the cursor)
+func (q JobQuery) Matches(job *domain.Job) bool {
+	if q.BusinessID != "" && job.BusinessID != q.BusinessID {
+		return false
+	}
+	if q.Status != "" && job.Status != q.Status {
+		return false
+	}
+	if q.PipelineKey != "" && job.PipelineKey != q.PipelineKey {
+		return false
+	}
+	if !q.CreatedAfter.IsZero() && job.CreatedAt.Before(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !job.CreatedAt.Before(q.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// JobCursor is the position after which the next page starts.
+// Jobs are ordered by CreatedAt descending, then ID descending.
+type JobCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// EncodeJobCursor encodes the position of the last job on a page
+func EncodeJobCursor(job *domain.Job) string {
+	raw := strconv.FormatInt(job.CreatedAt.UnixNano(), 10) + "|" + job.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeJobCursor decodes a cursor produced by EncodeJobCursor
+func DecodeJobCursor(cursor string) (*JobCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	nanos, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return &JobCursor{CreatedAt: time.Unix(0, n).UTC(), ID: id}, nil
+}
+
+// After reports whether a job comes after the cursor in listing order
+func (c *JobCursor) After(job *domain.Job) bool {
+	if job.CreatedAt.Equal(c.CreatedAt) {
+		return job.ID < c.ID
+	}
+	return job.CreatedAt.Before(c.CreatedAt)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Locker hands out named, expiring locks shared by every instance of the API.
+// Background work such as the scheduler uses it so that only one instance acts
+// on a given event.
+type Locker interface {
+	// TryLock acquires the lock named key for ttl. It returns false, without an
+	// error, when another holder has the lock and it has not expired yet.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Unlock releases the lock named key before it expires, so that the work it
+	// guards can be retried. Releasing a lock that is not held is not an error.
+	Unlock(ctx context.Context, key string) error
+}
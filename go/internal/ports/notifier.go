@@ -1,12 +1,21 @@
 package ports
 
-import "context"
+import (
+	"context"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
 
 // Notifier defines the interface for notifications (Slack)
 type Notifier interface {
 	SendNotification(ctx context.Context, req *NotificationRequest) (*NotificationResult, error)
 }
 
+// BusinessNotifiers returns the notifier configured for a business
+type BusinessNotifiers interface {
+	ForBusiness(business *domain.BusinessConfig) (Notifier, error)
+}
+
 // NotificationRequest contains notification data
 type NotificationRequest struct {
 	Channel   string
@@ -18,9 +27,9 @@ type NotificationRequest struct {
 
 // NotificationField represents a field in a notification
 type NotificationField struct {
-	Title string
-	Value string
-	Short bool
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"` // shown side by side with other short fields
 }
 
 // NotificationResult contains the result of sending a notification
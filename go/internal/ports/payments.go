@@ -12,6 +12,7 @@ type PaymentsProvider interface {
 	CalculateDeposit(ctx context.Context, estimateTotalCents int64) (*domain.Deposit, error)
 	GetInvoice(ctx context.Context, invoiceID string, useTest bool) (*InvoiceResult, error)
 	SendInvoice(ctx context.Context, invoiceID string, useTest bool) error
+	VoidInvoice(ctx context.Context, invoiceID string, useTest bool) error
 }
 
 // CreateInvoiceRequest contains data needed to create an invoice
@@ -2,12 +2,21 @@ package ports
 
 import (
 	"context"
+	"fmt"
+	"io/fs"
+
 	"github.com/bizops360/go-api/internal/domain"
 )
 
+// ErrPipelineNotFound is returned when a pipeline does not exist. It wraps
+// fs.ErrNotExist, like ErrBusinessNotFound.
+var ErrPipelineNotFound = fmt.Errorf("pipeline not found: %w", fs.ErrNotExist)
+
 // PipelinesRepo defines the interface for pipeline definition storage
 type PipelinesRepo interface {
 	GetByKey(ctx context.Context, key string) (*domain.PipelineDefinition, error)
+	// GetAll returns every pipeline, sorted by key
 	GetAll(ctx context.Context) ([]*domain.PipelineDefinition, error)
+	// Save inserts or replaces a pipeline definition
+	Save(ctx context.Context, pipeline *domain.PipelineDefinition) error
 }
-
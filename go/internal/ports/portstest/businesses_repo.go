@@ -0,0 +1,104 @@
+package portstest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// RunBusinessesRepoTests runs the BusinessesRepo conformance suite. newRepo
+// must return an empty repository for each call.
+func RunBusinessesRepoTests(t *testing.T, newRepo func(t *testing.T) ports.BusinessesRepo) {
+	t.Run("SaveAndGetByID", func(t *testing.T) {
+		testBusinessesRepoSaveAndGet(t, newRepo(t))
+	})
+	t.Run("GetByIDNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.GetByID(context.Background(), "missing"); !errors.Is(err, ports.ErrBusinessNotFound) {
+			t.Fatalf("expected ErrBusinessNotFound, got %v", err)
+		}
+	})
+	t.Run("GetAll", func(t *testing.T) {
+		testBusinessesRepoGetAll(t, newRepo(t))
+	})
+}
+
+func newBusiness(id string) *domain.BusinessConfig {
+	return &domain.BusinessConfig{
+		ID:          id,
+		DisplayName: "STL Party Helpers",
+		Timezone:    "America/Chicago",
+		Currency:    "USD",
+		Monday:      domain.MondayConfig{APITokenEnv: "MONDAY_API_TOKEN", Boards: map[string]int64{"leads": 1234567890}},
+		Slack:       domain.SlackConfig{Enabled: true, WebhookEnv: "SLACK_WEBHOOK_URL"},
+		Templates: domain.TemplateConfig{
+			Email: map[string]string{"quote": "quote_email.html"},
+			EmailTemplateSettings: domain.EmailTemplateSettings{
+				DefaultTemplate:    "apple_style",
+				AvailableTemplates: []string{"original", "apple_style"},
+			},
+		},
+		Pipelines: domain.BusinessPipelineConfig{
+			EventTimers: []domain.EventTimerConfig{{Key: "deposit_reminder", Trigger: "resend_deposit_link", OffsetDays: -3, At: "10:00"}},
+		},
+		Input: domain.InputSchema{
+			Fields: []domain.InputField{{Name: "numHelpers", Aliases: []string{"helpers_requested"}, Type: domain.InputTypeInteger, Required: true}},
+		},
+	}
+}
+
+func testBusinessesRepoSaveAndGet(t *testing.T, repo ports.BusinessesRepo) {
+	ctx := context.Background()
+	business := newBusiness("stlpartyhelpers")
+	if err := repo.Save(ctx, business); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, "stlpartyhelpers")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, business) {
+		t.Errorf("business did not round-trip:\n got %+v\nwant %+v", got, business)
+	}
+
+	// Save replaces the business
+	updated := newBusiness("stlpartyhelpers")
+	updated.Templates.EmailTemplateSettings.DefaultTemplate = "original"
+	updated.Slack.Enabled = false
+	if err := repo.Save(ctx, updated); err != nil {
+		t.Fatalf("Save (update) failed: %v", err)
+	}
+	got, err = repo.GetByID(ctx, "stlpartyhelpers")
+	if err != nil {
+		t.Fatalf("GetByID after update failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, updated) {
+		t.Errorf("update was not saved:\n got %+v\nwant %+v", got, updated)
+	}
+}
+
+func testBusinessesRepoGetAll(t *testing.T, repo ports.BusinessesRepo) {
+	ctx := context.Background()
+	for _, id := range []string{"zenith", "acme"} {
+		if err := repo.Save(ctx, newBusiness(id)); err != nil {
+			t.Fatalf("Save(%s) failed: %v", id, err)
+		}
+	}
+
+	businesses, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	var ids []string
+	for _, business := range businesses {
+		ids = append(ids, business.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"acme", "zenith"}) {
+		t.Errorf("expected businesses acme and zenith in order, got %v", ids)
+	}
+}
@@ -0,0 +1,213 @@
+package portstest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// RunCRMStoreTests runs the CRMStore conformance suite. newStore must return
+// an empty store for each call.
+func RunCRMStoreTests(t *testing.T, newStore func(t *testing.T) ports.CRMStore) {
+	t.Run("Boards", func(t *testing.T) {
+		testCRMStoreBoards(t, newStore(t))
+	})
+	t.Run("CreateAndGetDeal", func(t *testing.T) {
+		testCRMStoreCreateAndGetDeal(t, newStore(t))
+	})
+	t.Run("GetDealNotFound", func(t *testing.T) {
+		testCRMStoreDealNotFound(t, newStore(t))
+	})
+	t.Run("ListDeals", func(t *testing.T) {
+		testCRMStoreListDeals(t, newStore(t))
+	})
+	t.Run("Contacts", func(t *testing.T) {
+		testCRMStoreContacts(t, newStore(t))
+	})
+}
+
+func newDeal(businessID string, boardID int64, name, status string) *domain.CRMDeal {
+	return &domain.CRMDeal{
+		BusinessID: businessID,
+		BoardID:    boardID,
+		Name:       name,
+		Fields:     map[string]any{"status": status, "totalCost": 450.5},
+	}
+}
+
+func mustCreateDeal(t *testing.T, store ports.CRMStore, deal *domain.CRMDeal) *domain.CRMDeal {
+	t.Helper()
+	if err := store.CreateDeal(context.Background(), deal); err != nil {
+		t.Fatalf("CreateDeal(%s) failed: %v", deal.Name, err)
+	}
+	return deal
+}
+
+func dealNames(deals []*domain.CRMDeal) []string {
+	names := []string{}
+	for _, deal := range deals {
+		names = append(names, deal.Name)
+	}
+	return names
+}
+
+func testCRMStoreBoards(t *testing.T, store ports.CRMStore) {
+	ctx := context.Background()
+	statuses := []domain.CRMStatusColumn{{Field: "status", Labels: []string{"Lead", "Booked"}, Default: "Lead"}}
+	for _, board := range []*domain.CRMBoard{
+		{BusinessID: "stlpartyhelpers", ID: 2, Name: "tasks"},
+		{BusinessID: "stlpartyhelpers", ID: 1, Name: "leads", StatusColumns: statuses},
+		{BusinessID: "otherbusiness", ID: 1, Name: "deals"},
+	} {
+		if err := store.SaveBoard(ctx, board); err != nil {
+			t.Fatalf("SaveBoard(%s) failed: %v", board.Name, err)
+		}
+	}
+	// Saving again replaces the board
+	if err := store.SaveBoard(ctx, &domain.CRMBoard{BusinessID: "stlpartyhelpers", ID: 1, Name: "deals", StatusColumns: statuses}); err != nil {
+		t.Fatalf("SaveBoard failed: %v", err)
+	}
+
+	boards, err := store.ListBoards(ctx, "stlpartyhelpers")
+	if err != nil {
+		t.Fatalf("ListBoards failed: %v", err)
+	}
+	if len(boards) != 2 || boards[0].Name != "deals" || boards[1].Name != "tasks" {
+		t.Fatalf("expected the deals and tasks boards, got %+v", boards)
+	}
+	if !reflect.DeepEqual(boards[0].StatusColumns, statuses) {
+		t.Errorf("expected status columns %+v, got %+v", statuses, boards[0].StatusColumns)
+	}
+	if boards[0].UpdatedAt.IsZero() {
+		t.Errorf("expected updatedAt to be set, got %+v", boards[0])
+	}
+}
+
+func testCRMStoreCreateAndGetDeal(t *testing.T, store ports.CRMStore) {
+	ctx := context.Background()
+	first := mustCreateDeal(t, store, newDeal("stlpartyhelpers", 1, "Jane - Birthday", "Lead"))
+	second := mustCreateDeal(t, store, newDeal("stlpartyhelpers", 1, "John - Wedding", "Lead"))
+	if first.ID <= 0 || second.ID <= 0 || first.ID == second.ID {
+		t.Fatalf("expected distinct IDs, got %d and %d", first.ID, second.ID)
+	}
+
+	first.ContactID = "ct_123"
+	got, err := store.GetDeal(ctx, "stlpartyhelpers", first.ID)
+	if err != nil {
+		t.Fatalf("GetDeal failed: %v", err)
+	}
+	if got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+		t.Errorf("expected timestamps to be set, got %+v", got)
+	}
+	if got.Name != first.Name || got.BoardID != 1 || !reflect.DeepEqual(got.Fields, first.Fields) {
+		t.Errorf("expected %+v, got %+v", first, got)
+	}
+
+	// Saving replaces the deal
+	first.Name = "Jane - 30th Birthday"
+	first.Fields = map[string]any{"status": "Booked"}
+	if err := store.SaveDeal(ctx, first); err != nil {
+		t.Fatalf("SaveDeal failed: %v", err)
+	}
+	got, err = store.GetDeal(ctx, "stlpartyhelpers", first.ID)
+	if err != nil {
+		t.Fatalf("GetDeal failed: %v", err)
+	}
+	if got.Name != first.Name || got.ContactID != "ct_123" || !reflect.DeepEqual(got.Fields, first.Fields) {
+		t.Errorf("expected the saved deal to be replaced, got %+v", got)
+	}
+	if !got.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("expected createdAt %v to be kept, got %v", first.CreatedAt, got.CreatedAt)
+	}
+}
+
+func testCRMStoreDealNotFound(t *testing.T, store ports.CRMStore) {
+	ctx := context.Background()
+	deal := mustCreateDeal(t, store, newDeal("stlpartyhelpers", 1, "Jane - Birthday", "Lead"))
+
+	if _, err := store.GetDeal(ctx, "stlpartyhelpers", deal.ID+1000); !errors.Is(err, ports.ErrCRMDealNotFound) {
+		t.Errorf("expected ErrCRMDealNotFound, got %v", err)
+	}
+	// Deals of another business are not found
+	if _, err := store.GetDeal(ctx, "otherbusiness", deal.ID); !errors.Is(err, ports.ErrCRMDealNotFound) {
+		t.Errorf("expected ErrCRMDealNotFound for another business, got %v", err)
+	}
+}
+
+func testCRMStoreListDeals(t *testing.T, store ports.CRMStore) {
+	ctx := context.Background()
+	jane := mustCreateDeal(t, store, newDeal("stlpartyhelpers", 1, "Jane - Birthday", "Booked"))
+	mustCreateDeal(t, store, newDeal("stlpartyhelpers", 1, "John - Wedding", "Lead"))
+	mustCreateDeal(t, store, newDeal("stlpartyhelpers", 2, "Order napkins", "Lead"))
+	mustCreateDeal(t, store, newDeal("otherbusiness", 1, "Jane - Retirement", "Booked"))
+
+	// Updating a deal moves it to the front
+	jane.Fields["email"] = "Jane@Example.com"
+	if err := store.SaveDeal(ctx, jane); err != nil {
+		t.Fatalf("SaveDeal failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query ports.DealQuery
+		want  []string
+	}{
+		{name: "business", query: ports.DealQuery{BusinessID: "stlpartyhelpers"}, want: []string{"Jane - Birthday", "Order napkins", "John - Wedding"}},
+		{name: "board", query: ports.DealQuery{BusinessID: "stlpartyhelpers", BoardID: 1}, want: []string{"Jane - Birthday", "John - Wedding"}},
+		{name: "status", query: ports.DealQuery{BusinessID: "stlpartyhelpers", Fields: map[string]string{"status": "Lead"}}, want: []string{"Order napkins", "John - Wedding"}},
+		{name: "search name", query: ports.DealQuery{BusinessID: "stlpartyhelpers", Search: "wedding"}, want: []string{"John - Wedding"}},
+		{name: "search fields", query: ports.DealQuery{BusinessID: "stlpartyhelpers", Search: "jane@example"}, want: []string{"Jane - Birthday"}},
+		{name: "limit", query: ports.DealQuery{BusinessID: "stlpartyhelpers", BoardID: 1, Limit: 1}, want: []string{"Jane - Birthday"}},
+		{name: "search with limit", query: ports.DealQuery{BusinessID: "stlpartyhelpers", Search: "lead", Limit: 1}, want: []string{"Order napkins"}},
+		{name: "no match", query: ports.DealQuery{BusinessID: "stlpartyhelpers", Fields: map[string]string{"status": "Lost"}}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deals, err := store.ListDeals(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("ListDeals failed: %v", err)
+			}
+			if got := dealNames(deals); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func testCRMStoreContacts(t *testing.T, store ports.CRMStore) {
+	ctx := context.Background()
+	contact := &domain.CRMContact{
+		ID:         domain.CRMContactID("stlpartyhelpers", "jane@example.com", ""),
+		BusinessID: "stlpartyhelpers",
+		Name:       "Jane",
+		Email:      "jane@example.com",
+	}
+	if err := store.SaveContact(ctx, contact); err != nil {
+		t.Fatalf("SaveContact failed: %v", err)
+	}
+
+	// Saving again replaces the contact
+	contact.Phone = "314-555-0100"
+	if err := store.SaveContact(ctx, contact); err != nil {
+		t.Fatalf("SaveContact failed: %v", err)
+	}
+	got, err := store.GetContact(ctx, "stlpartyhelpers", contact.ID)
+	if err != nil {
+		t.Fatalf("GetContact failed: %v", err)
+	}
+	if got.Name != "Jane" || got.Email != contact.Email || got.Phone != contact.Phone || got.CreatedAt.IsZero() {
+		t.Errorf("expected %+v, got %+v", contact, got)
+	}
+
+	if _, err := store.GetContact(ctx, "stlpartyhelpers", "ct_missing"); !errors.Is(err, ports.ErrCRMContactNotFound) {
+		t.Errorf("expected ErrCRMContactNotFound, got %v", err)
+	}
+	if _, err := store.GetContact(ctx, "otherbusiness", contact.ID); !errors.Is(err, ports.ErrCRMContactNotFound) {
+		t.Errorf("expected ErrCRMContactNotFound for another business, got %v", err)
+	}
+}
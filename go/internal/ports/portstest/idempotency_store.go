@@ -0,0 +1,74 @@
+package portstest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// RunIdempotencyStoreTests runs the IdempotencyStore conformance suite.
+// newStore must return an empty store for each call.
+func RunIdempotencyStoreTests(t *testing.T, newStore func(t *testing.T) ports.IdempotencyStore) {
+	t.Run("BeginCompleteReplay", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+
+		mustBegin(t, store, "form-events:key-1", nil)
+		if _, err := store.Begin(ctx, "form-events:key-1", time.Minute); !errors.Is(err, ports.ErrIdempotencyKeyInFlight) {
+			t.Fatalf("expected ErrIdempotencyKeyInFlight, got %v", err)
+		}
+		mustBegin(t, store, "form-events:key-2", nil)
+
+		response := &ports.IdempotentResponse{StatusCode: 200, Body: []byte(`{"success":true}`)}
+		if err := store.Complete(ctx, "form-events:key-1", response, time.Hour); err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+		mustBegin(t, store, "form-events:key-1", response)
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		store := newStore(t)
+
+		mustBegin(t, store, "form-events:key-1", nil)
+		if err := store.Release(context.Background(), "form-events:key-1"); err != nil {
+			t.Fatalf("Release failed: %v", err)
+		}
+		mustBegin(t, store, "form-events:key-1", nil)
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+
+		// An abandoned claim lapses after its lease
+		if _, err := store.Begin(ctx, "form-events:abandoned", 50*time.Millisecond); err != nil {
+			t.Fatalf("Begin failed: %v", err)
+		}
+
+		// A stored response is forgotten after its TTL
+		mustBegin(t, store, "form-events:completed", nil)
+		response := &ports.IdempotentResponse{StatusCode: 202, Body: []byte(`{"status":"pending"}`)}
+		if err := store.Complete(ctx, "form-events:completed", response, 50*time.Millisecond); err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		mustBegin(t, store, "form-events:abandoned", nil)
+		mustBegin(t, store, "form-events:completed", nil)
+	})
+}
+
+func mustBegin(t *testing.T, store ports.IdempotencyStore, key string, want *ports.IdempotentResponse) {
+	t.Helper()
+	got, err := store.Begin(context.Background(), key, time.Minute)
+	if err != nil {
+		t.Fatalf("Begin(%s) failed: %v", key, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Begin(%s) = %+v, want %+v", key, got, want)
+	}
+}
@@ -0,0 +1,364 @@
+// Package portstest provides conformance test suites shared by every
+// implementation of the ports interfaces.
+package portstest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// RunJobsRepoTests runs the JobsRepo conformance suite. newRepo must return an
+// empty repository for each call.
+func RunJobsRepoTests(t *testing.T, newRepo func(t *testing.T) ports.JobsRepo) {
+	t.Run("SaveAndGetByID", func(t *testing.T) {
+		testJobsRepoSaveAndGet(t, newRepo(t))
+	})
+	t.Run("GetByIDNotFound", func(t *testing.T) {
+		testJobsRepoNotFound(t, newRepo(t))
+	})
+	t.Run("SaveOverwritesProgress", func(t *testing.T) {
+		testJobsRepoOverwrite(t, newRepo(t))
+	})
+	t.Run("CompareAndSetStatus", func(t *testing.T) {
+		testJobsRepoCompareAndSetStatus(t, newRepo(t))
+	})
+	t.Run("GetByBusinessID", func(t *testing.T) {
+		testJobsRepoGetByBusinessID(t, newRepo(t))
+	})
+	t.Run("ListFilters", func(t *testing.T) {
+		testJobsRepoListFilters(t, newRepo(t))
+	})
+	t.Run("ListPagination", func(t *testing.T) {
+		testJobsRepoListPagination(t, newRepo(t))
+	})
+	t.Run("ListInvalidCursor", func(t *testing.T) {
+		testJobsRepoInvalidCursor(t, newRepo(t))
+	})
+}
+
+// baseTime is a fixed, microsecond-aligned time so every store can round-trip it
+var baseTime = time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+
+func newJob(id, businessID, pipelineKey, status string, createdAt time.Time) *domain.Job {
+	errMsg := "stripe: card declined"
+	boardID, itemID := int64(123456789), int64(987654321)
+	return &domain.Job{
+		ID:          id,
+		BusinessID:  businessID,
+		PipelineKey: pipelineKey,
+		Status:      status,
+		Steps: []domain.JobStep{
+			{
+				Name:     "normalize_input",
+				Status:   "ok",
+				Critical: true,
+				Details:  map[string]any{"message": "input normalized"},
+				Outputs:  map[string]any{"email": "jane@example.com"},
+				Config:   map[string]any{"channel": "#leads"},
+				// Timing is kept to the millisecond, which every backend stores
+				StartedAt:  createdAt.Add(time.Millisecond),
+				FinishedAt: createdAt.Add(1500 * time.Millisecond),
+				DurationMs: 1499,
+				TimeoutMs:  15000,
+				SideEffects: []domain.SideEffect{
+					{Kind: "email", Summary: "Send quote to jane@example.com", Details: map[string]any{"to": "jane@example.com"}},
+				},
+			},
+			{Name: "create_deposit_invoice", Status: "failed", Error: &errMsg, Permanent: true},
+			{
+				Name:   "call_pipeline",
+				Status: "ok",
+				SubJob: &domain.Job{
+					ID:          id + "/notify_team",
+					BusinessID:  businessID,
+					PipelineKey: "notify_team",
+					Status:      "completed",
+					Steps:       []domain.JobStep{{Name: "send_slack_notification", Status: "ok"}},
+					CreatedAt:   createdAt,
+				},
+			},
+		},
+		Input:     map[string]any{"email": "jane@example.com", "numHelpers": float64(3)},
+		Result:    map[string]any{},
+		CreatedAt: createdAt,
+		Source:    "form",
+		DryRun:    true,
+		Options:   map[string]any{"payWithCheck": true},
+		Resource:  &domain.ResourceContext{Type: "monday_item", BoardID: &boardID, ItemID: &itemID},
+		ReplayOf:  "job-0",
+	}
+}
+
+func mustSave(t *testing.T, repo ports.JobsRepo, job *domain.Job) {
+	t.Helper()
+	if err := repo.Save(context.Background(), job); err != nil {
+		t.Fatalf("Save(%s) failed: %v", job.ID, err)
+	}
+}
+
+func jobIDs(jobs []*domain.Job) []string {
+	ids := make([]string, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	return ids
+}
+
+func assertIDs(t *testing.T, got []*domain.Job, want ...string) {
+	t.Helper()
+	ids := jobIDs(got)
+	if len(ids) != len(want) {
+		t.Fatalf("expected jobs %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected jobs %v, got %v", want, ids)
+		}
+	}
+}
+
+func testJobsRepoSaveAndGet(t *testing.T, repo ports.JobsRepo) {
+	ctx := context.Background()
+	job := newJob("job-1", "stlpartyhelpers", "quote_and_deposit", "failed", baseTime)
+	mustSave(t, repo, job)
+
+	got, err := repo.GetByID(ctx, "job-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.BusinessID != "stlpartyhelpers" || got.PipelineKey != "quote_and_deposit" || got.Status != "failed" {
+		t.Errorf("unexpected job fields: %+v", got)
+	}
+	if !got.CreatedAt.Equal(baseTime) {
+		t.Errorf("expected createdAt %v, got %v", baseTime, got.CreatedAt)
+	}
+	if got.UpdatedAt.IsZero() {
+		t.Error("expected updatedAt to be set")
+	}
+	if got.Input["email"] != "jane@example.com" {
+		t.Errorf("expected input to round-trip, got %v", got.Input)
+	}
+	if got.Source != "form" || !got.DryRun || got.Options["payWithCheck"] != true || got.ReplayOf != "job-0" {
+		t.Errorf("expected request context to round-trip, got source=%q dryRun=%v options=%v replayOf=%q", got.Source, got.DryRun, got.Options, got.ReplayOf)
+	}
+	if got.Resource == nil || got.Resource.Type != "monday_item" || got.Resource.ItemID == nil || *got.Resource.ItemID != 987654321 {
+		t.Errorf("expected resource to round-trip, got %+v", got.Resource)
+	}
+	if len(got.Steps) != 3 {
+		t.Fatalf("expected 3 steps, got %d", len(got.Steps))
+	}
+	if got.Steps[0].Name != "normalize_input" || !got.Steps[0].Critical || got.Steps[0].Details["message"] != "input normalized" {
+		t.Errorf("unexpected first step: %+v", got.Steps[0])
+	}
+	if got.Steps[0].Outputs["email"] != "jane@example.com" || got.Steps[0].Config["channel"] != "#leads" {
+		t.Errorf("expected step outputs and config to round-trip, got %+v", got.Steps[0])
+	}
+	if step := got.Steps[0]; !step.StartedAt.Equal(baseTime.Add(time.Millisecond)) || !step.FinishedAt.Equal(baseTime.Add(1500*time.Millisecond)) ||
+		step.DurationMs != 1499 || step.TimeoutMs != 15000 {
+		t.Errorf("expected step timing to round-trip, got %+v", step)
+	}
+	if effects := got.Steps[0].SideEffects; len(effects) != 1 || effects[0].Kind != "email" || effects[0].Details["to"] != "jane@example.com" {
+		t.Errorf("expected step side effects to round-trip, got %+v", effects)
+	}
+	if step := got.Steps[1]; !step.StartedAt.IsZero() || !step.FinishedAt.IsZero() || step.DurationMs != 0 {
+		t.Errorf("expected an untimed step to stay untimed, got %+v", step)
+	}
+	if got.Steps[1].Error == nil || *got.Steps[1].Error != "stripe: card declined" || !got.Steps[1].Permanent {
+		t.Errorf("unexpected second step: %+v", got.Steps[1])
+	}
+	if sub := got.Steps[2].SubJob; sub == nil || sub.ID != "job-1/notify_team" || sub.Status != "completed" ||
+		len(sub.Steps) != 1 || sub.Steps[0].Name != "send_slack_notification" || !sub.CreatedAt.Equal(baseTime) {
+		t.Errorf("expected the nested job to round-trip, got %+v", sub)
+	}
+
+	// Changes to the caller's job after saving must not leak into the store
+	job.Status = "completed"
+	got, err = repo.GetByID(ctx, "job-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Status != "failed" {
+		t.Errorf("expected stored status to stay failed, got %s", got.Status)
+	}
+
+	// A job saved without createdAt gets one
+	fresh := newJob("job-2", "stlpartyhelpers", "deposit_only", "pending", time.Time{})
+	mustSave(t, repo, fresh)
+	got, err = repo.GetByID(ctx, "job-2")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.CreatedAt.IsZero() {
+		t.Error("expected createdAt to be set on save")
+	}
+}
+
+func testJobsRepoNotFound(t *testing.T, repo ports.JobsRepo) {
+	_, err := repo.GetByID(context.Background(), "missing")
+	if !errors.Is(err, ports.ErrJobNotFound) {
+		t.Fatalf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func testJobsRepoOverwrite(t *testing.T, repo ports.JobsRepo) {
+	job := newJob("job-1", "stlpartyhelpers", "quote_and_deposit", "running", baseTime)
+	job.Steps = job.Steps[:1]
+	mustSave(t, repo, job)
+
+	job = newJob("job-1", "stlpartyhelpers", "quote_and_deposit", "completed", baseTime)
+	mustSave(t, repo, job)
+
+	got, err := repo.GetByID(context.Background(), "job-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Status != "completed" || len(got.Steps) != 3 {
+		t.Errorf("expected latest save to win, got status=%s steps=%d", got.Status, len(got.Steps))
+	}
+}
+
+func testJobsRepoCompareAndSetStatus(t *testing.T, repo ports.JobsRepo) {
+	ctx := context.Background()
+	mustSave(t, repo, newJob("job-1", "stlpartyhelpers", "quote_and_deposit", "failed", baseTime))
+
+	swapped, err := repo.CompareAndSetStatus(ctx, "job-1", "failed", "running")
+	if err != nil || !swapped {
+		t.Fatalf("expected the first claim to succeed, got %v %v", swapped, err)
+	}
+	swapped, err = repo.CompareAndSetStatus(ctx, "job-1", "failed", "running")
+	if err != nil || swapped {
+		t.Fatalf("expected the second claim to fail, got %v %v", swapped, err)
+	}
+
+	got, err := repo.GetByID(ctx, "job-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Status != "running" || len(got.Steps) != 3 {
+		t.Errorf("expected only the status to change, got status=%s steps=%d", got.Status, len(got.Steps))
+	}
+
+	if _, err := repo.CompareAndSetStatus(ctx, "missing", "failed", "running"); !errors.Is(err, ports.ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func testJobsRepoGetByBusinessID(t *testing.T, repo ports.JobsRepo) {
+	mustSave(t, repo, newJob("a", "stlpartyhelpers", "quote_and_deposit", "completed", baseTime))
+	mustSave(t, repo, newJob("b", "stlpartyhelpers", "quote_and_deposit", "completed", baseTime.Add(time.Hour)))
+	mustSave(t, repo, newJob("c", "otherbusiness", "quote_and_deposit", "completed", baseTime.Add(2*time.Hour)))
+	mustSave(t, repo, newJob("d", "stlpartyhelpers", "deposit_only", "failed", baseTime.Add(3*time.Hour)))
+
+	jobs, err := repo.GetByBusinessID(context.Background(), "stlpartyhelpers", 2)
+	if err != nil {
+		t.Fatalf("GetByBusinessID failed: %v", err)
+	}
+	assertIDs(t, jobs, "d", "b")
+}
+
+func testJobsRepoListFilters(t *testing.T, repo ports.JobsRepo) {
+	ctx := context.Background()
+	mustSave(t, repo, newJob("a", "stlpartyhelpers", "quote_and_deposit", "completed", baseTime))
+	mustSave(t, repo, newJob("b", "stlpartyhelpers", "quote_and_deposit", "failed", baseTime.Add(24*time.Hour)))
+	mustSave(t, repo, newJob("c", "stlpartyhelpers", "deposit_only", "failed", baseTime.Add(48*time.Hour)))
+	mustSave(t, repo, newJob("d", "otherbusiness", "quote_and_deposit", "failed", baseTime.Add(72*time.Hour)))
+
+	tests := []struct {
+		name  string
+		query ports.JobQuery
+		want  []string
+	}{
+		{
+			name:  "business only",
+			query: ports.JobQuery{BusinessID: "stlpartyhelpers"},
+			want:  []string{"c", "b", "a"},
+		},
+		{
+			name:  "status",
+			query: ports.JobQuery{BusinessID: "stlpartyhelpers", Status: "failed"},
+			want:  []string{"c", "b"},
+		},
+		{
+			name:  "pipeline key",
+			query: ports.JobQuery{BusinessID: "stlpartyhelpers", PipelineKey: "quote_and_deposit"},
+			want:  []string{"b", "a"},
+		},
+		{
+			name:  "created range",
+			query: ports.JobQuery{BusinessID: "stlpartyhelpers", CreatedAfter: baseTime.Add(24 * time.Hour), CreatedBefore: baseTime.Add(48 * time.Hour)},
+			want:  []string{"b"},
+		},
+		{
+			name:  "all filters",
+			query: ports.JobQuery{BusinessID: "stlpartyhelpers", Status: "failed", PipelineKey: "deposit_only", CreatedAfter: baseTime},
+			want:  []string{"c"},
+		},
+		{
+			name:  "no match",
+			query: ports.JobQuery{BusinessID: "stlpartyhelpers", Status: "pending"},
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.List(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			assertIDs(t, page.Jobs, tt.want...)
+			if page.NextCursor != "" {
+				t.Errorf("expected no next cursor, got %q", page.NextCursor)
+			}
+		})
+	}
+}
+
+func testJobsRepoListPagination(t *testing.T, repo ports.JobsRepo) {
+	ctx := context.Background()
+	// j1 and j2 share a timestamp to exercise the ID tiebreaker
+	mustSave(t, repo, newJob("j1", "stlpartyhelpers", "quote_and_deposit", "completed", baseTime))
+	mustSave(t, repo, newJob("j2", "stlpartyhelpers", "quote_and_deposit", "completed", baseTime))
+	mustSave(t, repo, newJob("j3", "stlpartyhelpers", "quote_and_deposit", "completed", baseTime.Add(time.Minute)))
+	mustSave(t, repo, newJob("j4", "stlpartyhelpers", "quote_and_deposit", "completed", baseTime.Add(2*time.Minute)))
+	mustSave(t, repo, newJob("j5", "stlpartyhelpers", "quote_and_deposit", "completed", baseTime.Add(3*time.Minute)))
+
+	var all []string
+	query := ports.JobQuery{BusinessID: "stlpartyhelpers", Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("pagination did not terminate")
+		}
+		page, err := repo.List(ctx, query)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		all = append(all, jobIDs(page.Jobs)...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	want := []string{"j5", "j4", "j3", "j2", "j1"}
+	if len(all) != len(want) {
+		t.Fatalf("expected %v across pages, got %v", want, all)
+	}
+	for i := range want {
+		if all[i] != want[i] {
+			t.Fatalf("expected %v across pages, got %v", want, all)
+		}
+	}
+}
+
+func testJobsRepoInvalidCursor(t *testing.T, repo ports.JobsRepo) {
+	_, err := repo.List(context.Background(), ports.JobQuery{BusinessID: "stlpartyhelpers", Cursor: "not a cursor!"})
+	if !errors.Is(err, ports.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
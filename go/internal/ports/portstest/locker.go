@@ -0,0 +1,62 @@
+package portstest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// RunLockerTests runs the Locker conformance suite. newLocker must return a
+// locker with no locks held for each call.
+func RunLockerTests(t *testing.T, newLocker func(t *testing.T) ports.Locker) {
+	t.Run("TryLockOnce", func(t *testing.T) {
+		locker := newLocker(t)
+		ctx := context.Background()
+
+		mustTryLock(t, locker, "schedule:stlpartyhelpers:weekly:1", time.Hour, true)
+		mustTryLock(t, locker, "schedule:stlpartyhelpers:weekly:1", time.Hour, false)
+		mustTryLock(t, locker, "schedule:stlpartyhelpers:weekly:2", time.Hour, true)
+
+		if _, err := locker.TryLock(ctx, "schedule:otherbusiness:weekly:1", time.Hour); err != nil {
+			t.Fatalf("TryLock failed: %v", err)
+		}
+	})
+
+	t.Run("TryLockAfterExpiry", func(t *testing.T) {
+		locker := newLocker(t)
+
+		mustTryLock(t, locker, "timer:job-1", 50*time.Millisecond, true)
+		time.Sleep(100 * time.Millisecond)
+		mustTryLock(t, locker, "timer:job-1", time.Hour, true)
+		mustTryLock(t, locker, "timer:job-1", time.Hour, false)
+	})
+
+	t.Run("Unlock", func(t *testing.T) {
+		locker := newLocker(t)
+		ctx := context.Background()
+
+		mustTryLock(t, locker, "timer:job-1", time.Hour, true)
+		if err := locker.Unlock(ctx, "timer:job-1"); err != nil {
+			t.Fatalf("Unlock failed: %v", err)
+		}
+		mustTryLock(t, locker, "timer:job-1", time.Hour, true)
+		mustTryLock(t, locker, "timer:job-1", time.Hour, false)
+
+		if err := locker.Unlock(ctx, "timer:never-locked"); err != nil {
+			t.Fatalf("Unlock of a free lock failed: %v", err)
+		}
+	})
+}
+
+func mustTryLock(t *testing.T, locker ports.Locker, key string, ttl time.Duration, want bool) {
+	t.Helper()
+	got, err := locker.TryLock(context.Background(), key, ttl)
+	if err != nil {
+		t.Fatalf("TryLock(%s) failed: %v", key, err)
+	}
+	if got != want {
+		t.Fatalf("TryLock(%s) = %v, want %v", key, got, want)
+	}
+}
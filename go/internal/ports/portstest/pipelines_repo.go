@@ -0,0 +1,110 @@
+package portstest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// RunPipelinesRepoTests runs the PipelinesRepo conformance suite. newRepo
+// must return an empty repository for each call that accepts pipelines using
+// the normalize_input and send_slack_notification actions.
+func RunPipelinesRepoTests(t *testing.T, newRepo func(t *testing.T) ports.PipelinesRepo) {
+	t.Run("SaveAndGetByKey", func(t *testing.T) {
+		testPipelinesRepoSaveAndGet(t, newRepo(t))
+	})
+	t.Run("GetByKeyNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.GetByKey(context.Background(), "missing"); !errors.Is(err, ports.ErrPipelineNotFound) {
+			t.Fatalf("expected ErrPipelineNotFound, got %v", err)
+		}
+	})
+	t.Run("GetAll", func(t *testing.T) {
+		testPipelinesRepoGetAll(t, newRepo(t))
+	})
+}
+
+func newPipeline(key string) *domain.PipelineDefinition {
+	return &domain.PipelineDefinition{
+		Key:         key,
+		Description: "Normalize a lead and notify the team",
+		Actions: []domain.ActionDefinition{
+			{
+				Name:     "normalize_input",
+				Critical: true,
+				Timeout:  10 * time.Second,
+				Retry: &domain.RetryPolicy{
+					MaxAttempts:    3,
+					InitialBackoff: time.Second,
+					MaxBackoff:     5 * time.Second,
+					RetryOn:        []string{"timeout"},
+				},
+			},
+			{
+				Parallel: &domain.ParallelGroup{
+					MaxConcurrency: 2,
+					Actions: []domain.ActionDefinition{
+						{Name: "send_slack_notification", When: `source == "form"`, Config: map[string]any{"channel": "#leads"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testPipelinesRepoSaveAndGet(t *testing.T, repo ports.PipelinesRepo) {
+	ctx := context.Background()
+	pipeline := newPipeline("intake")
+	if err := repo.Save(ctx, pipeline); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := repo.GetByKey(ctx, "intake")
+	if err != nil {
+		t.Fatalf("GetByKey failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, pipeline) {
+		t.Errorf("pipeline did not round-trip:\n got %+v\nwant %+v", got, pipeline)
+	}
+
+	// Save replaces the pipeline
+	updated := newPipeline("intake")
+	updated.Actions = updated.Actions[:1]
+	updated.Actions[0].Critical = false
+	if err := repo.Save(ctx, updated); err != nil {
+		t.Fatalf("Save (update) failed: %v", err)
+	}
+	got, err = repo.GetByKey(ctx, "intake")
+	if err != nil {
+		t.Fatalf("GetByKey after update failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, updated) {
+		t.Errorf("update was not saved:\n got %+v\nwant %+v", got, updated)
+	}
+}
+
+func testPipelinesRepoGetAll(t *testing.T, repo ports.PipelinesRepo) {
+	ctx := context.Background()
+	for _, key := range []string{"quote_and_deposit", "intake"} {
+		if err := repo.Save(ctx, newPipeline(key)); err != nil {
+			t.Fatalf("Save(%s) failed: %v", key, err)
+		}
+	}
+
+	pipelines, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	var keys []string
+	for _, pipeline := range pipelines {
+		keys = append(keys, pipeline.Key)
+	}
+	if !reflect.DeepEqual(keys, []string{"intake", "quote_and_deposit"}) {
+		t.Errorf("expected pipelines intake and quote_and_deposit in order, got %v", keys)
+	}
+}
@@ -0,0 +1,148 @@
+package portstest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+	"github.com/bizops360/go-api/internal/ports"
+)
+
+// RunTimersRepoTests runs the TimersRepo conformance suite. newRepo must return
+// an empty repository for each call.
+func RunTimersRepoTests(t *testing.T, newRepo func(t *testing.T) ports.TimersRepo) {
+	t.Run("SaveAndGetByID", func(t *testing.T) {
+		testTimersRepoSaveAndGet(t, newRepo(t))
+	})
+	t.Run("GetByIDNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.GetByID(context.Background(), "tmr_missing"); !errors.Is(err, ports.ErrTimerNotFound) {
+			t.Fatalf("expected ErrTimerNotFound, got %v", err)
+		}
+	})
+	t.Run("ListByLead", func(t *testing.T) {
+		testTimersRepoListByLead(t, newRepo(t))
+	})
+	t.Run("ListDue", func(t *testing.T) {
+		testTimersRepoListDue(t, newRepo(t))
+	})
+}
+
+func newTimer(businessID, leadID, key string, fireAt time.Time) *domain.Timer {
+	return &domain.Timer{
+		ID:         domain.TimerID(businessID, leadID, key),
+		BusinessID: businessID,
+		LeadID:     leadID,
+		Key:        key,
+		TriggerKey: "resend_deposit_link",
+		EventDate:  "2026-03-14",
+		OffsetDays: -3,
+		At:         "10:00",
+		FireAt:     fireAt,
+		Payload:    map[string]any{"template": "deposit_reminder"},
+		Status:     domain.TimerStatusScheduled,
+	}
+}
+
+func mustSaveTimer(t *testing.T, repo ports.TimersRepo, timer *domain.Timer) {
+	t.Helper()
+	if err := repo.Save(context.Background(), timer); err != nil {
+		t.Fatalf("Save(%s) failed: %v", timer.ID, err)
+	}
+}
+
+func timerKeys(timers []*domain.Timer) []string {
+	keys := []string{}
+	for _, timer := range timers {
+		keys = append(keys, timer.Key)
+	}
+	return keys
+}
+
+func testTimersRepoSaveAndGet(t *testing.T, repo ports.TimersRepo) {
+	ctx := context.Background()
+	timer := newTimer("stlpartyhelpers", "jane@example.com", "deposit_reminder", baseTime)
+	mustSaveTimer(t, repo, timer)
+
+	got, err := repo.GetByID(ctx, timer.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+		t.Errorf("expected timestamps to be set, got %+v", got)
+	}
+	if !got.FireAt.Equal(timer.FireAt) {
+		t.Errorf("expected fireAt %v, got %v", timer.FireAt, got.FireAt)
+	}
+	got.FireAt, got.CreatedAt, got.UpdatedAt = timer.FireAt, timer.CreatedAt, timer.UpdatedAt
+	if !reflect.DeepEqual(got, timer) {
+		t.Errorf("expected %+v, got %+v", timer, got)
+	}
+
+	// Saving again replaces the timer
+	timer.Status = domain.TimerStatusFired
+	timer.JobID = timer.ID + "_1773504000"
+	mustSaveTimer(t, repo, timer)
+	got, err = repo.GetByID(ctx, timer.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Status != domain.TimerStatusFired || got.JobID != timer.JobID {
+		t.Errorf("expected the saved timer to be replaced, got %+v", got)
+	}
+}
+
+func testTimersRepoListByLead(t *testing.T, repo ports.TimersRepo) {
+	ctx := context.Background()
+	mustSaveTimer(t, repo, newTimer("stlpartyhelpers", "jane@example.com", "review_request", baseTime.Add(96*time.Hour)))
+	mustSaveTimer(t, repo, newTimer("stlpartyhelpers", "jane@example.com", "deposit_reminder", baseTime))
+	mustSaveTimer(t, repo, newTimer("stlpartyhelpers", "john@example.com", "deposit_reminder", baseTime))
+	mustSaveTimer(t, repo, newTimer("otherbusiness", "jane@example.com", "deposit_reminder", baseTime))
+
+	timers, err := repo.ListByLead(ctx, "stlpartyhelpers", "jane@example.com")
+	if err != nil {
+		t.Fatalf("ListByLead failed: %v", err)
+	}
+	if got, want := timerKeys(timers), []string{"deposit_reminder", "review_request"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	timers, err = repo.ListByLead(ctx, "stlpartyhelpers", "nobody@example.com")
+	if err != nil {
+		t.Fatalf("ListByLead failed: %v", err)
+	}
+	if len(timers) != 0 {
+		t.Errorf("expected no timers, got %v", timerKeys(timers))
+	}
+}
+
+func testTimersRepoListDue(t *testing.T, repo ports.TimersRepo) {
+	ctx := context.Background()
+	mustSaveTimer(t, repo, newTimer("stlpartyhelpers", "jane@example.com", "later", baseTime.Add(time.Hour)))
+	mustSaveTimer(t, repo, newTimer("stlpartyhelpers", "jane@example.com", "second", baseTime))
+	mustSaveTimer(t, repo, newTimer("stlpartyhelpers", "jane@example.com", "first", baseTime.Add(-time.Hour)))
+	mustSaveTimer(t, repo, newTimer("stlpartyhelpers", "jane@example.com", "third", baseTime.Add(-time.Minute)))
+
+	cancelled := newTimer("stlpartyhelpers", "jane@example.com", "cancelled", baseTime.Add(-2*time.Hour))
+	cancelled.Status = domain.TimerStatusCancelled
+	mustSaveTimer(t, repo, cancelled)
+
+	timers, err := repo.ListDue(ctx, baseTime, 10)
+	if err != nil {
+		t.Fatalf("ListDue failed: %v", err)
+	}
+	if got, want := timerKeys(timers), []string{"first", "third", "second"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	timers, err = repo.ListDue(ctx, baseTime, 2)
+	if err != nil {
+		t.Fatalf("ListDue failed: %v", err)
+	}
+	if got, want := timerKeys(timers), []string{"first", "third"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v with limit 2, got %v", want, got)
+	}
+}
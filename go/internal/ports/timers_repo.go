@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bizops360/go-api/internal/domain"
+)
+
+// ErrTimerNotFound is returned when a timer does not exist
+var ErrTimerNotFound = errors.New("timer not found")
+
+// TimersRepo defines the interface for durable event-relative timer storage
+type TimersRepo interface {
+	// Save inserts or replaces a timer
+	Save(ctx context.Context, timer *domain.Timer) error
+	GetByID(ctx context.Context, id string) (*domain.Timer, error)
+	// ListByLead returns every timer of a lead, soonest first
+	ListByLead(ctx context.Context, businessID, leadID string) ([]*domain.Timer, error)
+	// ListDue returns up to limit scheduled timers whose FireAt is not after
+	// now, soonest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Timer, error)
+}
@@ -145,6 +145,11 @@ func (s *InvoiceService) SendInvoice(ctx context.Context, invoiceID string, useT
 	return s.paymentsProvider.SendInvoice(ctx, invoiceID, useTest)
 }
 
+// VoidInvoice voids a finalized invoice so it can no longer be paid
+func (s *InvoiceService) VoidInvoice(ctx context.Context, invoiceID string, useTest bool) error {
+	return s.paymentsProvider.VoidInvoice(ctx, invoiceID, useTest)
+}
+
 // CreateDepositInvoiceRequest contains data needed to create a deposit invoice
 type CreateDepositInvoiceRequest struct {
 	CustomerEmail    string